@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"log"
 
 	"github.com/gofiber/fiber/v2"
@@ -11,8 +12,10 @@ import (
 	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/config"
 	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/database"
 	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
 	authHandler "github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/handler/http"
 	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/middleware"
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/notify"
 	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/repository/postgres"
 	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/service"
 )
@@ -32,6 +35,7 @@ func main() {
 		&entities.User{},
 		&entities.RefreshToken{},
 		&entities.Task{},
+		&entities.TaskSeries{},
 		&entities.Routine{},
 		&entities.RoutineCompletion{},
 		&entities.Event{},
@@ -40,10 +44,36 @@ func main() {
 		&entities.TechStackItem{},
 		&entities.Project{},
 		&entities.ProjectTask{},
+		&entities.CalendarFeedToken{},
+		&entities.ReminderRule{},
+		&entities.ReminderDelivery{},
+		&entities.PushSubscription{},
+		&entities.Domain{},
+		&entities.Tag{},
+		&entities.TaskTag{},
+		&entities.TaskDependency{},
+		&entities.TaskStatusChange{},
+		&entities.TimeEntry{},
+		&entities.TaskRank{},
+		&entities.ProjectWIPLimit{},
 	); err != nil {
 		log.Fatal("Failed to run migrations:", err)
 	}
 
+	// Convert legacy data to the current schema
+	if err := database.MigrateEventRecurrence(db); err != nil {
+		log.Fatal("Failed to run migrations:", err)
+	}
+	if err := database.MigrateEventTimezones(db); err != nil {
+		log.Fatal("Failed to run migrations:", err)
+	}
+	if err := database.MigrateDomains(db); err != nil {
+		log.Fatal("Failed to run migrations:", err)
+	}
+	if err := database.MigrateTaskCompletedAt(db); err != nil {
+		log.Fatal("Failed to run migrations:", err)
+	}
+
 	// Initialize Repositories (Data Layer)
 	userRepo := postgres.NewUserRepository(db)
 	tokenRepo := postgres.NewTokenRepository(db)
@@ -53,15 +83,52 @@ func main() {
 	categoryRepo := postgres.NewCategoryRepository(db)
 	techStackRepo := postgres.NewTechStackItemRepository(db)
 	projectRepo := postgres.NewProjectRepository(db)
+	feedTokenRepo := postgres.NewCalendarFeedTokenRepository(db)
+	reminderRepo := postgres.NewReminderRepository(db)
+	pushRepo := postgres.NewPushSubscriptionRepository(db)
+	domainRepo := postgres.NewDomainRepository(db)
+	tagRepo := postgres.NewTagRepository(db)
+	timeEntryRepo := postgres.NewTimeEntryRepository(db)
+
+	// Initialize Notification Channels (reminder delivery)
+	channels := []interfaces.NotificationChannel{
+		notify.NewLogChannel(),
+		notify.NewEmailChannel(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPFrom),
+		notify.NewWebhookChannel(),
+	}
+	vapidPublicKey := ""
+	if cfg.VAPIDPrivateKey != "" {
+		webPush, err := notify.NewWebPushChannel(pushRepo, cfg.VAPIDPrivateKey, cfg.VAPIDSubject)
+		if err != nil {
+			log.Fatal("Failed to configure Web Push:", err)
+		}
+		channels = append(channels, webPush)
+		vapidPublicKey, _ = notify.VAPIDPublicKey(cfg.VAPIDPrivateKey)
+	} else {
+		log.Println("VAPID_PRIVATE_KEY not set, Web Push reminders are disabled")
+	}
 
 	// Initialize Services (Business Logic Layer)
 	authService := service.NewAuthService(userRepo, tokenRepo, cfg.JWTSecret)
-	taskService := service.NewTaskService(taskRepo)
-	routineService := service.NewRoutineService(routineRepo)
-	eventService := service.NewEventService(eventRepo)
+	taskService := service.NewTaskService(taskRepo, domainRepo, userRepo, tagRepo)
+	routineService := service.NewRoutineService(routineRepo, userRepo)
+	eventService := service.NewEventService(eventRepo, userRepo, domainRepo)
 	categoryService := service.NewCategoryService(categoryRepo, techStackRepo)
 	techStackService := service.NewTechStackService(techStackRepo, categoryRepo)
 	projectService := service.NewProjectService(projectRepo, taskRepo)
+	calendarFeedService := service.NewCalendarFeedService(feedTokenRepo, eventRepo)
+	calendarImportService := service.NewCalendarImportService(eventService, eventRepo, domainRepo)
+	freeBusyService := service.NewFreeBusyService(eventService, userRepo)
+	reminderService := service.NewReminderService(reminderRepo, pushRepo, eventRepo, taskRepo, routineRepo, channels, vapidPublicKey)
+	domainService := service.NewDomainService(domainRepo)
+	tagService := service.NewTagService(tagRepo)
+	agendaService := service.NewAgendaService(eventService, taskRepo, routineService, userRepo)
+	quickAddService := service.NewQuickAddService(taskService, eventService, domainRepo, userRepo)
+	timeEntryService := service.NewTimeEntryService(timeEntryRepo, taskRepo, projectRepo, userRepo)
+	trashService := service.NewTrashService(taskRepo, routineRepo, projectRepo, eventRepo, cfg.TrashRetentionDays)
+	boardService := service.NewBoardService(taskRepo, projectRepo, taskService, projectService)
+	reminderScheduler := service.NewReminderScheduler(reminderRepo, eventRepo, taskRepo, routineRepo, userRepo, eventService, routineService, channels)
+	trashPurger := service.NewTrashPurger(trashService)
 
 	// Initialize Handlers (HTTP Layer)
 	isDev := cfg.Environment == "development"
@@ -72,6 +139,17 @@ func main() {
 	categoryHdl := authHandler.NewCategoryHandler(categoryService)
 	techStackHdl := authHandler.NewTechStackHandler(techStackService)
 	projectHdl := authHandler.NewProjectHandler(projectService)
+	calendarFeedHdl := authHandler.NewCalendarFeedHandler(calendarFeedService)
+	calendarImportHdl := authHandler.NewCalendarImportHandler(calendarImportService)
+	freeBusyHdl := authHandler.NewFreeBusyHandler(freeBusyService)
+	reminderHdl := authHandler.NewReminderHandler(reminderService)
+	domainHdl := authHandler.NewDomainHandler(domainService)
+	agendaHdl := authHandler.NewAgendaHandler(agendaService)
+	tagHdl := authHandler.NewTagHandler(tagService)
+	quickAddHdl := authHandler.NewQuickAddHandler(quickAddService)
+	timeEntryHdl := authHandler.NewTimeEntryHandler(timeEntryService)
+	trashHdl := authHandler.NewTrashHandler(trashService)
+	boardHdl := authHandler.NewBoardHandler(boardService)
 
 	// Initialize Fiber app
 	app := fiber.New(fiber.Config{
@@ -111,6 +189,9 @@ func main() {
 	api.Get("/status", authHdl.GetStatus)
 	api.Post("/setup", middleware.AuthRateLimiter(), authHdl.Setup)
 
+	// Public calendar feed (authenticated by the secret token in the URL, calendar clients cannot send cookies)
+	api.Get("/calendar/feed/:token.ics", middleware.APIRateLimiter(), calendarFeedHdl.GetFeed) // GET /api/calendar/feed/:token.ics
+
 	// Auth routes
 	auth := api.Group("/auth")
 	auth.Post("/login", middleware.AuthRateLimiter(), authHdl.Login)
@@ -119,34 +200,61 @@ func main() {
 	// Protected auth routes (require valid access token)
 	auth.Post("/logout", middleware.AuthMiddleware(authService), authHdl.Logout)
 	auth.Get("/me", middleware.AuthMiddleware(authService), authHdl.GetMe)
+	auth.Put("/settings", middleware.AuthMiddleware(authService), authHdl.UpdateSettings)
 
 	// Task routes (protected - require authentication)
 	tasks := api.Group("/tasks", middleware.AuthMiddleware(authService), middleware.APIRateLimiter())
-	tasks.Get("/", taskHdl.GetTasks)                     // GET /api/tasks (with optional filters)
-	tasks.Post("/", taskHdl.CreateTask)                  // POST /api/tasks
-	tasks.Get("/:id", taskHdl.GetTask)                   // GET /api/tasks/:id
-	tasks.Put("/:id", taskHdl.UpdateTask)                // PUT /api/tasks/:id
-	tasks.Patch("/:id/status", taskHdl.ToggleTaskStatus) // PATCH /api/tasks/:id/status
-	tasks.Delete("/:id", taskHdl.DeleteTask)             // DELETE /api/tasks/:id
+	tasks.Get("/", taskHdl.GetTasks)                                             // GET /api/tasks (with optional filters)
+	tasks.Post("/", taskHdl.CreateTask)                                          // POST /api/tasks
+	tasks.Get("/lists/:list", boardHdl.GetOrderedTasks)                          // GET /api/tasks/lists/:list?key=... (domain, project or status in manual order)
+	tasks.Get("/:id", taskHdl.GetTask)                                           // GET /api/tasks/:id
+	tasks.Put("/:id", taskHdl.UpdateTask)                                        // PUT /api/tasks/:id
+	tasks.Patch("/:id/status", taskHdl.ToggleTaskStatus)                         // PATCH /api/tasks/:id/status (?force=true completes open subtasks, open blockers only warn)
+	tasks.Put("/:id/status", taskHdl.SetTaskStatus)                              // PUT /api/tasks/:id/status (body with status, ?force=true)
+	tasks.Get("/:id/history", taskHdl.GetTaskHistory)                            // GET /api/tasks/:id/history (status changes, lead and cycle time)
+	tasks.Post("/:id/move", boardHdl.MoveTask)                                   // POST /api/tasks/:id/move (body with list, key, afterId, beforeId, ?force=true)
+	tasks.Put("/:id/subtasks/order", taskHdl.ReorderSubtasks)                    // PUT /api/tasks/:id/subtasks/order
+	tasks.Put("/:id/tags", taskHdl.SetTaskTags)                                  // PUT /api/tasks/:id/tags
+	tasks.Get("/:id/dependencies", taskHdl.GetTaskDependencies)                  // GET /api/tasks/:id/dependencies
+	tasks.Post("/:id/dependencies", taskHdl.AddTaskDependency)                   // POST /api/tasks/:id/dependencies (body with blockedById)
+	tasks.Delete("/:id/dependencies/:blockedById", taskHdl.RemoveTaskDependency) // DELETE /api/tasks/:id/dependencies/:blockedById
+	tasks.Delete("/:id", taskHdl.DeleteTask)                                     // DELETE /api/tasks/:id
+	tasks.Get("/series/:id", taskHdl.GetTaskSeries)                              // GET /api/tasks/series/:id
+	tasks.Put("/series/:id", taskHdl.UpdateTaskSeries)                           // PUT /api/tasks/series/:id (updates open and future instances)
+	tasks.Post("/series/:id/stop", taskHdl.StopTaskSeries)                       // POST /api/tasks/series/:id/stop (?deleteOpen=true)
 
 	// Routine routes (protected - require authentication)
 	routines := api.Group("/routines", middleware.AuthMiddleware(authService), middleware.APIRateLimiter())
-	routines.Get("/", routineHdl.GetRoutines)                   // GET /api/routines (with optional ?frequency=Daily)
-	routines.Get("/today", routineHdl.GetTodaysRoutines)        // GET /api/routines/today
-	routines.Post("/", routineHdl.CreateRoutine)                // POST /api/routines
-	routines.Get("/:id", routineHdl.GetRoutine)                 // GET /api/routines/:id
-	routines.Put("/:id", routineHdl.UpdateRoutine)              // PUT /api/routines/:id
-	routines.Patch("/:id/complete", routineHdl.CompleteRoutine) // PATCH /api/routines/:id/complete
-	routines.Patch("/:id/skip", routineHdl.SkipRoutine)         // PATCH /api/routines/:id/skip
-	routines.Delete("/:id", routineHdl.DeleteRoutine)           // DELETE /api/routines/:id
+	routines.Get("/", routineHdl.GetRoutines)                                      // GET /api/routines (with optional ?frequency=Daily)
+	routines.Get("/today", routineHdl.GetTodaysRoutines)                           // GET /api/routines/today
+	routines.Get("/stats", routineHdl.GetAllRoutineStats)                          // GET /api/routines/stats?from=...&to=... (heatmap and rates across all routines)
+	routines.Post("/", routineHdl.CreateRoutine)                                   // POST /api/routines
+	routines.Get("/:id", routineHdl.GetRoutine)                                    // GET /api/routines/:id
+	routines.Put("/:id", routineHdl.UpdateRoutine)                                 // PUT /api/routines/:id
+	routines.Patch("/:id/complete", routineHdl.CompleteRoutine)                    // PATCH /api/routines/:id/complete
+	routines.Patch("/:id/skip", routineHdl.SkipRoutine)                            // PATCH /api/routines/:id/skip
+	routines.Get("/:id/stats", routineHdl.GetRoutineStats)                         // GET /api/routines/:id/stats?from=...&to=...
+	routines.Get("/:id/completions", routineHdl.GetCompletions)                    // GET /api/routines/:id/completions?from=...&to=...
+	routines.Post("/:id/completions", routineHdl.LogCompletion)                    // POST /api/routines/:id/completions (body with date, status; backfills past days)
+	routines.Put("/:id/completions/:completionId", routineHdl.UpdateCompletion)    // PUT /api/routines/:id/completions/:completionId
+	routines.Delete("/:id/completions/:completionId", routineHdl.DeleteCompletion) // DELETE /api/routines/:id/completions/:completionId (undo)
+	routines.Delete("/:id", routineHdl.DeleteRoutine)                              // DELETE /api/routines/:id
 
 	// Event routes (protected - require authentication)
 	events := api.Group("/events", middleware.AuthMiddleware(authService), middleware.APIRateLimiter())
-	events.Get("/", eventHdl.GetEvents)         // GET /api/events?start=...&end=...
-	events.Post("/", eventHdl.CreateEvent)      // POST /api/events
-	events.Get("/:id", eventHdl.GetEvent)       // GET /api/events/:id
-	events.Put("/:id", eventHdl.UpdateEvent)    // PUT /api/events/:id
-	events.Delete("/:id", eventHdl.DeleteEvent) // DELETE /api/events/:id (requires body with deleteScope)
+	events.Get("/", eventHdl.GetEvents)                                             // GET /api/events?start=...&end=...
+	events.Post("/", eventHdl.CreateEvent)                                          // POST /api/events
+	events.Post("/import", calendarImportHdl.ImportEvents)                          // POST /api/events/import?dryRun=true (.ics upload)
+	events.Get("/busy", freeBusyHdl.GetBusy)                                        // GET /api/events/busy?start=...&end=...&ignoreDomains=...
+	events.Get("/free-slots", freeBusyHdl.FindFreeSlots)                            // GET /api/events/free-slots?start=...&end=...&duration=90
+	events.Get("/conflicts", eventHdl.GetConflicts)                                 // GET /api/events/conflicts?start=...&end=...&recurrenceRule=...
+	events.Get("/:id", eventHdl.GetEvent)                                           // GET /api/events/:id
+	events.Put("/:id", eventHdl.UpdateEvent)                                        // PUT /api/events/:id
+	events.Delete("/:id", eventHdl.DeleteEvent)                                     // DELETE /api/events/:id (requires body with deleteScope)
+	events.Get("/:id/exceptions", eventHdl.GetEventExceptions)                      // GET /api/events/:id/exceptions
+	events.Post("/:id/exceptions/:exceptionId/restore", eventHdl.RestoreOccurrence) // POST /api/events/:id/exceptions/:exceptionId/restore
+	events.Post("/:id/exceptions/:exceptionId/revert", eventHdl.RevertOccurrence)   // POST /api/events/:id/exceptions/:exceptionId/revert
+	events.Post("/:id/merge", eventHdl.MergeSplit)                                  // POST /api/events/:id/merge (split series back into its parent)
 
 	// Category routes (protected - require authentication)
 	categories := api.Group("/categories", middleware.AuthMiddleware(authService), middleware.APIRateLimiter())
@@ -174,6 +282,79 @@ func main() {
 	projects.Post("/:id/tasks", projectHdl.AssignTask)             // POST /api/projects/:id/tasks
 	projects.Delete("/:id/tasks/:taskId", projectHdl.UnassignTask) // DELETE /api/projects/:id/tasks/:taskId
 	projects.Get("/:id/tasks", projectHdl.GetProjectTasks)         // GET /api/projects/:id/tasks
+	projects.Get("/:id/progress", projectHdl.GetProjectProgress)   // GET /api/projects/:id/progress?includeSubtasks=true
+	projects.Get("/:id/board", boardHdl.GetProjectBoard)           // GET /api/projects/:id/board (Kanban columns with WIP limits)
+	projects.Put("/:id/wip-limits", boardHdl.SetProjectWIPLimits)  // PUT /api/projects/:id/wip-limits (body with limits per status)
+
+	// Calendar feed token routes (protected - require authentication)
+	calendarFeeds := api.Group("/calendar-feeds", middleware.AuthMiddleware(authService), middleware.APIRateLimiter())
+	calendarFeeds.Get("/", calendarFeedHdl.GetFeedTokens)         // GET /api/calendar-feeds
+	calendarFeeds.Post("/", calendarFeedHdl.CreateFeedToken)      // POST /api/calendar-feeds
+	calendarFeeds.Delete("/:id", calendarFeedHdl.RevokeFeedToken) // DELETE /api/calendar-feeds/:id
+
+	// Reminder routes (protected - require authentication)
+	reminders := api.Group("/reminders", middleware.AuthMiddleware(authService), middleware.APIRateLimiter())
+	reminders.Get("/", reminderHdl.GetReminders)            // GET /api/reminders (with optional ?entityType=...&entityId=...)
+	reminders.Post("/", reminderHdl.CreateReminder)         // POST /api/reminders
+	reminders.Get("/channels", reminderHdl.GetChannels)     // GET /api/reminders/channels
+	reminders.Get("/deliveries", reminderHdl.GetDeliveries) // GET /api/reminders/deliveries?limit=50
+	reminders.Delete("/:id", reminderHdl.DeleteReminder)    // DELETE /api/reminders/:id
+
+	// Web Push routes (protected - require authentication)
+	push := api.Group("/push", middleware.AuthMiddleware(authService), middleware.APIRateLimiter())
+	push.Get("/vapid-public-key", reminderHdl.GetVAPIDPublicKey) // GET /api/push/vapid-public-key
+	push.Post("/subscriptions", reminderHdl.SubscribePush)       // POST /api/push/subscriptions
+	push.Delete("/subscriptions", reminderHdl.UnsubscribePush)   // DELETE /api/push/subscriptions (body with endpoint)
+
+	// Domain routes (protected - require authentication)
+	domains := api.Group("/domains", middleware.AuthMiddleware(authService), middleware.APIRateLimiter())
+	domains.Get("/", domainHdl.GetDomains)            // GET /api/domains (with optional ?includeArchived=true)
+	domains.Post("/", domainHdl.CreateDomain)         // POST /api/domains
+	domains.Get("/:id", domainHdl.GetDomain)          // GET /api/domains/:id
+	domains.Put("/:id", domainHdl.UpdateDomain)       // PUT /api/domains/:id (renaming rewrites tasks and events)
+	domains.Post("/:id/merge", domainHdl.MergeDomain) // POST /api/domains/:id/merge (body with targetId)
+	domains.Delete("/:id", domainHdl.DeleteDomain)    // DELETE /api/domains/:id
+
+	// Agenda routes (protected - require authentication)
+	agenda := api.Group("/agenda", middleware.AuthMiddleware(authService), middleware.APIRateLimiter())
+	agenda.Get("/", agendaHdl.GetAgenda) // GET /api/agenda?date=YYYY-MM-DD&view=day|week&timezone=...
+
+	// Tag routes (protected - require authentication)
+	tags := api.Group("/tags", middleware.AuthMiddleware(authService), middleware.APIRateLimiter())
+	tags.Get("/", tagHdl.GetTags)            // GET /api/tags (with optional ?q=prefix&limit=10, most used first)
+	tags.Put("/:id", tagHdl.RenameTag)       // PUT /api/tags/:id (body with name)
+	tags.Post("/:id/merge", tagHdl.MergeTag) // POST /api/tags/:id/merge (body with targetId)
+	tags.Delete("/:id", tagHdl.DeleteTag)    // DELETE /api/tags/:id
+
+	// Quick-add routes (protected - require authentication)
+	quickAdd := api.Group("/quick-add", middleware.AuthMiddleware(authService), middleware.APIRateLimiter())
+	quickAdd.Post("/", quickAddHdl.QuickAdd)               // POST /api/quick-add (body with text, creates a task or event)
+	quickAdd.Post("/preview", quickAddHdl.PreviewQuickAdd) // POST /api/quick-add/preview (interpretation only, for confirmation)
+
+	// Time tracking routes (protected - require authentication)
+	timeTracking := api.Group("/time", middleware.AuthMiddleware(authService), middleware.APIRateLimiter())
+	timeTracking.Get("/running", timeEntryHdl.GetRunningTimer)        // GET /api/time/running
+	timeTracking.Post("/start", timeEntryHdl.StartTimer)              // POST /api/time/start (body with taskId, stops the running timer)
+	timeTracking.Post("/stop", timeEntryHdl.StopTimer)                // POST /api/time/stop
+	timeTracking.Get("/entries", timeEntryHdl.GetTimeEntries)         // GET /api/time/entries?from=YYYY-MM-DD&to=YYYY-MM-DD&taskId=...
+	timeTracking.Post("/entries", timeEntryHdl.CreateTimeEntry)       // POST /api/time/entries (manual entry)
+	timeTracking.Put("/entries/:id", timeEntryHdl.UpdateTimeEntry)    // PUT /api/time/entries/:id
+	timeTracking.Delete("/entries/:id", timeEntryHdl.DeleteTimeEntry) // DELETE /api/time/entries/:id
+	timeTracking.Get("/report", timeEntryHdl.GetTimeReport)           // GET /api/time/report?from=YYYY-MM-DD&to=YYYY-MM-DD (per day, week, project, domain, task)
+	timeTracking.Get("/export", timeEntryHdl.ExportTimeEntries)       // GET /api/time/export?from=YYYY-MM-DD&to=YYYY-MM-DD (CSV)
+
+	// Trash routes (protected - require authentication)
+	trash := api.Group("/trash", middleware.AuthMiddleware(authService), middleware.APIRateLimiter())
+	trash.Get("/", trashHdl.GetTrash)                      // GET /api/trash (?type=tasks|routines|projects|events)
+	trash.Post("/:type/:id/restore", trashHdl.RestoreItem) // POST /api/trash/:type/:id/restore (with everything deleted together with it)
+	trash.Delete("/:type/:id", trashHdl.DeleteItem)        // DELETE /api/trash/:type/:id (permanently)
+	trash.Delete("/", trashHdl.EmptyTrash)                 // DELETE /api/trash (permanently deletes all trashed items)
+
+	// Start reminder scheduler (deliveries are persisted, so pending reminders resume after a restart)
+	go reminderScheduler.Start(context.Background())
+
+	// Start trash purger (deletes items trashed longer than TRASH_RETENTION_DAYS)
+	go trashPurger.Start(context.Background())
 
 	// Start server
 	log.Printf("Server starting on port %s", cfg.Port)
@@ -0,0 +1,24 @@
+// Command vapid-key prints a new VAPID private key for Web Push (VAPID_PRIVATE_KEY).
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/notify"
+)
+
+func main() {
+	privateKey, err := notify.GenerateVAPIDKey()
+	if err != nil {
+		log.Fatal("Failed to generate VAPID key:", err)
+	}
+
+	publicKey, err := notify.VAPIDPublicKey(privateKey)
+	if err != nil {
+		log.Fatal("Failed to derive VAPID public key:", err)
+	}
+
+	fmt.Printf("VAPID_PRIVATE_KEY=%s\n", privateKey)
+	fmt.Printf("# Public key (served at GET /api/push/vapid-public-key): %s\n", publicKey)
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
 
 	"github.com/joho/godotenv"
 )
@@ -13,6 +14,16 @@ type Config struct {
 	DatabaseURL    string
 	JWTSecret      string
 	AllowedOrigins string
+
+	// Reminder delivery
+	SMTPHost        string
+	SMTPPort        string
+	SMTPFrom        string
+	VAPIDPrivateKey string // Raw P-256 key (base64url), Web Push is disabled without it
+	VAPIDSubject    string // Contact for push services (mailto: or https:)
+
+	// Trash
+	TrashRetentionDays int // Trashed items are purged after this many days
 }
 
 func Load() *Config {
@@ -29,6 +40,14 @@ func Load() *Config {
 		DatabaseURL:    getEnv("DATABASE_URL", ""),
 		JWTSecret:      getEnv("JWT_SECRET", ""),
 		AllowedOrigins: getEnv("ALLOWED_ORIGINS", "http://localhost:3000"),
+
+		SMTPHost:        getEnv("SMTP_HOST", "localhost"),
+		SMTPPort:        getEnv("SMTP_PORT", "1025"),
+		SMTPFrom:        getEnv("SMTP_FROM", "reminders@mylifeos.local"),
+		VAPIDPrivateKey: getEnv("VAPID_PRIVATE_KEY", ""),
+		VAPIDSubject:    getEnv("VAPID_SUBJECT", "mailto:admin@mylifeos.local"),
+
+		TrashRetentionDays: getEnvInt("TRASH_RETENTION_DAYS", 30),
 	}
 }
 
@@ -38,3 +57,11 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getEnvInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return defaultValue
+	}
+	return value
+}
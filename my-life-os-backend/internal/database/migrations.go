@@ -0,0 +1,231 @@
+package database
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/rrule"
+)
+
+// legacyEventRecurrence is an event row with the old recurrence columns
+type legacyEventRecurrence struct {
+	ID             uuid.UUID
+	StartDate      time.Time
+	RecurrenceType *string
+	RecurrenceDays *string
+	RecurrenceEnd  *time.Time
+}
+
+// MigrateEventRecurrence converts the old recurrence_type/recurrence_days columns into RRULEs and drops them
+func MigrateEventRecurrence(db *gorm.DB) error {
+	migrator := db.Migrator()
+	if !migrator.HasColumn("events", "recurrence_type") {
+		return nil
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var rows []legacyEventRecurrence
+		err := tx.Table("events").
+			Select("id, start_date, recurrence_type, recurrence_days, recurrence_end").
+			Where("recurrence_type IS NOT NULL AND recurrence_type <> '' AND recurrence_rule IS NULL").
+			Find(&rows).Error
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			rule, err := rrule.FromLegacy(*row.RecurrenceType, row.RecurrenceDays, row.RecurrenceEnd)
+			if err != nil {
+				// Keep the event, but as a single occurrence
+				log.Printf("Event %s has an invalid recurrence (%v), converting to a single event", row.ID, err)
+				if err := tx.Table("events").Where("id = ?", row.ID).
+					Updates(map[string]any{"is_recurring": false, "recurrence_end": nil}).Error; err != nil {
+					return err
+				}
+				continue
+			}
+
+			if err := tx.Table("events").Where("id = ?", row.ID).
+				Update("recurrence_rule", rule.String()).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Migrator().DropColumn("events", "recurrence_type"); err != nil {
+			return err
+		}
+		if tx.Migrator().HasColumn("events", "recurrence_days") {
+			if err := tx.Migrator().DropColumn("events", "recurrence_days"); err != nil {
+				return err
+			}
+		}
+
+		if len(rows) > 0 {
+			log.Printf("Migrated %d recurring events to recurrence rules", len(rows))
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to migrate event recurrence: %w", err)
+	}
+
+	return nil
+}
+
+// legacyEventTimezone is an event row without a timezone, joined with its owner's timezone
+type legacyEventTimezone struct {
+	ID           uuid.UUID
+	StartDate    time.Time
+	IsRecurring  bool
+	UserTimezone string
+}
+
+// MigrateEventTimezones assigns the owner's timezone to events created before events had one.
+// Recurring events used to be expanded in UTC, so the original dates of their exceptions are
+// moved to the local time of the series start (occurrences now keep their local time across DST).
+func MigrateEventTimezones(db *gorm.DB) error {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var rows []legacyEventTimezone
+		err := tx.Table("events").
+			Select("events.id, events.start_date, events.is_recurring, users.timezone AS user_timezone").
+			Joins("JOIN users ON users.id = events.user_id").
+			Where("events.timezone IS NULL OR events.timezone = ''").
+			Find(&rows).Error
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			timezone := row.UserTimezone
+			loc, err := time.LoadLocation(timezone)
+			if timezone == "" || err != nil {
+				timezone = "UTC"
+				loc = time.UTC
+			}
+
+			if err := tx.Table("events").Where("id = ?", row.ID).
+				Update("timezone", timezone).Error; err != nil {
+				return err
+			}
+
+			if !row.IsRecurring || loc == time.UTC {
+				continue
+			}
+
+			var exceptions []entities.EventException
+			if err := tx.Where("event_id = ?", row.ID).Find(&exceptions).Error; err != nil {
+				return err
+			}
+
+			start := row.StartDate.In(loc)
+			for _, exception := range exceptions {
+				original := exception.OriginalDate.In(loc)
+				moved := time.Date(original.Year(), original.Month(), original.Day(),
+					start.Hour(), start.Minute(), start.Second(), 0, loc)
+				if moved.Equal(exception.OriginalDate) {
+					continue
+				}
+
+				if err := tx.Table("event_exceptions").Where("id = ?", exception.ID).
+					Update("original_date", moved.UTC()).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		if len(rows) > 0 {
+			log.Printf("Assigned timezones to %d events", len(rows))
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to migrate event timezones: %w", err)
+	}
+
+	return nil
+}
+
+// MigrateDomains creates the domains of users who have none yet: the default domains plus
+// every other domain name their tasks and events already use, so no existing value is lost.
+func MigrateDomains(db *gorm.DB) error {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var userIDs []uuid.UUID
+		err := tx.Table("users").
+			Where("NOT EXISTS (SELECT 1 FROM domains WHERE domains.user_id = users.id)").
+			Pluck("id", &userIDs).Error
+		if err != nil {
+			return err
+		}
+
+		for _, userID := range userIDs {
+			var used []string
+			err := tx.Raw(`SELECT domain FROM tasks WHERE user_id = ?
+				UNION SELECT domain FROM events WHERE user_id = ?
+				UNION SELECT modified_domain FROM event_exceptions WHERE user_id = ? AND modified_domain IS NOT NULL`,
+				userID, userID, userID).Scan(&used).Error
+			if err != nil {
+				return err
+			}
+
+			now := time.Now()
+			var domains []entities.Domain
+			seen := make(map[string]bool)
+			add := func(name, color, icon string) {
+				key := strings.ToLower(strings.TrimSpace(name))
+				if key == "" || seen[key] {
+					return
+				}
+				seen[key] = true
+				domains = append(domains, entities.Domain{
+					ID:        uuid.New(),
+					UserID:    userID,
+					Name:      strings.TrimSpace(name),
+					Color:     color,
+					Icon:      icon,
+					CreatedAt: now,
+					UpdatedAt: now,
+				})
+			}
+
+			for _, d := range entities.DefaultDomains {
+				add(d.Name, d.Color, d.Icon)
+			}
+			for _, name := range used {
+				add(name, entities.DefaultDomainColor, "")
+			}
+
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&domains).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(userIDs) > 0 {
+			log.Printf("Created domains for %d users", len(userIDs))
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to migrate domains: %w", err)
+	}
+
+	return nil
+}
+
+// MigrateTaskCompletedAt sets the completion time of tasks completed before the status history existed.
+// Their last update is the best estimate of when they were completed.
+func MigrateTaskCompletedAt(db *gorm.DB) error {
+	err := db.Exec(`UPDATE tasks SET completed_at = updated_at WHERE status = ? AND completed_at IS NULL`,
+		entities.StatusDone).Error
+	if err != nil {
+		return fmt.Errorf("failed to migrate task completion times: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,22 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CalendarFeedToken represents a revocable secret used to subscribe to the iCalendar feed
+type CalendarFeedToken struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID     uuid.UUID  `gorm:"type:uuid;not null;index" json:"userId"`
+	Name       string     `gorm:"type:text;not null" json:"name"`          // e.g. "Thunderbird", "Phone"
+	TokenHash  string     `gorm:"type:text;not null;uniqueIndex" json:"-"` // "-" = never serialize the hash
+	LastUsedAt *time.Time `gorm:"type:timestamptz" json:"lastUsedAt"`
+	CreatedAt  time.Time  `gorm:"type:timestamptz;not null" json:"createdAt"`
+}
+
+// TableName specifies the table name for GORM
+func (CalendarFeedToken) TableName() string {
+	return "calendar_feed_tokens"
+}
@@ -0,0 +1,48 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Domain is a user-defined life area shared by tasks and events (they reference it by name)
+type Domain struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_domains_user_name" json:"userId"`
+	Name      string    `gorm:"type:text;not null;uniqueIndex:idx_domains_user_name" json:"name"`
+	Color     string    `gorm:"type:varchar(7);not null;default:'#6b7280'" json:"color"` // Hex color (#RRGGBB)
+	Icon      string    `gorm:"type:varchar(50)" json:"icon"`                            // Lucide icon name
+	Archived  bool      `gorm:"not null;default:false" json:"archived"`                  // Archived domains cannot be assigned to new items
+	CreatedAt time.Time `gorm:"type:timestamptz;not null" json:"createdAt"`
+	UpdatedAt time.Time `gorm:"type:timestamptz;not null" json:"updatedAt"`
+}
+
+// TableName specifies the table name for GORM
+func (Domain) TableName() string {
+	return "domains"
+}
+
+// DefaultDomainColor is used for domains created without a color
+const DefaultDomainColor = "#6b7280"
+
+// DefaultDomains are created for every user (the former fixed event and task domains)
+var DefaultDomains = []Domain{
+	{Name: "Work", Color: "#3b82f6", Icon: "briefcase"},
+	{Name: "University", Color: "#a855f7", Icon: "graduation-cap"},
+	{Name: "Personal", Color: "#22c55e", Icon: "user"},
+	{Name: "Coding Time", Color: "#06b6d4", Icon: "code"},
+	{Name: "Coding Project", Color: "#14b8a6", Icon: "folder-git-2"},
+	{Name: "Personal Project", Color: "#84cc16", Icon: "folder-heart"},
+	{Name: "Study", Color: "#6366f1", Icon: "book-open"},
+	{Name: "Goals", Color: "#f59e0b", Icon: "target"},
+	{Name: "Finances", Color: "#0ea5e9", Icon: "wallet"},
+	{Name: "Household", Color: "#78716c", Icon: "sofa"},
+	{Name: "Health", Color: "#ec4899", Icon: "heart-pulse"},
+	{Name: "Social", Color: "#eab308", Icon: "users"},
+	{Name: "Holidays", Color: "#ef4444", Icon: "tree-palm"},
+	{Name: "Travel", Color: "#f97316", Icon: "plane"},
+	{Name: "Maintenance", Color: "#6b7280", Icon: "wrench"},
+	{Name: "Entertainment", Color: "#d946ef", Icon: "clapperboard"},
+	{Name: "Family", Color: "#10b981", Icon: "house"},
+}
@@ -4,31 +4,49 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type Event struct {
 	ID     uuid.UUID `gorm:"type:uuid;primary_key" json:"id"`
 	UserID uuid.UUID `gorm:"type:uuid;not null" json:"userId"`
 
-	// Basic Info
+	// Basic Info (dates are stored in UTC)
 	Title     string     `gorm:"type:varchar(255);not null" json:"title"`
 	StartDate time.Time  `gorm:"type:timestamp;not null" json:"startDate"`
 	EndDate   *time.Time `gorm:"type:timestamp" json:"endDate"` // nil = All Day
 	AllDay    bool       `gorm:"not null;default:false" json:"allDay"`
+	Timezone  string     `gorm:"type:varchar(64)" json:"timezone"`         // IANA zone the event is planned in (e.g. Europe/Berlin)
 	Domain    string     `gorm:"type:varchar(100);not null" json:"domain"` // Work, University, Personal, etc.
 
 	// Recurrence
 	IsRecurring    bool       `gorm:"not null;default:false" json:"isRecurring"`
-	RecurrenceType *string    `gorm:"type:varchar(50)" json:"recurrenceType"` // daily, weekly, monthly, yearly
-	RecurrenceEnd  *time.Time `gorm:"type:timestamp" json:"recurrenceEnd"`    // nil = never ends
-	RecurrenceDays *string    `gorm:"type:text" json:"recurrenceDays"`        // JSON array: ["monday","wednesday"]
+	RecurrenceRule *string    `gorm:"type:text" json:"recurrenceRule"`     // RFC 5545 RRULE: "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH"
+	RecurrenceEnd  *time.Time `gorm:"type:timestamp" json:"recurrenceEnd"` // Last occurrence (derived from UNTIL/COUNT), nil = never ends
 
 	// UI Options
 	HideFromAgenda bool `gorm:"not null;default:false" json:"hideFromAgenda"`
 
+	// iCalendar UID of imported events (nil = created in MyLifeOS)
+	ICalUID *string `gorm:"type:text;index" json:"icalUid,omitempty"`
+
+	// Series this event was split from by a "following" edit (nil = not split off)
+	ParentEventID *uuid.UUID `gorm:"type:uuid;index" json:"parentEventId"`
+
 	// Timestamps
 	CreatedAt time.Time `gorm:"not null" json:"createdAt"`
 	UpdatedAt time.Time `gorm:"not null" json:"updatedAt"`
+
+	// Moved to the trash
+	DeletedAt gorm.DeletedAt `gorm:"type:timestamptz;index" json:"deletedAt"`
+}
+
+// BeforeSave hook - timestamp columns have no zone, so dates are always stored in UTC
+func (e *Event) BeforeSave(tx *gorm.DB) error {
+	e.StartDate = e.StartDate.UTC()
+	e.EndDate = utcPtr(e.EndDate)
+	e.RecurrenceEnd = utcPtr(e.RecurrenceEnd)
+	return nil
 }
 
 type EventException struct {
@@ -47,3 +65,20 @@ type EventException struct {
 
 	CreatedAt time.Time `gorm:"not null" json:"createdAt"`
 }
+
+// BeforeSave hook - timestamp columns have no zone, so dates are always stored in UTC
+func (e *EventException) BeforeSave(tx *gorm.DB) error {
+	e.OriginalDate = e.OriginalDate.UTC()
+	e.ModifiedStartDate = utcPtr(e.ModifiedStartDate)
+	e.ModifiedEndDate = utcPtr(e.ModifiedEndDate)
+	return nil
+}
+
+// utcPtr converts an optional time to UTC
+func utcPtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
@@ -2,6 +2,7 @@ package entities
 
 import (
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 // Project status values
@@ -28,6 +29,12 @@ type Project struct {
 	// Many-to-many relationships
 	TechStack []TechStackItem `gorm:"many2many:project_tech_stack;" json:"techStack"`
 	Tasks     []ProjectTask   `gorm:"foreignKey:ProjectID" json:"tasks"`
+
+	// Board settings
+	WIPLimits []ProjectWIPLimit `gorm:"foreignKey:ProjectID" json:"wipLimits"`
+
+	// Moved to the trash (task assignments and tech stack are kept for restoring)
+	DeletedAt gorm.DeletedAt `gorm:"type:timestamptz;index" json:"deletedAt"`
 }
 
 // TableName specifies the table name for GORM
@@ -35,19 +42,20 @@ func (Project) TableName() string {
 	return "projects"
 }
 
-// GetProgress calculates the completion percentage based on assigned tasks
-func (p *Project) GetProgress() float64 {
-	if len(p.Tasks) == 0 {
-		return 0.0
-	}
-
-	completedCount := 0
+// GetProgress calculates the completion percentage based on assigned tasks (cancelled tasks are left out).
+// With includeSubtasks, open tasks count the completed part of their subtasks.
+func (p *Project) GetProgress(includeSubtasks bool) float64 {
+	completed, count := 0.0, 0
 	for _, pt := range p.Tasks {
-		// Check if the task is completed using the Task StatusDone constant
-		if pt.Task.Status == StatusDone {
-			completedCount++
+		if pt.Task.Status == StatusCancelled {
+			continue
 		}
+		completed += pt.Task.Completion(includeSubtasks)
+		count++
+	}
+	if count == 0 {
+		return 0.0
 	}
 
-	return float64(completedCount) / float64(len(p.Tasks)) * 100
+	return completed / float64(count) * 100
 }
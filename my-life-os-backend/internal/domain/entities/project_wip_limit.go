@@ -0,0 +1,17 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+)
+
+// ProjectWIPLimit is the number of tasks of a project that should be in a status at the same time
+type ProjectWIPLimit struct {
+	ProjectID uuid.UUID `gorm:"type:uuid;primaryKey" json:"projectId"`
+	Status    string    `gorm:"type:text;primaryKey" json:"status"`
+	Limit     int       `gorm:"column:max_tasks;not null" json:"limit"`
+}
+
+// TableName specifies the table name for GORM
+func (ProjectWIPLimit) TableName() string {
+	return "project_wip_limits"
+}
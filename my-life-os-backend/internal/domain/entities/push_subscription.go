@@ -0,0 +1,22 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PushSubscription is a browser's Web Push subscription (from PushManager.subscribe)
+type PushSubscription struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;index" json:"userId"`
+	Endpoint  string    `gorm:"type:text;not null;uniqueIndex" json:"endpoint"`
+	P256dh    string    `gorm:"type:text;not null" json:"-"` // Client public key (base64url)
+	Auth      string    `gorm:"type:text;not null" json:"-"` // Client auth secret (base64url)
+	CreatedAt time.Time `gorm:"type:timestamptz;not null" json:"createdAt"`
+}
+
+// TableName specifies the table name for GORM
+func (PushSubscription) TableName() string {
+	return "push_subscriptions"
+}
@@ -0,0 +1,72 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Reminder entity types
+const (
+	ReminderEntityEvent   = "event"
+	ReminderEntityTask    = "task"
+	ReminderEntityRoutine = "routine"
+)
+
+// Reminder channels
+const (
+	ReminderChannelLog     = "log"
+	ReminderChannelEmail   = "email"
+	ReminderChannelWebhook = "webhook"
+	ReminderChannelWebPush = "webpush"
+)
+
+// Reminder delivery status
+const (
+	DeliveryStatusPending = "pending"
+	DeliveryStatusSent    = "sent"
+	DeliveryStatusFailed  = "failed"
+)
+
+// ReminderRule notifies a user some time before an event, task deadline or routine is due
+type ReminderRule struct {
+	ID            uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID        uuid.UUID `gorm:"type:uuid;not null;index" json:"userId"`
+	EntityType    string    `gorm:"type:varchar(20);not null;index:idx_reminder_rules_entity" json:"entityType"` // event, task, routine
+	EntityID      uuid.UUID `gorm:"type:uuid;not null;index:idx_reminder_rules_entity" json:"entityId"`
+	OffsetMinutes int       `gorm:"not null;default:0" json:"offsetMinutes"`  // Minutes before the due time
+	Channel       string    `gorm:"type:varchar(20);not null" json:"channel"` // log, email, webhook, webpush
+	Target        *string   `gorm:"type:text" json:"target"`                  // Email address or webhook URL (nil = channel default)
+	CreatedAt     time.Time `gorm:"type:timestamptz;not null" json:"createdAt"`
+	UpdatedAt     time.Time `gorm:"type:timestamptz;not null" json:"updatedAt"`
+}
+
+// TableName specifies the table name for GORM
+func (ReminderRule) TableName() string {
+	return "reminder_rules"
+}
+
+// ReminderDelivery is a scheduled notification (outbox).
+// The unique (rule, due time) pair makes scheduling idempotent, so every occurrence is notified only once.
+type ReminderDelivery struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID        uuid.UUID  `gorm:"type:uuid;not null;index" json:"userId"`
+	RuleID        uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_reminder_deliveries_dedupe" json:"ruleId"`
+	EntityType    string     `gorm:"type:varchar(20);not null" json:"entityType"`
+	EntityID      uuid.UUID  `gorm:"type:uuid;not null" json:"entityId"`
+	Channel       string     `gorm:"type:varchar(20);not null" json:"channel"`
+	Title         string     `gorm:"type:text;not null" json:"title"`                                                   // Entity title at scheduling time
+	DueAt         time.Time  `gorm:"type:timestamptz;not null;uniqueIndex:idx_reminder_deliveries_dedupe" json:"dueAt"` // When the event/task/routine is due
+	RemindAt      time.Time  `gorm:"type:timestamptz;not null" json:"remindAt"`
+	Status        string     `gorm:"type:varchar(20);not null;default:'pending';index" json:"status"` // pending, sent, failed
+	Attempts      int        `gorm:"not null;default:0" json:"attempts"`
+	LastError     *string    `gorm:"type:text" json:"lastError"`
+	NextAttemptAt time.Time  `gorm:"type:timestamptz;not null;index" json:"nextAttemptAt"`
+	SentAt        *time.Time `gorm:"type:timestamptz" json:"sentAt"`
+	CreatedAt     time.Time  `gorm:"type:timestamptz;not null" json:"createdAt"`
+}
+
+// TableName specifies the table name for GORM
+func (ReminderDelivery) TableName() string {
+	return "reminder_deliveries"
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 // YearlyDate represents month and day for yearly routines
@@ -12,6 +13,34 @@ type YearlyDate struct {
 	Day   int `json:"day"`   // 1-31
 }
 
+// Monthly rules (Routine.MonthlyRule)
+const (
+	MonthlyRuleDay        = "Day"        // On DayOfMonth (default)
+	MonthlyRuleLastDay    = "LastDay"    // On the last day of the month
+	MonthlyRuleNthWeekday = "NthWeekday" // On the WeekOfMonth-th Weekday (e.g. 2nd Tuesday)
+)
+
+// Routine periods (Routine.QuotaPeriod and Routine.CompletionWindow)
+const (
+	RoutinePeriodWeek    = "Week" // Monday to Sunday
+	RoutinePeriodMonth   = "Month"
+	RoutinePeriodQuarter = "Quarter"
+)
+
+// RoutineSchedule holds the extended schedule rules of a routine (nil fields are not set)
+type RoutineSchedule struct {
+	Weekdays     []int   `gorm:"type:jsonb;serializer:json" json:"weekdays"` // 0-6 for Weekly on several days (instead of Weekday)
+	IntervalDays *int    `gorm:"type:int" json:"intervalDays"`               // N for Interval (every N days)
+	StartDate    *string `gorm:"type:varchar(10)" json:"startDate"`          // YYYY-MM-DD anchor for Interval (default: created day)
+	MonthlyRule  *string `gorm:"type:varchar(20)" json:"monthlyRule"`        // Day, LastDay, NthWeekday for Monthly
+	WeekOfMonth  *int    `gorm:"type:int" json:"weekOfMonth"`                // 1-5 or -1 (last) for NthWeekday, with Weekday
+	QuotaCount   *int    `gorm:"type:int" json:"quotaCount"`                 // N for Quota (N times per period, any days)
+	QuotaPeriod  *string `gorm:"type:varchar(20)" json:"quotaPeriod"`        // Week, Month, Quarter for Quota
+
+	// Week, Month or Quarter: non-daily routines may be done on any day of the period around a scheduled day
+	CompletionWindow *string `gorm:"type:varchar(20)" json:"completionWindow"`
+}
+
 // Routine represents a reccuring task or habit
 type Routine struct {
 	ID     uuid.UUID `gorm:"type:uuid;primary_key" json:"id"`
@@ -19,12 +48,15 @@ type Routine struct {
 	Title  string    `gorm:"type:varchar(255);not null" json:"title"`
 
 	// Frequency settings
-	Frequency    string      `gorm:"type:varchar(50);not null" json:"frequency"` // Daily, Weekly, Monthly, Quarterly, Yearly
-	Weekday      *int        `gorm:"type:int" json:"weekday"`                    // 0-6 for Weekly (0=Sunday)
+	Frequency    string      `gorm:"type:varchar(50);not null" json:"frequency"` // Daily, Weekly, Monthly, Quarterly, Yearly, Interval, Quota
+	Weekday      *int        `gorm:"type:int" json:"weekday"`                    // 0-6 for Weekly and NthWeekday (0=Sunday)
 	DayOfMonth   *int        `gorm:"type:int" json:"dayOfMonth"`                 // 1-31 for Monthly
 	QuarterlyDay *int        `gorm:"type:int" json:"quarterlyDay"`               // 1-31 for Quarterly
 	YearlyDate   *YearlyDate `gorm:"type:jsonb" json:"yearlyDate"`               // {month, day} for Yearly
 
+	// Extended schedule (multiple weekdays, intervals, monthly rules, quotas)
+	RoutineSchedule `gorm:"embedded"`
+
 	// Options
 	IsSkippable bool `gorm:"not null;default:false" json:"isSkippable"`
 	ShowStreak  bool `gorm:"not null;default:false" json:"showStreak"`
@@ -40,4 +72,7 @@ type Routine struct {
 	// Timestamps
 	CreatedAt time.Time `gorm:"not null" json:"createdAt"`
 	UpdatedAt time.Time `gorm:"not null" json:"updatedAt"`
+
+	// Moved to the trash
+	DeletedAt gorm.DeletedAt `gorm:"type:timestamptz;index" json:"deletedAt"`
 }
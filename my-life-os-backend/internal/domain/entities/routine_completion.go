@@ -6,6 +6,12 @@ import (
 	"github.com/google/uuid"
 )
 
+// Routine completion status
+const (
+	RoutineCompleted = "completed"
+	RoutineSkipped   = "skipped"
+)
+
 // RoutineCompletion represents a single completion/skip event for a routine
 type RoutineCompletion struct {
 	ID          uuid.UUID `gorm:"type:uuid;primary_key" json:"id"`
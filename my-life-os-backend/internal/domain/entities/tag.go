@@ -0,0 +1,32 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Tag is a free-form label for tasks (names are lowercase and unique per user)
+type Tag struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_tags_user_name" json:"userId"`
+	Name      string    `gorm:"type:text;not null;uniqueIndex:idx_tags_user_name" json:"name"`
+	CreatedAt time.Time `gorm:"type:timestamptz;not null" json:"createdAt"`
+	UpdatedAt time.Time `gorm:"type:timestamptz;not null" json:"updatedAt"`
+}
+
+// TableName specifies the table name for GORM
+func (Tag) TableName() string {
+	return "tags"
+}
+
+// TaskTag links a task to one of its tags (join table of Task.Tags)
+type TaskTag struct {
+	TaskID uuid.UUID `gorm:"type:uuid;primaryKey" json:"taskId"`
+	TagID  uuid.UUID `gorm:"type:uuid;primaryKey;index" json:"tagId"`
+}
+
+// TableName specifies the table name for GORM
+func (TaskTag) TableName() string {
+	return "task_tags"
+}
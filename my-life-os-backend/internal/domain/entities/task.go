@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 // Task priority levels
@@ -15,21 +16,58 @@ const (
 
 // Task status
 const (
-	StatusTodo = "Todo"
-	StatusDone = "Done"
+	StatusTodo       = "Todo"
+	StatusInProgress = "In Progress"
+	StatusWaiting    = "Waiting"
+	StatusBlocked    = "Blocked"
+	StatusDone       = "Done"
+	StatusCancelled  = "Cancelled"
 )
 
-// Task domains/categories
-const (
-	DomainWork            = "Work"
-	DomainUniversity      = "University"
-	DomainCodingProject   = "Coding Project"
-	DomainPersonalProject = "Personal Project"
-	DomainGoals           = "Goals"
-	DomainFinances        = "Finances"
-	DomainHousehold       = "Household"
-	DomainHealth          = "Health"
-)
+// TaskStatuses are all task statuses in board column order
+var TaskStatuses = []string{StatusTodo, StatusInProgress, StatusWaiting, StatusBlocked, StatusDone, StatusCancelled}
+
+// TaskStatusTransitions is the task workflow: the statuses a task can move to from each status
+var TaskStatusTransitions = map[string][]string{
+	StatusTodo:       {StatusInProgress, StatusWaiting, StatusBlocked, StatusDone, StatusCancelled},
+	StatusInProgress: {StatusTodo, StatusWaiting, StatusBlocked, StatusDone, StatusCancelled},
+	StatusWaiting:    {StatusTodo, StatusInProgress, StatusBlocked, StatusDone, StatusCancelled},
+	StatusBlocked:    {StatusTodo, StatusInProgress, StatusWaiting, StatusCancelled},
+	StatusDone:       {StatusTodo, StatusInProgress},
+	StatusCancelled:  {StatusTodo},
+}
+
+// TerminalTaskStatuses are the statuses of tasks that need no more work
+var TerminalTaskStatuses = []string{StatusDone, StatusCancelled}
+
+// IsValidTaskStatus checks if a status is part of the task workflow
+func IsValidTaskStatus(status string) bool {
+	_, ok := TaskStatusTransitions[status]
+	return ok
+}
+
+// IsTerminalTaskStatus checks if a status ends the work on a task (Done or Cancelled)
+func IsTerminalTaskStatus(status string) bool {
+	for _, terminal := range TerminalTaskStatuses {
+		if status == terminal {
+			return true
+		}
+	}
+	return false
+}
+
+// CanTransitionTaskStatus checks if the workflow allows moving a task from one status to another
+func CanTransitionTaskStatus(from, to string) bool {
+	for _, next := range TaskStatusTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// MaxSubtaskDepth limits how deep subtasks can be nested below a top-level task
+const MaxSubtaskDepth = 3
 
 // Task represents a user's task/todo item
 type Task struct {
@@ -39,13 +77,88 @@ type Task struct {
 	Description string     `gorm:"type:text" json:"description"`
 	Priority    string     `gorm:"type:text;not null;default:'Medium'" json:"priority"`
 	Status      string     `gorm:"type:text;not null;default:'Todo'" json:"status"`
-	Domain      string     `gorm:"type:text;not null" json:"domain"`
+	Domain      string     `gorm:"type:text;not null" json:"domain"` // Name of one of the user's domains
 	Deadline    *time.Time `gorm:"type:timestamptz" json:"deadline"`
-	CreatedAt   time.Time  `gorm:"type:timestamptz;not null" json:"createdAt"`
-	UpdatedAt   time.Time  `gorm:"type:timestamptz;not null" json:"updatedAt"`
+
+	// Workflow timestamps (from the status history)
+	StartedAt   *time.Time `gorm:"type:timestamptz" json:"startedAt"`         // First time the task was In Progress
+	CompletedAt *time.Time `gorm:"type:timestamptz;index" json:"completedAt"` // Set while the task is Done
+
+	// Subtasks (nil parent = top-level task)
+	ParentTaskID *uuid.UUID `gorm:"type:uuid;index" json:"parentTaskId"`
+	Position     int        `gorm:"not null;default:0" json:"position"` // Order among the subtasks of the parent
+	Subtasks     []*Task    `gorm:"foreignKey:ParentTaskID" json:"subtasks,omitempty"`
+
+	// Recurring task series this task is an instance of (nil = not recurring)
+	SeriesID *uuid.UUID `gorm:"type:uuid;index" json:"seriesId"`
+
+	Tags []*Tag `gorm:"many2many:task_tags" json:"tags,omitempty"`
+
+	CreatedAt time.Time `gorm:"type:timestamptz;not null" json:"createdAt"`
+	UpdatedAt time.Time `gorm:"type:timestamptz;not null" json:"updatedAt"`
+
+	// Moved to the trash (subtasks trashed together share the time)
+	DeletedAt gorm.DeletedAt `gorm:"type:timestamptz;index" json:"deletedAt"`
 }
 
 // TableName specifies the table name for Task
 func (Task) TableName() string {
 	return "tasks"
 }
+
+// TagNames returns the names of the (loaded) tags of a task
+func (t *Task) TagNames() []string {
+	names := make([]string, len(t.Tags))
+	for i, tag := range t.Tags {
+		names[i] = tag.Name
+	}
+	return names
+}
+
+// IsOpen checks if a task still needs work (its status is not terminal)
+func (t *Task) IsOpen() bool {
+	return !IsTerminalTaskStatus(t.Status)
+}
+
+// LeadTime returns the time from creating to completing a task (nil while it is not Done)
+func (t *Task) LeadTime() *time.Duration {
+	if t.CompletedAt == nil {
+		return nil
+	}
+	lead := t.CompletedAt.Sub(t.CreatedAt)
+	return &lead
+}
+
+// CycleTime returns the time from starting to completing a task (nil if it was never In Progress or is not Done)
+func (t *Task) CycleTime() *time.Duration {
+	if t.CompletedAt == nil || t.StartedAt == nil {
+		return nil
+	}
+	cycle := t.CompletedAt.Sub(*t.StartedAt)
+	return &cycle
+}
+
+// Completion returns how much of a task is done (0-1).
+// With includeSubtasks, an open task counts the average completion of its (loaded) subtasks.
+// Cancelled subtasks are left out.
+func (t *Task) Completion(includeSubtasks bool) float64 {
+	if t.Status == StatusDone {
+		return 1
+	}
+	if !includeSubtasks {
+		return 0
+	}
+
+	total, count := 0.0, 0
+	for _, subtask := range t.Subtasks {
+		if subtask.Status == StatusCancelled {
+			continue
+		}
+		total += subtask.Completion(true)
+		count++
+	}
+	if count == 0 {
+		return 0
+	}
+	return total / float64(count)
+}
@@ -0,0 +1,19 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TaskDependency means a task is blocked by another task until that one is done
+type TaskDependency struct {
+	TaskID      uuid.UUID `gorm:"type:uuid;primaryKey" json:"taskId"`
+	BlockedByID uuid.UUID `gorm:"type:uuid;primaryKey;index" json:"blockedById"`
+	CreatedAt   time.Time `gorm:"type:timestamptz;not null" json:"createdAt"`
+}
+
+// TableName specifies the table name for GORM
+func (TaskDependency) TableName() string {
+	return "task_dependencies"
+}
@@ -0,0 +1,26 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+)
+
+// Task list contexts with a manual order (the list key is the domain name, project ID or status)
+const (
+	TaskListDomain  = "domain"
+	TaskListProject = "project"
+	TaskListStatus  = "status"
+)
+
+// TaskRank is the position of a task in a manually ordered list (ranks sort lexicographically)
+type TaskRank struct {
+	TaskID  uuid.UUID `gorm:"type:uuid;primaryKey" json:"taskId"`
+	List    string    `gorm:"type:text;primaryKey" json:"list"`
+	ListKey string    `gorm:"type:text;primaryKey" json:"key"`
+	UserID  uuid.UUID `gorm:"type:uuid;not null;index" json:"userId"`
+	Rank    string    `gorm:"type:text;not null" json:"rank"`
+}
+
+// TableName specifies the table name for GORM
+func (TaskRank) TableName() string {
+	return "task_ranks"
+}
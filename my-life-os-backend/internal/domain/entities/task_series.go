@@ -0,0 +1,38 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Task recurrence types
+const (
+	RecurrenceSchedule        = "schedule"         // Deadlines follow a fixed RRULE schedule
+	RecurrenceAfterCompletion = "after_completion" // Next deadline is N days after the previous instance was completed
+)
+
+// TaskSeries links the instances of a recurring task.
+// Completing an instance creates the next one, copied from the completed instance with its deadline shifted.
+type TaskSeries struct {
+	ID     uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID uuid.UUID `gorm:"type:uuid;not null;index" json:"userId"`
+
+	RecurrenceType string    `gorm:"type:text;not null" json:"recurrenceType"`   // schedule, after_completion
+	RecurrenceRule *string   `gorm:"type:text" json:"recurrenceRule"`            // RFC 5545 RRULE (schedule only): "FREQ=MONTHLY;BYMONTHDAY=1"
+	IntervalDays   int       `gorm:"not null;default:0" json:"intervalDays"`     // Days after completion (after_completion only)
+	StartDate      time.Time `gorm:"type:timestamptz;not null" json:"startDate"` // Deadline of the first instance (DTSTART of the rule)
+	Timezone       string    `gorm:"type:text;not null" json:"timezone"`         // IANA zone deadlines are shifted in
+
+	StoppedAt *time.Time `gorm:"type:timestamptz" json:"stoppedAt"` // nil = active, no new instances once stopped
+
+	Tasks []*Task `gorm:"foreignKey:SeriesID" json:"tasks,omitempty"`
+
+	CreatedAt time.Time `gorm:"type:timestamptz;not null" json:"createdAt"`
+	UpdatedAt time.Time `gorm:"type:timestamptz;not null" json:"updatedAt"`
+}
+
+// TableName specifies the table name for TaskSeries
+func (TaskSeries) TableName() string {
+	return "task_series"
+}
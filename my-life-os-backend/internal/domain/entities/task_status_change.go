@@ -0,0 +1,48 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TaskStatusChange records one transition of a task in the status workflow
+type TaskStatusChange struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	TaskID     uuid.UUID `gorm:"type:uuid;not null;index:idx_task_status_changes_task_changed" json:"taskId"`
+	UserID     uuid.UUID `gorm:"type:uuid;not null;index" json:"userId"`
+	FromStatus string    `gorm:"type:text;not null" json:"fromStatus"`
+	ToStatus   string    `gorm:"type:text;not null" json:"toStatus"`
+	ChangedAt  time.Time `gorm:"type:timestamptz;not null;index:idx_task_status_changes_task_changed" json:"changedAt"`
+}
+
+// TableName specifies the table name for GORM
+func (TaskStatusChange) TableName() string {
+	return "task_status_changes"
+}
+
+// NewTaskStatusChange moves a task to a new status at a point in time and returns the record of the transition.
+// Entering In Progress the first time sets StartedAt, entering Done sets CompletedAt and leaving Done clears it.
+func NewTaskStatusChange(task *Task, status string, now time.Time) *TaskStatusChange {
+	change := &TaskStatusChange{
+		ID:         uuid.New(),
+		TaskID:     task.ID,
+		UserID:     task.UserID,
+		FromStatus: task.Status,
+		ToStatus:   status,
+		ChangedAt:  now,
+	}
+
+	task.Status = status
+	if status == StatusInProgress && task.StartedAt == nil {
+		task.StartedAt = &now
+	}
+	if status == StatusDone {
+		task.CompletedAt = &now
+	} else {
+		task.CompletedAt = nil
+	}
+	task.UpdatedAt = now
+
+	return change
+}
@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TimeEntry is time spent on a task, either a timer (started and stopped with server timestamps) or a manual entry.
+// A user has at most one running entry (EndedAt nil), enforced by a partial unique index.
+type TimeEntry struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID    uuid.UUID  `gorm:"type:uuid;not null;index:idx_time_entries_user_start;uniqueIndex:idx_time_entries_running,where:ended_at IS NULL" json:"userId"`
+	TaskID    uuid.UUID  `gorm:"type:uuid;not null;index" json:"taskId"`
+	StartedAt time.Time  `gorm:"type:timestamptz;not null;index:idx_time_entries_user_start" json:"startedAt"`
+	EndedAt   *time.Time `gorm:"type:timestamptz" json:"endedAt"` // nil = timer is running
+	Note      string     `gorm:"type:text" json:"note"`
+
+	Task *Task `gorm:"foreignKey:TaskID" json:"task,omitempty"`
+
+	CreatedAt time.Time `gorm:"type:timestamptz;not null" json:"createdAt"`
+	UpdatedAt time.Time `gorm:"type:timestamptz;not null" json:"updatedAt"`
+}
+
+// TableName specifies the table name for GORM
+func (TimeEntry) TableName() string {
+	return "time_entries"
+}
+
+// IsRunning checks if the entry is a running timer
+func (e *TimeEntry) IsRunning() bool {
+	return e.EndedAt == nil
+}
+
+// End returns the end of the entry (now for a running timer)
+func (e *TimeEntry) End(now time.Time) time.Time {
+	if e.EndedAt == nil {
+		return now
+	}
+	return *e.EndedAt
+}
+
+// Duration returns the time spent (so far for a running timer)
+func (e *TimeEntry) Duration(now time.Time) time.Duration {
+	return e.End(now).Sub(e.StartedAt)
+}
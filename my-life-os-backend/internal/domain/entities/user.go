@@ -8,13 +8,14 @@ import (
 )
 
 type User struct {
-	ID           uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
-	Email        string    `gorm:"uniqueIndex;not null" json:"email"`
-	PasswordHash string    `gorm:"not null" json:"-"` // "-" = don't serialize to JSON
-	Name         string    `gorm:"not null" json:"name"`
-	Timezone     string    `gorm:"default:Europe/Berlin" json:"timezone,omitempty"`
-	CreatedAt    time.Time `json:"createdAt"`
-	UpdatedAt    time.Time `json:"updatedAt"`
+	ID              uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	Email           string    `gorm:"uniqueIndex;not null" json:"email"`
+	PasswordHash    string    `gorm:"not null" json:"-"` // "-" = don't serialize to JSON
+	Name            string    `gorm:"not null" json:"name"`
+	Timezone        string    `gorm:"default:Europe/Berlin" json:"timezone,omitempty"`
+	DayRolloverHour int       `gorm:"not null;default:0" json:"dayRolloverHour"` // Routine days end at this hour (0-12, e.g. 4 = 04:00)
+	CreatedAt       time.Time `json:"createdAt"`
+	UpdatedAt       time.Time `json:"updatedAt"`
 }
 
 // BeforeCreate hook - generates UUID before creating
@@ -0,0 +1,79 @@
+package interfaces
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Agenda item types
+const (
+	AgendaItemEvent   = "event"
+	AgendaItemRoutine = "routine"
+	AgendaItemTask    = "task"
+)
+
+// Agenda views
+const (
+	AgendaViewDay  = "day"
+	AgendaViewWeek = "week"
+)
+
+// AgendaItem is an event occurrence, routine or task on an agenda day
+type AgendaItem struct {
+	Type   string     `json:"type"` // event, routine, task
+	ID     uuid.UUID  `json:"id"`
+	Title  string     `json:"title"`
+	Domain string     `json:"domain,omitempty"` // Events and tasks
+	Start  *time.Time `json:"start"`            // Event start, routine time or task deadline (nil = no specific time)
+	End    *time.Time `json:"end"`              // Events only
+	AllDay bool       `json:"allDay"`
+
+	// Events
+	IsRecurring bool `json:"isRecurring,omitempty"`
+
+	// Routines
+	TimeType string `json:"timeType,omitempty"` // AM, PM, AllDay, Specific
+
+	// Tasks
+	Priority string `json:"priority,omitempty"`
+	Overdue  bool   `json:"overdue,omitempty"` // Open task with a deadline before today
+
+	// Routines: pending, completed, skipped - Tasks: Todo, Done
+	Status string `json:"status,omitempty"`
+}
+
+// AgendaLoad sums up how full an agenda day is
+type AgendaLoad struct {
+	BusyMinutes     int `json:"busyMinutes"`     // Time booked by timed events (overlaps counted once)
+	EventCount      int `json:"eventCount"`      // Events on the day (all-day events included)
+	RoutineCount    int `json:"routineCount"`    // Routines due on the day
+	RoutinesDone    int `json:"routinesDone"`    // Routines completed or skipped
+	TaskCount       int `json:"taskCount"`       // Tasks due on the day (overdue ones included)
+	OpenTaskCount   int `json:"openTaskCount"`   // Tasks not done yet
+	OverdueCount    int `json:"overdueCount"`    // Overdue tasks (today only)
+	HighPriorityDue int `json:"highPriorityDue"` // Open high priority tasks
+}
+
+// AgendaDay is the time-ordered agenda of one day in the user's timezone
+type AgendaDay struct {
+	Date  string        `json:"date"` // YYYY-MM-DD
+	Start time.Time     `json:"start"`
+	End   time.Time     `json:"end"`
+	Items []*AgendaItem `json:"items"` // All-day items first, then by time
+	Load  AgendaLoad    `json:"load"`
+}
+
+// Agenda is a day or week view combining events, routines and tasks
+type Agenda struct {
+	View     string       `json:"view"`
+	Timezone string       `json:"timezone"`
+	Days     []*AgendaDay `json:"days"`
+}
+
+// AgendaService defines methods for the combined agenda view.
+type AgendaService interface {
+	// GetAgenda returns the agenda of the day (or Monday-based week) of date (only its calendar date is used, nil = today).
+	// Days are computed in the given timezone (empty = user's timezone).
+	GetAgenda(userID uuid.UUID, date *time.Time, view, timezone string) (*Agenda, error)
+}
@@ -28,6 +28,9 @@ type AuthService interface {
 	// Logout invalidates all refresh tokens for a user
 	Logout(userID uuid.UUID) error
 
+	// UpdateSettings changes the timezone and routine day rollover hour of a user (nil = keep)
+	UpdateSettings(userID uuid.UUID, timezone *string, dayRolloverHour *int) (*entities.User, error)
+
 	// ValidateAccessToken verifies an access token and returns the user ID
 	ValidateAccessToken(accessToken string) (uuid.UUID, error)
 }
@@ -0,0 +1,56 @@
+package interfaces
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
+)
+
+// TaskMove places a task in a manually ordered list between two neighbours
+// (no neighbour = top of the list, both neighbours must be next to each other)
+type TaskMove struct {
+	List     string     // domain, project or status
+	Key      string     // Domain name, project ID or status (moving to another status column changes the status)
+	AfterID  *uuid.UUID // Task the moved task goes below
+	BeforeID *uuid.UUID // Task the moved task goes above
+}
+
+// TaskMoveResult is a moved task with its new rank and the WIP limits it exceeds
+type TaskMoveResult struct {
+	Task     *entities.Task `json:"task"`
+	NextTask *entities.Task `json:"nextTask,omitempty"` // Next instance when the move completed a recurring task
+	Rank     string         `json:"rank"`
+	Warnings []string       `json:"warnings"`
+}
+
+// BoardColumn is the tasks of a project in one status (in manual order)
+type BoardColumn struct {
+	Status    string           `json:"status"`
+	Tasks     []*entities.Task `json:"tasks"`
+	Count     int              `json:"count"`
+	WIPLimit  int              `json:"wipLimit"` // 0 = no limit
+	OverLimit bool             `json:"overLimit"`
+}
+
+// ProjectBoard is the Kanban board of a project (one column per status)
+type ProjectBoard struct {
+	ProjectID uuid.UUID      `json:"projectId"`
+	Columns   []*BoardColumn `json:"columns"`
+	Warnings  []string       `json:"warnings"`
+}
+
+// BoardService defines methods for manual task order and project boards.
+type BoardService interface {
+	// GetOrderedTasks retrieves the tasks of a list in manual order (tasks never moved come last, oldest first)
+	GetOrderedTasks(userID uuid.UUID, list, key string) ([]*entities.Task, error)
+
+	// MoveTask moves a task of a user to a position in a list, only the moved task gets a new rank
+	// unless the list runs out of room (force closes open subtasks when moving to a closed status)
+	MoveTask(userID, taskID uuid.UUID, move TaskMove, force bool) (*TaskMoveResult, error)
+
+	// GetProjectBoard retrieves the Kanban board of a project for a user
+	GetProjectBoard(userID, projectID uuid.UUID) (*ProjectBoard, error)
+
+	// SetProjectWIPLimits replaces the per-status WIP limits of a project (a limit of 0 removes it)
+	SetProjectWIPLimits(userID, projectID uuid.UUID, limits map[string]int) (*ProjectBoard, error)
+}
@@ -0,0 +1,22 @@
+package interfaces
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
+)
+
+// CalendarFeedService defines methods for the iCalendar export feed.
+type CalendarFeedService interface {
+	// CreateFeedToken creates a new feed token and returns it together with the plain secret (shown only once)
+	CreateFeedToken(userID uuid.UUID, name string) (*entities.CalendarFeedToken, string, error)
+
+	// GetUserFeedTokens retrieves all feed tokens for a user
+	GetUserFeedTokens(userID uuid.UUID) ([]*entities.CalendarFeedToken, error)
+
+	// RevokeFeedToken deletes a feed token (ensures user owns it)
+	RevokeFeedToken(tokenID, userID uuid.UUID) error
+
+	// GenerateFeed renders all events of the token's owner as an iCalendar (RFC 5545) document
+	GenerateFeed(secret string) ([]byte, error)
+}
@@ -0,0 +1,28 @@
+package interfaces
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
+)
+
+// CalendarFeedTokenRepository defines methods for calendar feed token data access.
+type CalendarFeedTokenRepository interface {
+	// CreateFeedToken stores a new feed token.
+	CreateFeedToken(token *entities.CalendarFeedToken) error
+
+	// FindFeedTokenByID retrieves a feed token by its ID.
+	FindFeedTokenByID(tokenID uuid.UUID) (*entities.CalendarFeedToken, error)
+
+	// FindFeedTokenByHash retrieves a feed token by the hash of its secret.
+	FindFeedTokenByHash(tokenHash string) (*entities.CalendarFeedToken, error)
+
+	// FindFeedTokensByUserID retrieves all feed tokens for a user.
+	FindFeedTokensByUserID(userID uuid.UUID) ([]*entities.CalendarFeedToken, error)
+
+	// TouchFeedToken updates the last used timestamp of a feed token.
+	TouchFeedToken(tokenID uuid.UUID) error
+
+	// DeleteFeedToken removes (revokes) a feed token.
+	DeleteFeedToken(tokenID uuid.UUID) error
+}
@@ -0,0 +1,42 @@
+package interfaces
+
+import (
+	"io"
+
+	"github.com/google/uuid"
+)
+
+// Import result statuses
+const (
+	ImportStatusCreated    = "created"
+	ImportStatusSkipped    = "skipped"
+	ImportStatusDowngraded = "downgraded"
+)
+
+// CalendarImportResult describes what happened to a single imported event.
+type CalendarImportResult struct {
+	UID        string     `json:"uid"`
+	Title      string     `json:"title"`
+	Status     string     `json:"status"` // created, skipped, downgraded
+	Reason     string     `json:"reason,omitempty"`
+	Warnings   []string   `json:"warnings,omitempty"`
+	EventID    *uuid.UUID `json:"eventId,omitempty"` // nil for skipped events and dry runs
+	Exceptions int        `json:"exceptions"`        // number of EXDATE/RECURRENCE-ID exceptions
+}
+
+// CalendarImportReport summarizes an import run.
+type CalendarImportReport struct {
+	DryRun     bool                    `json:"dryRun"`
+	Created    int                     `json:"created"`
+	Skipped    int                     `json:"skipped"`
+	Downgraded int                     `json:"downgraded"`
+	Results    []*CalendarImportResult `json:"results"`
+}
+
+// CalendarImportService defines methods for importing iCalendar (.ics) files.
+type CalendarImportService interface {
+	// ImportICS parses an .ics file and creates its events for a user.
+	// defaultDomain is used for events whose CATEGORIES do not match a valid domain.
+	// With dryRun nothing is written, but the report shows what would happen.
+	ImportICS(userID uuid.UUID, r io.Reader, defaultDomain string, dryRun bool) (*CalendarImportReport, error)
+}
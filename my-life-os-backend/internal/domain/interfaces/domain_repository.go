@@ -0,0 +1,34 @@
+package interfaces
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
+)
+
+// DomainRepository defines methods for domain data access.
+type DomainRepository interface {
+	// CreateDomain adds a new domain to the database.
+	CreateDomain(domain *entities.Domain) error
+
+	// CreateDomainsIfNotExist adds domains, skipping names the user already has.
+	CreateDomainsIfNotExist(domains []*entities.Domain) error
+
+	// FindDomainByID retrieves a domain by its ID.
+	FindDomainByID(domainID uuid.UUID) (*entities.Domain, error)
+
+	// FindDomainsByUserID retrieves all domains for a user.
+	FindDomainsByUserID(userID uuid.UUID, includeArchived bool) ([]*entities.Domain, error)
+
+	// UpdateDomain modifies a domain; if it was renamed, tasks and events referencing the old name are rewritten.
+	UpdateDomain(domain *entities.Domain, oldName string) error
+
+	// MergeDomains moves all tasks and events of the source domain to the target and deletes the source.
+	MergeDomains(source, target *entities.Domain) error
+
+	// CountDomainReferences counts the tasks, events and event exceptions using a domain name.
+	CountDomainReferences(userID uuid.UUID, name string) (int64, error)
+
+	// DeleteDomain removes a domain from the database.
+	DeleteDomain(domainID uuid.UUID) error
+}
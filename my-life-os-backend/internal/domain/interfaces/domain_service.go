@@ -0,0 +1,28 @@
+package interfaces
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
+)
+
+// DomainService defines methods for domain business logic.
+type DomainService interface {
+	// CreateDomain creates a new domain
+	CreateDomain(userID uuid.UUID, name, color, icon string) (*entities.Domain, error)
+
+	// GetDomain retrieves a single domain (ensures user owns it)
+	GetDomain(domainID, userID uuid.UUID) (*entities.Domain, error)
+
+	// GetUserDomains retrieves the domains of a user (the defaults are created on first use)
+	GetUserDomains(userID uuid.UUID, includeArchived bool) ([]*entities.Domain, error)
+
+	// UpdateDomain updates a domain, renaming rewrites all tasks and events using it
+	UpdateDomain(domainID, userID uuid.UUID, name, color, icon string, archived bool) (*entities.Domain, error)
+
+	// MergeDomain moves all tasks and events of a domain into another one and deletes it
+	MergeDomain(sourceID, targetID, userID uuid.UUID) (*entities.Domain, error)
+
+	// DeleteDomain deletes an unused domain
+	DeleteDomain(domainID, userID uuid.UUID) error
+}
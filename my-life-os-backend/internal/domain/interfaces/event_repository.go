@@ -12,20 +12,49 @@ type EventRepository interface {
 	// CreateEvent adds a new event to the database.
 	CreateEvent(event *entities.Event) error
 
+	// CreateEventWithExceptions adds a new event with its exceptions in one transaction.
+	CreateEventWithExceptions(event *entities.Event, exceptions []*entities.EventException) error
+
 	// FindEventByID retrieves an event by its ID.
 	FindEventByID(eventID uuid.UUID) (*entities.Event, error)
 
+	// FindEventsByUserID retrieves all events for a user (base events, not expanded)
+	FindEventsByUserID(userID uuid.UUID) ([]*entities.Event, error)
+
+	// FindEventByICalUID retrieves a user's event by its iCalendar UID.
+	FindEventByICalUID(userID uuid.UUID, icalUID string) (*entities.Event, error)
+
 	// FindEventsByUserIDAndDateRange retrieves all events for a user within a date range
 	FindEventsByUserIDAndDateRange(userID uuid.UUID, start, end time.Time) ([]*entities.Event, error)
 
 	// UpdateEvent modifies an existing event.
 	UpdateEvent(event *entities.Event) error
 
-	// DeleteEvent removes an event from the database.
+	// DeleteEvent moves an event to the trash (its exceptions are kept).
 	DeleteEvent(eventID uuid.UUID) error
 
+	// FindDeletedEventsByUserID retrieves the trashed events of a user.
+	FindDeletedEventsByUserID(userID uuid.UUID) ([]*entities.Event, error)
+
+	// FindDeletedEventByID retrieves an event in the trash by its ID.
+	FindDeletedEventByID(eventID uuid.UUID) (*entities.Event, error)
+
+	// RestoreEvent takes an event out of the trash.
+	RestoreEvent(eventID uuid.UUID) error
+
+	// PurgeEvent permanently removes an event with its exceptions.
+	PurgeEvent(eventID uuid.UUID) error
+
+	// PurgeDeletedEvents permanently removes the events moved to the trash before a time and returns how many.
+	PurgeDeletedEvents(before time.Time) (int64, error)
+
+	// MergeEventSeries saves the extended parent series and the exceptions moved to it from the split,
+	// then deletes the split (splits of the split are linked to the parent)
+	MergeEventSeries(parent, split *entities.Event, moved []*entities.EventException) error
+
 	// Exception handling
 	CreateEventException(exception *entities.EventException) error
+	FindEventExceptionByID(exceptionID uuid.UUID) (*entities.EventException, error)
 	FindEventExceptionsByEventID(eventID uuid.UUID) ([]*entities.EventException, error)
 	FindEventExceptionsByDateRange(eventID uuid.UUID, start, end time.Time) ([]*entities.EventException, error)
 	DeleteEventException(exceptionID uuid.UUID) error
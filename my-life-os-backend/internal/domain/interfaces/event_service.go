@@ -8,27 +8,79 @@ import (
 	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
 )
 
+// EventConflict is an existing event occurrence that overlaps a planned event
+type EventConflict struct {
+	EventID        uuid.UUID `json:"eventId"`
+	Title          string    `json:"title"`
+	OccurrenceDate time.Time `json:"occurrenceDate"` // Start of the conflicting occurrence
+	EndDate        time.Time `json:"endDate"`
+	IsRecurring    bool      `json:"isRecurring"`
+	PlannedStart   time.Time `json:"plannedStart"` // Occurrence of the planned event that collides
+	OverlapMinutes int       `json:"overlapMinutes"`
+}
+
+// ConflictQuery describes a planned event (or a single edited occurrence) to check for conflicts
+type ConflictQuery struct {
+	StartDate      time.Time
+	EndDate        *time.Time
+	AllDay         bool
+	Timezone       string  // Zone the recurrence is expanded in and results are returned in (empty = user's timezone)
+	RecurrenceRule *string // Every occurrence of a recurring event is checked (up to one year ahead)
+
+	ExcludeEventID    *uuid.UUID // Event being edited, its occurrences do not conflict with themselves
+	ExcludeOccurrence *time.Time // Only skip this occurrence of ExcludeEventID (editing a single occurrence)
+}
+
+// EventConflictError is returned when an event overlaps existing events and conflicts are not allowed
+type EventConflictError struct {
+	Conflicts []*EventConflict
+}
+
+// Error implements the error interface
+func (e *EventConflictError) Error() string {
+	return "event conflicts with an existing event"
+}
+
 // EventService defines methods for event business logic.
 type EventService interface {
-	// CreateEvent creates a new event
+	// CreateEvent creates a new event (conflicts return an EventConflictError unless allowConflict is set)
 	CreateEvent(userID uuid.UUID, title string, startDate time.Time, endDate *time.Time, allDay bool,
-		domain string, isRecurring bool, recurrenceType *string, recurrenceEnd *time.Time,
-		recurrenceDays *string, hideFromAgenda bool) (*entities.Event, error)
+		timezone string, domain string, isRecurring bool, recurrenceRule *string, hideFromAgenda bool, allowConflict bool) (*entities.Event, error)
+
+	// ValidateEvent runs the validation of CreateEvent (fields, recurrence and conflicts) without creating anything
+	ValidateEvent(userID uuid.UUID, title string, startDate time.Time, endDate *time.Time, allDay bool,
+		timezone string, domain string, isRecurring bool, recurrenceRule *string, allowConflict bool) error
 
 	// GetEvent retrieves a single event (ensures user owns it)
 	GetEvent(eventID, userID uuid.UUID) (*entities.Event, error)
 
-	// GetUserEventsInRange retrieves all events (expanded occurrences) for a user in date range
-	GetUserEventsInRange(userID uuid.UUID, start, end time.Time) ([]*entities.Event, error)
+	// GetUserEventsInRange retrieves all events (expanded occurrences) for a user in date range,
+	// with dates in the given timezone (empty = user's timezone)
+	GetUserEventsInRange(userID uuid.UUID, start, end time.Time, timezone string) ([]*entities.Event, error)
 
-	// UpdateEvent updates an event (with edit scope: "this", "following", "all")
+	// UpdateEvent updates an event (with edit scope: "this", "following", "all"),
+	// conflicts return an EventConflictError unless allowConflict is set
 	UpdateEvent(eventID, userID uuid.UUID, occurrenceDate *time.Time, editScope string,
-		title string, startDate time.Time, endDate *time.Time, allDay bool, domain string,
-		recurrenceType *string, recurrenceEnd *time.Time, recurrenceDays *string, hideFromAgenda bool) (*entities.Event, error)
+		title string, startDate time.Time, endDate *time.Time, allDay bool, timezone string, domain string,
+		recurrenceRule *string, hideFromAgenda bool, allowConflict bool) (*entities.Event, error)
 
-	// DeleteEvent deletes an event (with delete scope: "this", "following", "all")
+	// DeleteEvent deletes an event (with delete scope: "this", "following", "all" - "all" moves it to the trash)
 	DeleteEvent(eventID, userID uuid.UUID, occurrenceDate *time.Time, deleteScope string) error
 
-	// CheckConflict checks if event conflicts with existing events (only for non-all-day)
-	CheckConflict(userID uuid.UUID, startDate time.Time, endDate *time.Time, allDay bool, excludeEventID *uuid.UUID) (bool, error)
+	// GetEventExceptions retrieves the deleted and modified occurrences of a recurring event
+	GetEventExceptions(eventID, userID uuid.UUID) ([]*entities.EventException, error)
+
+	// RestoreOccurrence brings back an occurrence deleted with the "this" scope
+	RestoreOccurrence(eventID, exceptionID, userID uuid.UUID) error
+
+	// RevertOccurrence resets an occurrence edited with the "this" scope to the series values
+	RevertOccurrence(eventID, exceptionID, userID uuid.UUID) error
+
+	// MergeSplit merges a series split off by a "following" edit back into its parent series.
+	// The parent continues with its own values until the end of the split.
+	MergeSplit(eventID, userID uuid.UUID) (*entities.Event, error)
+
+	// FindConflicts returns every existing occurrence that overlaps the planned event (only for non-all-day),
+	// recurring events are checked occurrence by occurrence
+	FindConflicts(userID uuid.UUID, query ConflictQuery) ([]*EventConflict, error)
 }
@@ -0,0 +1,51 @@
+package interfaces
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BusyInterval is a period blocked by an event occurrence
+type BusyInterval struct {
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+	EventID uuid.UUID `json:"eventId"`
+	Title   string    `json:"title"`
+	Domain  string    `json:"domain"`
+	AllDay  bool      `json:"allDay"`
+}
+
+// FreeSlot is a free period that fits the requested duration
+type FreeSlot struct {
+	Start          time.Time `json:"start"`
+	End            time.Time `json:"end"`            // Start + requested duration
+	FreeUntil      time.Time `json:"freeUntil"`      // End of the free gap (within working hours)
+	DayBusyMinutes int       `json:"dayBusyMinutes"` // Booked time during that day's working hours
+}
+
+// FreeBusyQuery describes a free/busy or slot search
+type FreeBusyQuery struct {
+	Start         time.Time
+	End           time.Time
+	Timezone      string   // Zone for working hours and results (empty = user's timezone)
+	IgnoreDomains []string // Events of these domains do not block time (e.g. "Holidays")
+	IgnoreAllDay  bool     // All-day events do not block time
+
+	// Slot search only
+	Duration     time.Duration
+	Buffer       time.Duration // Kept free before and after every busy interval
+	WorkdayStart int           // Minutes after midnight (e.g. 540 = 09:00)
+	WorkdayEnd   int           // Minutes after midnight (e.g. 1080 = 18:00)
+	Limit        int           // Maximum number of slots (0 = default)
+}
+
+// FreeBusyService defines methods for availability queries on the expanded calendar.
+// Events that started more than 31 days before the queried range are not considered, even if they last into it.
+type FreeBusyService interface {
+	// GetBusyIntervals returns the busy intervals of a user (recurrences and exceptions included)
+	GetBusyIntervals(userID uuid.UUID, query FreeBusyQuery) ([]*BusyInterval, error)
+
+	// FindFreeSlots returns free slots for the requested duration, ranked by how busy their day is
+	FindFreeSlots(userID uuid.UUID, query FreeBusyQuery) ([]*FreeSlot, error)
+}
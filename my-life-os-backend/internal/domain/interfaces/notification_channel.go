@@ -0,0 +1,33 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Notification is a reminder ready to be delivered
+type Notification struct {
+	DeliveryID uuid.UUID `json:"deliveryId"`
+	UserID     uuid.UUID `json:"userId"`
+	EntityType string    `json:"entityType"`
+	EntityID   uuid.UUID `json:"entityId"`
+	Title      string    `json:"title"`
+	Body       string    `json:"body"`
+	DueAt      time.Time `json:"dueAt"`
+	Email      string    `json:"-"` // Recipient for the email channel if the rule has no target
+	Target     *string   `json:"-"` // Rule target (email address or webhook URL)
+}
+
+// NotificationChannel delivers notifications through one transport (log, email, webhook, webpush).
+type NotificationChannel interface {
+	// Name returns the channel name used in reminder rules
+	Name() string
+
+	// ValidateTarget checks a rule target before the rule is stored
+	ValidateTarget(target *string) error
+
+	// Send delivers a notification, an error causes a retry
+	Send(ctx context.Context, notification *Notification) error
+}
@@ -1,6 +1,8 @@
 package interfaces
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 
 	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
@@ -23,9 +25,27 @@ type ProjectRepository interface {
 	// UpdateProject modifies an existing project.
 	UpdateProject(project *entities.Project) error
 
-	// DeleteProject removes a project from the database.
+	// ReplaceWIPLimits replaces the per-status WIP limits of a project.
+	ReplaceWIPLimits(projectID uuid.UUID, limits []*entities.ProjectWIPLimit) error
+
+	// DeleteProject moves a project to the trash (its task assignments and tech stack are kept).
 	DeleteProject(projectID uuid.UUID) error
 
+	// FindDeletedProjectsByUserID retrieves the trashed projects of a user.
+	FindDeletedProjectsByUserID(userID uuid.UUID) ([]*entities.Project, error)
+
+	// FindDeletedProjectByID retrieves a project in the trash by its ID.
+	FindDeletedProjectByID(projectID uuid.UUID) (*entities.Project, error)
+
+	// RestoreProject takes a project out of the trash.
+	RestoreProject(projectID uuid.UUID) error
+
+	// PurgeProject permanently removes a project with its task assignments and tech stack links.
+	PurgeProject(projectID uuid.UUID) error
+
+	// PurgeDeletedProjects permanently removes the projects moved to the trash before a time and returns how many.
+	PurgeDeletedProjects(before time.Time) (int64, error)
+
 	// AssignTask assigns a task to a project.
 	AssignTask(projectTask *entities.ProjectTask) error
 
@@ -34,4 +54,7 @@ type ProjectRepository interface {
 
 	// FindProjectTasks retrieves all tasks assigned to a project.
 	FindProjectTasks(projectID uuid.UUID) ([]*entities.ProjectTask, error)
+
+	// FindProjectTasksByTaskIDs retrieves the project assignments of tasks (with their projects and WIP limits).
+	FindProjectTasksByTaskIDs(taskIDs []uuid.UUID) ([]*entities.ProjectTask, error)
 }
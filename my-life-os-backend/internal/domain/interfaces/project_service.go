@@ -6,6 +6,13 @@ import (
 	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
 )
 
+// ProjectTaskGraph is the tasks of a project with their dependencies (for drawing the graph)
+type ProjectTaskGraph struct {
+	Tasks         []*entities.ProjectTask    `json:"tasks"`
+	Dependencies  []*entities.TaskDependency `json:"dependencies"`  // Dependencies touching a project task or subtask
+	ExternalTasks []*entities.Task           `json:"externalTasks"` // Tasks outside the project at the other end of a dependency
+}
+
 // ProjectService defines the interface for project management business logic.
 type ProjectService interface {
 	// CreateProject creates a new project for a user.
@@ -23,7 +30,7 @@ type ProjectService interface {
 	// UpdateProject updates an existing project for a user.
 	UpdateProject(projectID, userID uuid.UUID, title, description string, status string, repositoryURL string, techStackIDs []uuid.UUID) (*entities.Project, error)
 
-	// DeleteProject removes a project by its ID for a user.
+	// DeleteProject moves a project to the trash by its ID for a user.
 	DeleteProject(projectID, userID uuid.UUID) error
 
 	// AssignTaskToProject assigns a task to a project for a user.
@@ -32,9 +39,10 @@ type ProjectService interface {
 	// UnassignTaskFromProject removes a task assignment from a project for a user.
 	UnassignTaskFromProject(projectID, taskID, userID uuid.UUID) error
 
-	// GetProjectTasks retrieves all tasks assigned to a project for a user.
-	GetProjectTasks(projectID, userID uuid.UUID) ([]*entities.ProjectTask, error)
+	// GetProjectTasks retrieves all tasks assigned to a project for a user with the dependency graph.
+	GetProjectTasks(projectID, userID uuid.UUID) (*ProjectTaskGraph, error)
 
-	// CalculateProgress calculates the completion percentage of a project.
-	CalculateProgress(projectID uuid.UUID) (float64, error)
+	// CalculateProgress calculates the completion percentage of a project
+	// (optionally counting the completed part of tasks with subtasks).
+	CalculateProgress(projectID uuid.UUID, includeSubtasks bool) (float64, error)
 }
@@ -0,0 +1,22 @@
+package interfaces
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
+)
+
+// PushSubscriptionRepository defines methods for Web Push subscription data access.
+type PushSubscriptionRepository interface {
+	// SaveSubscription stores a subscription (an existing endpoint is updated).
+	SaveSubscription(subscription *entities.PushSubscription) error
+
+	// FindSubscriptionsByUserID retrieves all subscriptions of a user.
+	FindSubscriptionsByUserID(userID uuid.UUID) ([]*entities.PushSubscription, error)
+
+	// DeleteSubscriptionByEndpoint removes a subscription of a user by its endpoint.
+	DeleteSubscriptionByEndpoint(userID uuid.UUID, endpoint string) error
+
+	// DeleteSubscription removes a subscription (e.g. when the push service reports it as expired).
+	DeleteSubscription(subscriptionID uuid.UUID) error
+}
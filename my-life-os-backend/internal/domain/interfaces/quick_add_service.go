@@ -0,0 +1,33 @@
+package interfaces
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/quickadd"
+)
+
+// QuickAddInput is a natural-language input such as "Submit thesis draft fri 17:00 !high #University"
+type QuickAddInput struct {
+	Text          string
+	Type          string // Force task or event (empty = detect)
+	Timezone      string // IANA timezone, defaults to the user's timezone
+	Domain        string // Used if the text names no domain
+	AllowConflict bool   // Events only
+}
+
+// QuickAddResult is the created task or event with the interpretation of the input
+type QuickAddResult struct {
+	Interpretation *quickadd.Result `json:"interpretation"`
+	Task           *entities.Task   `json:"task,omitempty"`
+	Event          *entities.Event  `json:"event,omitempty"`
+}
+
+// QuickAddService defines methods for creating tasks and events from natural language.
+type QuickAddService interface {
+	// PreviewQuickAdd interprets an input without creating anything (for confirmation)
+	PreviewQuickAdd(userID uuid.UUID, input QuickAddInput) (*quickadd.Result, error)
+
+	// QuickAdd interprets an input and creates the task or event
+	QuickAdd(userID uuid.UUID, input QuickAddInput) (*QuickAddResult, error)
+}
@@ -0,0 +1,43 @@
+package interfaces
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
+)
+
+// ReminderRepository defines methods for reminder rule and delivery data access.
+type ReminderRepository interface {
+	// CreateRule stores a new reminder rule.
+	CreateRule(rule *entities.ReminderRule) error
+
+	// FindRuleByID retrieves a reminder rule by its ID.
+	FindRuleByID(ruleID uuid.UUID) (*entities.ReminderRule, error)
+
+	// FindRulesByUserID retrieves the reminder rules of a user, optionally for a single entity.
+	FindRulesByUserID(userID uuid.UUID, entityType *string, entityID *uuid.UUID) ([]*entities.ReminderRule, error)
+
+	// FindAllRules retrieves the reminder rules of all users (used by the scheduler).
+	FindAllRules() ([]*entities.ReminderRule, error)
+
+	// DeleteRule removes a reminder rule together with its pending deliveries.
+	DeleteRule(ruleID uuid.UUID) error
+
+	// CreateDelivery stores a delivery unless one already exists for the same rule and due time.
+	CreateDelivery(delivery *entities.ReminderDelivery) error
+
+	// ClaimDueDeliveries locks pending deliveries that are due and postpones them by the lease,
+	// so a crashed worker's deliveries are retried once the lease expires.
+	ClaimDueDeliveries(now time.Time, lease time.Duration, limit int) ([]*entities.ReminderDelivery, error)
+
+	// MarkDeliverySent marks a delivery as sent.
+	MarkDeliverySent(deliveryID uuid.UUID, sentAt time.Time) error
+
+	// MarkDeliveryAttemptFailed records a failed attempt and either schedules a retry or gives up.
+	MarkDeliveryAttemptFailed(deliveryID uuid.UUID, lastError string, nextAttemptAt *time.Time) error
+
+	// FindDeliveriesByUserID retrieves the most recent deliveries of a user.
+	FindDeliveriesByUserID(userID uuid.UUID, limit int) ([]*entities.ReminderDelivery, error)
+}
@@ -0,0 +1,46 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
+)
+
+// ReminderService defines methods for reminder rules and push subscriptions.
+type ReminderService interface {
+	// CreateRule creates a reminder rule for an event, task or routine (ensures user owns the entity)
+	CreateRule(userID uuid.UUID, entityType string, entityID uuid.UUID, offsetMinutes int, channel string, target *string) (*entities.ReminderRule, error)
+
+	// GetRules retrieves the reminder rules of a user, optionally for a single entity
+	GetRules(userID uuid.UUID, entityType *string, entityID *uuid.UUID) ([]*entities.ReminderRule, error)
+
+	// DeleteRule deletes a reminder rule (ensures user owns it)
+	DeleteRule(ruleID, userID uuid.UUID) error
+
+	// GetDeliveries retrieves the most recent deliveries of a user
+	GetDeliveries(userID uuid.UUID, limit int) ([]*entities.ReminderDelivery, error)
+
+	// GetChannels returns the names of the configured channels
+	GetChannels() []string
+
+	// GetVAPIDPublicKey returns the key browsers subscribe with (empty if Web Push is not configured)
+	GetVAPIDPublicKey() string
+
+	// SubscribePush stores a Web Push subscription for a user
+	SubscribePush(userID uuid.UUID, endpoint, p256dh, auth string) (*entities.PushSubscription, error)
+
+	// UnsubscribePush removes a Web Push subscription of a user
+	UnsubscribePush(userID uuid.UUID, endpoint string) error
+}
+
+// ReminderScheduler plans and delivers reminders in the background.
+type ReminderScheduler interface {
+	// Start runs the scheduler until the context is cancelled
+	Start(ctx context.Context)
+
+	// RunOnce plans upcoming deliveries and sends the due ones
+	RunOnce(ctx context.Context, now time.Time) error
+}
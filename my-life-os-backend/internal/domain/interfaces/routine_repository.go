@@ -22,9 +22,24 @@ type RoutineRepository interface {
 	// Update routine
 	UpdateRoutine(routine *entities.Routine) error
 
-	// Delete routine
+	// Move routine to the trash (completions are kept)
 	DeleteRoutine(id uuid.UUID) error
 
+	// Get the trashed routines of a user
+	GetDeletedRoutinesByUserID(userID uuid.UUID) ([]*entities.Routine, error)
+
+	// Get a routine in the trash by ID
+	GetDeletedRoutineByID(id uuid.UUID) (*entities.Routine, error)
+
+	// Take routine out of the trash
+	RestoreRoutine(id uuid.UUID) error
+
+	// Permanently delete routine with its completions
+	PurgeRoutine(id uuid.UUID) error
+
+	// Permanently delete the routines moved to the trash before a time (returns how many)
+	PurgeDeletedRoutines(before time.Time) (int64, error)
+
 	// Update streak counters
 	UpdateStreak(id uuid.UUID, currentStreak, longestStreak int) error
 
@@ -37,4 +52,22 @@ type RoutineRepository interface {
 
 	// Get completion history for a routine (for streak calculation)
 	GetCompletionHistory(routineID uuid.UUID, limit int) ([]*entities.RoutineCompletion, error)
+
+	// Get a completion/skip event by ID
+	GetCompletionByID(id uuid.UUID) (*entities.RoutineCompletion, error)
+
+	// Get the completions of a routine between two dates (inclusive, oldest first)
+	GetCompletionsInRange(routineID uuid.UUID, from, to time.Time) ([]*entities.RoutineCompletion, error)
+
+	// Get the completions of all routines of a user between two dates (inclusive, oldest first)
+	GetCompletionsByUserIDInRange(userID uuid.UUID, from, to time.Time) ([]*entities.RoutineCompletion, error)
+
+	// Get all completions of a routine (oldest first, for recomputing streaks)
+	GetAllCompletions(routineID uuid.UUID) ([]*entities.RoutineCompletion, error)
+
+	// Update the date or status of a completion/skip event
+	UpdateCompletion(completion *entities.RoutineCompletion) error
+
+	// Delete a completion/skip event
+	DeleteCompletion(id uuid.UUID) error
 }
@@ -1,11 +1,70 @@
 package interfaces
 
 import (
+	"time"
+
 	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
 
 	"github.com/google/uuid"
 )
 
+// RoutineDay is one heatmap cell: how the routines due on a day went
+type RoutineDay struct {
+	Date      string `json:"date"` // YYYY-MM-DD
+	Completed int    `json:"completed"`
+	Skipped   int    `json:"skipped"`
+	Missed    int    `json:"missed"` // Scheduled but neither completed nor skipped (derived from the frequency)
+}
+
+// RoutineRate is the completion rate of a period or weekday (completed / due, skipped days count as due)
+type RoutineRate struct {
+	Period    string  `json:"period,omitempty"`  // Week start (YYYY-MM-DD, Monday) or month (YYYY-MM)
+	Weekday   *int    `json:"weekday,omitempty"` // 0-6 (0=Sunday) for weekday rates
+	Due       int     `json:"due"`
+	Completed int     `json:"completed"`
+	Rate      float64 `json:"rate"` // 0-1 (0 without due days)
+}
+
+// StreakWindow is the longest run of completions in a range
+type StreakWindow struct {
+	Length int    `json:"length"`
+	Start  string `json:"start,omitempty"` // YYYY-MM-DD
+	End    string `json:"end,omitempty"`   // YYYY-MM-DD
+}
+
+// RoutineTrend compares the completion rate with the period of the same length before
+type RoutineTrend struct {
+	Rate         float64  `json:"rate"`
+	PreviousRate *float64 `json:"previousRate"` // nil without due days in the previous period
+	Change       *float64 `json:"change"`       // Rate - PreviousRate
+}
+
+// RoutineStats are the statistics of one routine or of all routines of a user over a range
+type RoutineStats struct {
+	RoutineID     *uuid.UUID    `json:"routineId,omitempty"` // nil for all routines
+	From          string        `json:"from"`
+	To            string        `json:"to"`
+	Heatmap       []RoutineDay  `json:"heatmap"` // One entry per day (oldest first)
+	Due           int           `json:"due"`
+	Completed     int           `json:"completed"`
+	Skipped       int           `json:"skipped"`
+	Missed        int           `json:"missed"`
+	Weeks         []RoutineRate `json:"weeks"`
+	Months        []RoutineRate `json:"months"`
+	Weekdays      []RoutineRate `json:"weekdays"`     // Sunday to Saturday
+	BestWeekdays  []int         `json:"bestWeekdays"` // Weekdays with the highest rate
+	LongestStreak StreakWindow  `json:"longestStreak"`
+	Trend         RoutineTrend  `json:"trend"`
+}
+
+// RoutineOccurrence is a routine listed on a routine day with its completion state
+type RoutineOccurrence struct {
+	Routine *entities.Routine
+	Day     time.Time  // Routine day (date at UTC midnight, as stored in completions)
+	Start   *time.Time // Specific time in the user's timezone (nil for AM/PM/all-day routines)
+	Status  string     // "pending", "completed" or "skipped"
+}
+
 // RoutineService defines the interface for routine business logic
 type RoutineService interface {
 	// CreateRoutine creates a new routine for a user
@@ -14,6 +73,7 @@ type RoutineService interface {
 		title, frequency string,
 		weekday, dayOfMonth, quarterlyDay *int,
 		yearlyDate *entities.YearlyDate,
+		schedule entities.RoutineSchedule,
 		isSkippable, showStreak bool,
 		timeType string,
 		specificTime *string,
@@ -25,17 +85,18 @@ type RoutineService interface {
 	// GetRoutines retrieves all routines for a user with optional frequency filter
 	GetRoutines(userID uuid.UUID, frequency *string) ([]*entities.Routine, error)
 
-	// UpdateRoutine updates an existing routine for a user
+	// UpdateRoutine updates an existing routine for a user (only the set schedule fields change)
 	UpdateRoutine(
 		routineID, userID uuid.UUID,
 		title, frequency *string,
 		weekday, dayOfMonth, quarterlyDay *int,
 		yearlyDate *entities.YearlyDate,
+		schedule entities.RoutineSchedule,
 		isSkippable, showStreak *bool,
 		timeType, specificTime *string,
 	) (*entities.Routine, error)
 
-	// DeleteRoutine removes a routine by its ID for a user
+	// DeleteRoutine moves a routine to the trash by its ID for a user
 	DeleteRoutine(routineID, userID uuid.UUID) error
 
 	// CompleteRoutine marks routine as done for current cycle and updates streak
@@ -44,6 +105,29 @@ type RoutineService interface {
 	// SkipRoutine marks routine as skipped for current cycle (preserves streak if skippable)
 	SkipRoutine(routineID, userID uuid.UUID) error
 
+	// LogCompletion records a completion or skip for today or a past date (YYYY-MM-DD) and recomputes the streaks
+	LogCompletion(routineID, userID uuid.UUID, date, status string) (*entities.RoutineCompletion, *entities.Routine, error)
+
+	// UpdateCompletion changes the date or status of a completion/skip and recomputes the streaks
+	UpdateCompletion(routineID, completionID, userID uuid.UUID, date, status *string) (*entities.RoutineCompletion, *entities.Routine, error)
+
+	// DeleteCompletion removes a completion/skip and recomputes the streaks
+	DeleteCompletion(routineID, completionID, userID uuid.UUID) (*entities.Routine, error)
+
+	// GetCompletions retrieves the completions/skips of a routine between two dates (YYYY-MM-DD, inclusive)
+	GetCompletions(routineID, userID uuid.UUID, from, to string) ([]*entities.RoutineCompletion, error)
+
+	// GetRoutineStats retrieves the statistics of a routine between two dates (YYYY-MM-DD, inclusive, default last year)
+	GetRoutineStats(routineID, userID uuid.UUID, from, to string) (*RoutineStats, error)
+
+	// GetAllRoutineStats retrieves the statistics of all routines of a user between two dates
+	GetAllRoutineStats(userID uuid.UUID, from, to string) (*RoutineStats, error)
+
 	// GetTodaysRoutines retrieves routines relevant for today based on frequency and schedule
+	// (quota and completion window routines until they are done for the period or it ends)
 	GetTodaysRoutines(userID uuid.UUID) ([]*entities.Routine, error)
+
+	// GetRoutineOccurrences retrieves the routines listed on each routine day between two dates (inclusive):
+	// scheduled routines, and period-based routines while their period is open or on the days they were done
+	GetRoutineOccurrences(userID uuid.UUID, first, last time.Time) ([]*RoutineOccurrence, error)
 }
@@ -0,0 +1,43 @@
+package interfaces
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
+)
+
+// TagUsage is a tag with the number of tasks using it
+type TagUsage struct {
+	entities.Tag `gorm:"embedded"`
+	UsageCount   int64 `json:"usageCount"`
+}
+
+// TagRepository defines methods for tag data access.
+type TagRepository interface {
+	// CreateTagsIfNotExist adds tags, skipping names the user already has.
+	CreateTagsIfNotExist(tags []*entities.Tag) error
+
+	// FindTagByID retrieves a tag by its ID.
+	FindTagByID(tagID uuid.UUID) (*entities.Tag, error)
+
+	// FindTagByName retrieves a tag of a user by its name.
+	FindTagByName(userID uuid.UUID, name string) (*entities.Tag, error)
+
+	// FindTagsByNames retrieves the tags of a user with the given names.
+	FindTagsByNames(userID uuid.UUID, names []string) ([]*entities.Tag, error)
+
+	// FindTagUsage retrieves the tags of a user starting with prefix, most used first (limit 0 = all).
+	FindTagUsage(userID uuid.UUID, prefix string, limit int) ([]*TagUsage, error)
+
+	// SetTaskTags replaces the tags of a task.
+	SetTaskTags(taskID uuid.UUID, tagIDs []uuid.UUID) error
+
+	// RenameTag renames a tag and touches all tasks using it in one transaction.
+	RenameTag(tag *entities.Tag) error
+
+	// MergeTags moves all tasks of the source tag to the target and deletes the source in one transaction.
+	MergeTags(source, target *entities.Tag) error
+
+	// DeleteTag removes a tag from all tasks and from the database.
+	DeleteTag(tagID uuid.UUID) error
+}
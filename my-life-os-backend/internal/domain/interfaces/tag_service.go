@@ -0,0 +1,34 @@
+package interfaces
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
+)
+
+// TagCondition matches tasks having at least one of the tags (or none of them if negated)
+type TagCondition struct {
+	Tags   []string
+	Negate bool
+}
+
+// TagQuery is a parsed tag filter such as "tag:errand -tag:waiting OR tag:urgent,today".
+// A task matches if it matches every condition of at least one group.
+type TagQuery struct {
+	Groups [][]TagCondition
+}
+
+// TagService defines methods for tag business logic.
+type TagService interface {
+	// GetUserTags retrieves the tags of a user starting with prefix, most used first (for autocomplete)
+	GetUserTags(userID uuid.UUID, prefix string, limit int) ([]*TagUsage, error)
+
+	// RenameTag renames a tag on all tasks using it
+	RenameTag(tagID, userID uuid.UUID, name string) (*entities.Tag, error)
+
+	// MergeTag moves all tasks of a tag to another one and deletes it
+	MergeTag(sourceID, targetID, userID uuid.UUID) (*entities.Tag, error)
+
+	// DeleteTag removes a tag from all tasks and deletes it
+	DeleteTag(tagID, userID uuid.UUID) error
+}
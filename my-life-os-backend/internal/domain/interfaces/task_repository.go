@@ -1,11 +1,55 @@
 package interfaces
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 
 	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
 )
 
+// Task sort keys
+const (
+	TaskSortDeadline  = "deadline"  // Tasks without a deadline come last in both directions
+	TaskSortPriority  = "priority"  // Low < Medium < High
+	TaskSortCreatedAt = "createdAt" // Creation time
+)
+
+// TaskFilter restricts a task query (zero values match every task)
+type TaskFilter struct {
+	Domain         string
+	Status         string
+	NoDeadline     bool       // Only tasks without a deadline
+	DeadlineFrom   *time.Time // Deadline >= DeadlineFrom
+	DeadlineBefore *time.Time // Deadline < DeadlineBefore
+	ExcludeDone    bool       // Only open tasks (not done or cancelled)
+	Tags           *TagQuery  // AND/OR/NOT of tags
+	Blocked        *bool      // Only open tasks with (true) or without (false) open blockers
+	TopLevel       bool       // Only tasks that are not subtasks
+}
+
+// TaskSort is one key of a multi-key task ordering
+type TaskSort struct {
+	Key  string // deadline, priority, createdAt
+	Desc bool
+}
+
+// TaskCursor marks the last task of a page (the next page starts after it)
+type TaskCursor struct {
+	Deadline  *time.Time `json:"d"`
+	Priority  string     `json:"p"`
+	CreatedAt time.Time  `json:"c"`
+	ID        uuid.UUID  `json:"id"`
+}
+
+// TaskCounts are the number of matching tasks in total and per domain and status.
+// Domain counts ignore the domain filter and status counts the status filter (for sidebar badges).
+type TaskCounts struct {
+	Total    int64            `json:"total"`
+	Domains  map[string]int64 `json:"domains"`
+	Statuses map[string]int64 `json:"statuses"`
+}
+
 // TaskRepository defines methods for task data access.
 type TaskRepository interface {
 	// CreateTask adds a new task to the database.
@@ -14,17 +58,92 @@ type TaskRepository interface {
 	// FindTaskByID retrieves a task by its ID.
 	FindTaskByID(taskID uuid.UUID) (*entities.Task, error)
 
+	// FindTaskWithSubtasks retrieves a task with its nested subtasks (ordered by position).
+	FindTaskWithSubtasks(taskID uuid.UUID) (*entities.Task, error)
+
+	// FindSubtasks retrieves the direct subtasks of a task (ordered by position).
+	FindSubtasks(parentTaskID uuid.UUID) ([]*entities.Task, error)
+
 	// FindByUserID retrieves all tasks for a user
 	FindTasksByUserID(userID uuid.UUID) ([]*entities.Task, error)
 
-	// FindByUserIDAndFilters retrieves tasks with filters
-	FindTasksByUserIDAndFilters(userID uuid.UUID, filters map[string]any) ([]*entities.Task, error)
+	// FindTasksByUserIDAndFilters retrieves the tasks of a user matching a filter in the given order
+	// (ties are ordered by ID), starting after cursor (nil = first page), at most limit tasks (0 = all).
+	FindTasksByUserIDAndFilters(userID uuid.UUID, filter TaskFilter, sort []TaskSort, cursor *TaskCursor, limit int) ([]*entities.Task, error)
+
+	// CountTasksByUserIDAndFilters counts the tasks of a user matching a filter, in total and per domain and status.
+	CountTasksByUserIDAndFilters(userID uuid.UUID, filter TaskFilter) (*TaskCounts, error)
 
 	// UpdateTask modifies an existing task.
 	UpdateTask(task *entities.Task) error
 
-	UpdateStatus(taskID uuid.UUID, status string) error
+	// UpdateTaskStatuses stores the status and workflow timestamps of tasks together with their status changes.
+	UpdateTaskStatuses(tasks []*entities.Task, changes []*entities.TaskStatusChange) error
+
+	// FindTaskStatusChanges retrieves the status history of a task (oldest first).
+	FindTaskStatusChanges(taskID uuid.UUID) ([]*entities.TaskStatusChange, error)
+
+	// UpdatePositions stores the order of subtasks (position = index in taskIDs).
+	UpdatePositions(taskIDs []uuid.UUID) error
+
+	// FindTaskRanks retrieves the ranks of a user's tasks in all lists of a context (domain, project or status).
+	FindTaskRanks(userID uuid.UUID, list string) ([]*entities.TaskRank, error)
+
+	// SaveTaskRanks creates or replaces the ranks of tasks in lists.
+	SaveTaskRanks(ranks []*entities.TaskRank) error
 
-	// DeleteTask removes a task from the database.
+	// DeleteTask moves a task and all of its subtasks to the trash and stops timers running on them.
 	DeleteTask(taskID uuid.UUID) error
+
+	// FindDeletedTasksByUserID retrieves the trashed tasks of a user that were not deleted together with their parent.
+	FindDeletedTasksByUserID(userID uuid.UUID) ([]*entities.Task, error)
+
+	// FindDeletedTaskByID retrieves a task in the trash by its ID.
+	FindDeletedTaskByID(taskID uuid.UUID) (*entities.Task, error)
+
+	// RestoreTask takes a trashed task out of the trash together with the subtasks deleted with it.
+	RestoreTask(task *entities.Task) error
+
+	// PurgeTask permanently removes a task, its subtasks and everything linked to them.
+	PurgeTask(taskID uuid.UUID) error
+
+	// PurgeDeletedTasks permanently removes the tasks moved to the trash before a time and returns how many.
+	PurgeDeletedTasks(before time.Time) (int64, error)
+
+	// CreateTaskSeries adds a new recurring task series to the database.
+	CreateTaskSeries(series *entities.TaskSeries) error
+
+	// FindTaskSeriesByID retrieves a series by its ID.
+	FindTaskSeriesByID(seriesID uuid.UUID) (*entities.TaskSeries, error)
+
+	// FindTaskSeriesWithTasks retrieves a series with all of its instances (ordered by deadline).
+	FindTaskSeriesWithTasks(seriesID uuid.UUID) (*entities.TaskSeries, error)
+
+	// FindOpenSeriesTasks retrieves the instances of a series that are not done or cancelled yet.
+	FindOpenSeriesTasks(seriesID uuid.UUID) ([]*entities.Task, error)
+
+	// UpdateTaskSeries modifies an existing series.
+	UpdateTaskSeries(series *entities.TaskSeries) error
+
+	// FindTasksByIDs retrieves several tasks by their IDs.
+	FindTasksByIDs(taskIDs []uuid.UUID) ([]*entities.Task, error)
+
+	// CreateTaskDependency adds a dependency (existing dependencies are kept) if check accepts the current
+	// dependencies of the user. Dependencies of the same user are added one at a time.
+	CreateTaskDependency(userID uuid.UUID, dependency *entities.TaskDependency, check func(dependencies []*entities.TaskDependency) error) error
+
+	// DeleteTaskDependency removes a dependency.
+	DeleteTaskDependency(taskID, blockedByID uuid.UUID) error
+
+	// FindTaskDependenciesByUserID retrieves all dependencies between the tasks of a user (including trashed ones).
+	FindTaskDependenciesByUserID(userID uuid.UUID) ([]*entities.TaskDependency, error)
+
+	// FindTaskDependencies retrieves the dependencies in which any of the tasks is blocked or blocking.
+	FindTaskDependencies(taskIDs []uuid.UUID) ([]*entities.TaskDependency, error)
+
+	// FindBlockers retrieves the tasks a task is blocked by.
+	FindBlockers(taskID uuid.UUID) ([]*entities.Task, error)
+
+	// FindBlockedTasks retrieves the tasks blocked by a task.
+	FindBlockedTasks(taskID uuid.UUID) ([]*entities.Task, error)
 }
@@ -1,30 +1,132 @@
 package interfaces
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 
 	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
 )
 
+// TaskRecurrence describes how a recurring task repeats
+type TaskRecurrence struct {
+	Type           string `json:"type"`           // schedule, after_completion
+	RecurrenceRule string `json:"recurrenceRule"` // RFC 5545 RRULE (schedule only)
+	IntervalDays   int    `json:"intervalDays"`   // Days after completion (after_completion only)
+	Timezone       string `json:"timezone"`       // IANA zone (empty = user's timezone)
+}
+
+// Task dependency filters
+const (
+	TaskDependencyBlocked    = "blocked"    // Open tasks with open blockers
+	TaskDependencyActionable = "actionable" // Open tasks without open blockers
+)
+
+// TaskQuery lists the tasks of a user (empty fields match every task)
+type TaskQuery struct {
+	Domain     string
+	Status     string
+	TimeFilter string // long_term, overdue, today, tomorrow, next_week, next_month
+	Dependency string // blocked, actionable
+	TagFilter  string // AND/OR/NOT of tags, e.g. "tag:errand -tag:waiting OR tag:urgent"
+	Sort       string // Comma-separated keys, "-" for descending, e.g. "deadline,-priority" (empty = deadline,-createdAt)
+	Cursor     string // NextCursor of the previous page (empty = first page)
+	Limit      int    // Page size (0 = all tasks)
+}
+
+// TaskPage is one page of a task list with the counts for sidebar badges
+type TaskPage struct {
+	Tasks      []*entities.Task `json:"tasks"`
+	NextCursor string           `json:"nextCursor,omitempty"` // Empty on the last page
+	Counts     *TaskCounts      `json:"counts"`
+}
+
+// TaskDependencies are the tasks a task is blocked by and the tasks it blocks
+type TaskDependencies struct {
+	BlockedBy []*entities.Task `json:"blockedBy"`
+	Blocking  []*entities.Task `json:"blocking"`
+}
+
+// TaskStatusResult is a task after a status change with the next instance of a recurring task
+// and the open blockers it was completed despite
+type TaskStatusResult struct {
+	Task     *entities.Task   `json:"task"`
+	NextTask *entities.Task   `json:"nextTask,omitempty"` // Next instance when a recurring task was closed
+	Blockers []*entities.Task `json:"blockers,omitempty"` // Open tasks the completed task is blocked by
+	Warnings []string         `json:"warnings"`
+}
+
+// TaskHistory is the status history of a task with its workflow metrics
+type TaskHistory struct {
+	Changes          []*entities.TaskStatusChange `json:"changes"` // Oldest first
+	StartedAt        *time.Time                   `json:"startedAt"`
+	CompletedAt      *time.Time                   `json:"completedAt"`
+	LeadTimeSeconds  *int64                       `json:"leadTimeSeconds"`  // Created to completed (nil while not Done)
+	CycleTimeSeconds *int64                       `json:"cycleTimeSeconds"` // Started to completed (nil while not Done or never started)
+}
+
 // TaskService defines the interface for task management business logic.
 type TaskService interface {
-	// CreateTask creates a new task for a user
-	CreateTask(userID uuid.UUID, title, description, priority, domain string, deadline *string) (*entities.Task, error)
+	// CreateTask creates a new task for a user (a subtask if parentTaskID is set) together with its tags
+	// (unknown tags are created). With a recurrence the task becomes the first instance of a new series.
+	CreateTask(userID uuid.UUID, title, description, priority, domain string, deadline *string, parentTaskID *uuid.UUID, recurrence *TaskRecurrence, tags []string) (*entities.Task, error)
 
 	// GetTask retrieves a single task by its ID for a user
 	GetTask(taskID, userID uuid.UUID) (*entities.Task, error)
 
+	// GetTaskWithSubtasks retrieves a task with its nested subtasks for a user
+	GetTaskWithSubtasks(taskID, userID uuid.UUID) (*entities.Task, error)
+
+	// ReorderSubtasks sets the order of the subtasks of a task (every subtask must be listed once)
+	ReorderSubtasks(taskID, userID uuid.UUID, subtaskIDs []uuid.UUID) ([]*entities.Task, error)
+
 	// GetUserTasks retrieves all tasks for a user
 	GetUserTasks(userID uuid.UUID) ([]*entities.Task, error)
 
-	GetUserTasksWithFilters(userID uuid.UUID, domain, status, timeFilter string) ([]*entities.Task, error)
+	// GetUserTasksWithFilters retrieves a sorted page of the tasks of a user matching the filters,
+	// with the counts per domain and status
+	GetUserTasksWithFilters(userID uuid.UUID, query TaskQuery) (*TaskPage, error)
+
+	// SetTaskTags replaces the tags of a task for a user (unknown tags are created)
+	SetTaskTags(taskID, userID uuid.UUID, tags []string) (*entities.Task, error)
 
 	// UpdateTask updates an existing task for a user
 	UpdateTask(taskID, userID uuid.UUID, title, description, priority, domain string, deadline *string) (*entities.Task, error)
 
-	// ToggleTaskStatus toggles the completion status of a task for a user
-	ToggleTaskStatus(taskID, userID uuid.UUID) (*entities.Task, error)
+	// ToggleTaskStatus toggles the completion status of a task for a user.
+	// A task with open subtasks can only be completed with force (which completes the subtasks too),
+	// reopening a subtask reopens its completed parents.
+	// A task with open blockers is completed with a warning listing them.
+	// Completing an instance of a recurring task returns the next instance created for it.
+	// Toggling moves an open task to Done and a done or cancelled task back to Todo (within the workflow).
+	ToggleTaskStatus(taskID, userID uuid.UUID, force bool) (*TaskStatusResult, error)
+
+	// SetTaskStatus moves a task of a user to another status allowed by the workflow and records the change.
+	// Closing a task (Done or Cancelled) follows the rules of ToggleTaskStatus for subtasks, blockers and recurring tasks.
+	SetTaskStatus(taskID, userID uuid.UUID, status string, force bool) (*TaskStatusResult, error)
+
+	// GetTaskHistory retrieves the status history of a task with its lead and cycle time for a user
+	GetTaskHistory(taskID, userID uuid.UUID) (*TaskHistory, error)
 
-	// DeleteTask removes a task by its ID for a user
+	// DeleteTask moves a task and its subtasks to the trash by its ID for a user
 	DeleteTask(taskID, userID uuid.UUID) error
+
+	// GetTaskSeries retrieves a recurring task series with all of its instances for a user
+	GetTaskSeries(seriesID, userID uuid.UUID) (*entities.TaskSeries, error)
+
+	// UpdateTaskSeries updates the open instances of a series (and so all future ones).
+	// A nil description keeps the text of each instance, a nil recurrence keeps the current schedule.
+	UpdateTaskSeries(seriesID, userID uuid.UUID, title string, description *string, priority, domain string, recurrence *TaskRecurrence) (*entities.TaskSeries, error)
+
+	// StopTaskSeries stops creating new instances of a series (deleteOpen also removes its open instances)
+	StopTaskSeries(seriesID, userID uuid.UUID, deleteOpen bool) (*entities.TaskSeries, error)
+
+	// AddTaskDependency marks a task as blocked by another task of the user (cycles are rejected)
+	AddTaskDependency(taskID, blockedByID, userID uuid.UUID) (*entities.TaskDependency, error)
+
+	// RemoveTaskDependency removes a dependency between two tasks of the user
+	RemoveTaskDependency(taskID, blockedByID, userID uuid.UUID) error
+
+	// GetTaskDependencies retrieves the tasks a task is blocked by and the tasks it blocks for a user
+	GetTaskDependencies(taskID, userID uuid.UUID) (*TaskDependencies, error)
 }
@@ -0,0 +1,41 @@
+package interfaces
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
+)
+
+// TimeEntryRepository defines methods for time entry data access.
+type TimeEntryRepository interface {
+	// CreateTimeEntry adds a new time entry to the database.
+	CreateTimeEntry(entry *entities.TimeEntry) error
+
+	// StartTimer stops the running timer of the user (at the start of the new one) and adds the new timer.
+	StartTimer(entry *entities.TimeEntry) error
+
+	// StopRunningTimer stops the running timer of a user (nil if none was running).
+	StopRunningTimer(userID uuid.UUID, endedAt time.Time) (*entities.TimeEntry, error)
+
+	// FindTimeEntryByID retrieves a time entry by its ID (with its task).
+	FindTimeEntryByID(entryID uuid.UUID) (*entities.TimeEntry, error)
+
+	// FindRunningTimeEntry retrieves the running timer of a user (with its task).
+	FindRunningTimeEntry(userID uuid.UUID) (*entities.TimeEntry, error)
+
+	// FindTimeEntriesByUserID retrieves the entries of a user overlapping [from, to) (with their tasks, ordered by start).
+	// A nil taskID matches every task, entries of trashed tasks are left out.
+	FindTimeEntriesByUserID(userID uuid.UUID, from, to time.Time, taskID *uuid.UUID) ([]*entities.TimeEntry, error)
+
+	// FindOverlappingTimeEntries retrieves the entries of a user overlapping [from, to), except excludeID.
+	// Running timers overlap everything after their start.
+	FindOverlappingTimeEntries(userID uuid.UUID, from, to time.Time, excludeID uuid.UUID) ([]*entities.TimeEntry, error)
+
+	// UpdateTimeEntry modifies an existing time entry.
+	UpdateTimeEntry(entry *entities.TimeEntry) error
+
+	// DeleteTimeEntry removes a time entry from the database.
+	DeleteTimeEntry(entryID uuid.UUID) error
+}
@@ -0,0 +1,63 @@
+package interfaces
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
+)
+
+// TimeTotal is the time spent in one group of a time report
+type TimeTotal struct {
+	Key     string `json:"key"`   // Day or week start (YYYY-MM-DD), project or task ID (empty = no project), domain name
+	Label   string `json:"label"` // Project or task title, domain name or the date
+	Seconds int64  `json:"seconds"`
+}
+
+// TimeReport sums up the time entries of a date range.
+// Subtasks count for their top-level task and for the projects of the task or any of its parents;
+// a task in several projects counts for each of them.
+type TimeReport struct {
+	From         string      `json:"from"` // YYYY-MM-DD
+	To           string      `json:"to"`   // YYYY-MM-DD (inclusive)
+	Timezone     string      `json:"timezone"`
+	TotalSeconds int64       `json:"totalSeconds"`
+	Days         []TimeTotal `json:"days"`     // Every day of the range in order
+	Weeks        []TimeTotal `json:"weeks"`    // Every week (from Monday) of the range in order
+	Projects     []TimeTotal `json:"projects"` // Most time first
+	Domains      []TimeTotal `json:"domains"`  // Most time first
+	Tasks        []TimeTotal `json:"tasks"`    // Top-level tasks, most time first
+}
+
+// TimeEntryService defines methods for time tracking business logic.
+type TimeEntryService interface {
+	// StartTimer starts a timer on a task at the server time, stopping the running timer of the user.
+	// Starting the task that is already running returns the running timer.
+	StartTimer(userID, taskID uuid.UUID, note string) (*entities.TimeEntry, error)
+
+	// StopTimer stops the running timer of a user at the server time
+	StopTimer(userID uuid.UUID) (*entities.TimeEntry, error)
+
+	// GetRunningTimer retrieves the running timer of a user (nil if none is running)
+	GetRunningTimer(userID uuid.UUID) (*entities.TimeEntry, error)
+
+	// CreateTimeEntry adds a manual entry (it must be in the past and not overlap other entries)
+	CreateTimeEntry(userID, taskID uuid.UUID, startedAt, endedAt time.Time, note string) (*entities.TimeEntry, error)
+
+	// UpdateTimeEntry edits an entry (running timers keep running with a nil end)
+	UpdateTimeEntry(entryID, userID, taskID uuid.UUID, startedAt time.Time, endedAt *time.Time, note string) (*entities.TimeEntry, error)
+
+	// DeleteTimeEntry removes an entry (ensures user owns it)
+	DeleteTimeEntry(entryID, userID uuid.UUID) error
+
+	// GetTimeEntries retrieves the entries of a user in a date range (YYYY-MM-DD, inclusive, empty = current week),
+	// optionally only those of a task
+	GetTimeEntries(userID uuid.UUID, from, to, timezone string, taskID *uuid.UUID) ([]*entities.TimeEntry, error)
+
+	// GetTimeReport sums up the time of a user in a date range per day, week, project, domain and task
+	GetTimeReport(userID uuid.UUID, from, to, timezone string) (*TimeReport, error)
+
+	// ExportTimeEntries exports the entries of a user in a date range as CSV
+	ExportTimeEntries(userID uuid.UUID, from, to, timezone string) ([]byte, error)
+}
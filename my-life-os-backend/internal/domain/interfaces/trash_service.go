@@ -0,0 +1,55 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
+)
+
+// Trash item types
+const (
+	TrashTypeTask    = "tasks"
+	TrashTypeRoutine = "routines"
+	TrashTypeProject = "projects"
+	TrashTypeEvent   = "events"
+)
+
+// TrashTypes lists every type of item that can be moved to the trash
+var TrashTypes = []string{TrashTypeTask, TrashTypeRoutine, TrashTypeProject, TrashTypeEvent}
+
+// Trash lists the trashed items of a user per type (most recently deleted first, nil for types not requested)
+type Trash struct {
+	Tasks         []*entities.Task    `json:"tasks"` // Subtasks deleted with their parent are restored with it
+	Routines      []*entities.Routine `json:"routines"`
+	Projects      []*entities.Project `json:"projects"`
+	Events        []*entities.Event   `json:"events"`
+	RetentionDays int                 `json:"retentionDays"` // Items are purged this many days after deletion
+}
+
+// TrashService defines methods for restoring and purging deleted items.
+type TrashService interface {
+	// GetTrash retrieves the trashed items of a user, of a single type or of all types (empty itemType)
+	GetTrash(userID uuid.UUID, itemType string) (*Trash, error)
+
+	// RestoreItem takes an item of a user out of the trash with everything deleted together with it
+	RestoreItem(userID uuid.UUID, itemType string, itemID uuid.UUID) error
+
+	// DeleteItem permanently deletes a trashed item of a user
+	DeleteItem(userID uuid.UUID, itemType string, itemID uuid.UUID) error
+
+	// EmptyTrash permanently deletes all trashed items of a user
+	EmptyTrash(userID uuid.UUID) error
+
+	// PurgeExpired permanently deletes the items of all users trashed longer than the retention period
+	// and returns how many were purged
+	PurgeExpired(now time.Time) (int64, error)
+}
+
+// TrashPurger purges expired trash in the background.
+type TrashPurger interface {
+	// Start runs the purger until the context is cancelled
+	Start(ctx context.Context)
+}
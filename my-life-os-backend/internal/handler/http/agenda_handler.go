@@ -0,0 +1,56 @@
+package http
+
+import (
+	"time"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type AgendaHandler struct {
+	agendaService interfaces.AgendaService
+}
+
+// NewAgendaHandler creates a new agenda handler
+func NewAgendaHandler(agendaService interfaces.AgendaService) *AgendaHandler {
+	return &AgendaHandler{
+		agendaService: agendaService,
+	}
+}
+
+// GetAgenda handles GET /api/agenda?date=2024-05-13&view=week&timezone=Europe/Berlin
+func (h *AgendaHandler) GetAgenda(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Date defaults to today (in the agenda's timezone)
+	var date *time.Time
+	if dateStr := c.Query("date"); dateStr != "" {
+		parsed, err := time.Parse("2006-01-02", dateStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid date format (expected YYYY-MM-DD)",
+			})
+		}
+		date = &parsed
+	}
+
+	// Get agenda
+	agenda, err := h.agendaService.GetAgenda(userID, date, c.Query("view", interfaces.AgendaViewDay), c.Query("timezone"))
+	if err != nil {
+		if err.Error() == "invalid view" || err.Error() == "invalid timezone" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to retrieve agenda",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"agenda": agenda,
+	})
+}
@@ -36,10 +36,16 @@ type LoginRequest struct {
 }
 
 type UserResponse struct {
-	ID       uuid.UUID `json:"id"`
-	Email    string    `json:"email"`
-	Name     string    `json:"name"`
-	Timezone string    `json:"timezone,omitempty"`
+	ID              uuid.UUID `json:"id"`
+	Email           string    `json:"email"`
+	Name            string    `json:"name"`
+	Timezone        string    `json:"timezone,omitempty"`
+	DayRolloverHour int       `json:"dayRolloverHour"`
+}
+
+type UpdateSettingsRequest struct {
+	Timezone        *string `json:"timezone"`        // IANA timezone
+	DayRolloverHour *int    `json:"dayRolloverHour"` // Routine days end at this hour (0-12)
 }
 
 // GetStatus checks if the application needs setup
@@ -82,10 +88,11 @@ func (h *AuthHandler) Setup(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
 		"message": "Account created successfully",
 		"user": UserResponse{
-			ID:       user.ID,
-			Email:    user.Email,
-			Name:     user.Name,
-			Timezone: user.Timezone,
+			ID:              user.ID,
+			Email:           user.Email,
+			Name:            user.Name,
+			Timezone:        user.Timezone,
+			DayRolloverHour: user.DayRolloverHour,
 		},
 	})
 }
@@ -114,10 +121,11 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 	return c.JSON(fiber.Map{
 		"message": "Login successful",
 		"user": UserResponse{
-			ID:       user.ID,
-			Email:    user.Email,
-			Name:     user.Name,
-			Timezone: user.Timezone,
+			ID:              user.ID,
+			Email:           user.Email,
+			Name:            user.Name,
+			Timezone:        user.Timezone,
+			DayRolloverHour: user.DayRolloverHour,
 		},
 	})
 }
@@ -195,6 +203,37 @@ func (h *AuthHandler) GetMe(c *fiber.Ctx) error {
 	})
 }
 
+// UpdateSettings changes the timezone and routine day rollover hour of the current user
+// PUT /api/auth/settings
+func (h *AuthHandler) UpdateSettings(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	var req UpdateSettingsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	user, err := h.authService.UpdateSettings(userID, req.Timezone, req.DayRolloverHour)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Settings updated successfully",
+		"user": UserResponse{
+			ID:              user.ID,
+			Email:           user.Email,
+			Name:            user.Name,
+			Timezone:        user.Timezone,
+			DayRolloverHour: user.DayRolloverHour,
+		},
+	})
+}
+
 // Helper: Set auth cookies (HttpOnly, Secure in production, SameSite Lax)
 func (h *AuthHandler) setAuthCookies(c *fiber.Ctx, accessToken, refreshToken string) {
 	// Access Token Cookie (15 minutes)
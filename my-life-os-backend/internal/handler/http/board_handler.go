@@ -0,0 +1,166 @@
+package http
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type BoardHandler struct {
+	boardService interfaces.BoardService
+}
+
+// NewBoardHandler creates a new board handler
+func NewBoardHandler(boardService interfaces.BoardService) *BoardHandler {
+	return &BoardHandler{
+		boardService: boardService,
+	}
+}
+
+// MoveTaskRequest represents the request body for moving a task in a list
+type MoveTaskRequest struct {
+	List     string     `json:"list"` // domain, project, status
+	Key      string     `json:"key"`  // Domain name, project ID or status
+	AfterID  *uuid.UUID `json:"afterId"`
+	BeforeID *uuid.UUID `json:"beforeId"`
+}
+
+// SetWIPLimitsRequest represents the request body for setting the WIP limits of a project
+type SetWIPLimitsRequest struct {
+	Limits map[string]int `json:"limits"` // Status -> max tasks (0 = no limit)
+}
+
+// boardError maps board service errors to responses
+func boardError(c *fiber.Ctx, err error) error {
+	if strings.HasPrefix(err.Error(), "unauthorized:") {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Task or project not found",
+		})
+	}
+	if err.Error() == "task has open subtasks" {
+		return taskStatusError(c, err)
+	}
+	if strings.HasPrefix(err.Error(), "invalid") {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": "Failed to update board",
+	})
+}
+
+// GetOrderedTasks handles GET /api/tasks/lists/:list (?key=domain name, project ID or status)
+func (h *BoardHandler) GetOrderedTasks(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Get tasks in manual order
+	tasks, err := h.boardService.GetOrderedTasks(userID, c.Params("list"), c.Query("key"))
+	if err != nil {
+		return boardError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"tasks": tasks,
+	})
+}
+
+// MoveTask handles POST /api/tasks/:id/move
+// (?force=true closes open subtasks too when moving into a closed status column)
+func (h *BoardHandler) MoveTask(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Parse task ID
+	taskID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid task ID",
+		})
+	}
+
+	// Parse request body
+	var req MoveTaskRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Move task (only its own rank changes in most cases)
+	move := interfaces.TaskMove{
+		List:     req.List,
+		Key:      req.Key,
+		AfterID:  req.AfterID,
+		BeforeID: req.BeforeID,
+	}
+	result, err := h.boardService.MoveTask(userID, taskID, move, c.QueryBool("force", false))
+	if err != nil {
+		return boardError(c, err)
+	}
+
+	return c.JSON(result)
+}
+
+// GetProjectBoard handles GET /api/projects/:id/board
+func (h *BoardHandler) GetProjectBoard(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Parse project ID
+	projectID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	// Get board with one column per status
+	board, err := h.boardService.GetProjectBoard(userID, projectID)
+	if err != nil {
+		return boardError(c, err)
+	}
+
+	return c.JSON(board)
+}
+
+// SetProjectWIPLimits handles PUT /api/projects/:id/wip-limits
+func (h *BoardHandler) SetProjectWIPLimits(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Parse project ID
+	projectID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	// Parse request body
+	var req SetWIPLimitsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Replace limits and return the updated board
+	board, err := h.boardService.SetProjectWIPLimits(userID, projectID, req.Limits)
+	if err != nil {
+		return boardError(c, err)
+	}
+
+	return c.JSON(board)
+}
@@ -0,0 +1,133 @@
+package http
+
+import (
+	"strings"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type CalendarFeedHandler struct {
+	feedService interfaces.CalendarFeedService
+}
+
+// NewCalendarFeedHandler creates a new calendar feed handler
+func NewCalendarFeedHandler(feedService interfaces.CalendarFeedService) *CalendarFeedHandler {
+	return &CalendarFeedHandler{
+		feedService: feedService,
+	}
+}
+
+// CreateFeedTokenRequest represents the request body for creating a feed token
+type CreateFeedTokenRequest struct {
+	Name string `json:"name"` // e.g. "Thunderbird", "Phone"
+}
+
+// CreateFeedToken handles POST /api/calendar-feeds
+func (h *CalendarFeedHandler) CreateFeedToken(c *fiber.Ctx) error {
+	// Get user ID from context (set by auth middleware)
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Parse request body
+	var req CreateFeedTokenRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Validate required fields
+	if req.Name == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Name is required",
+		})
+	}
+
+	// Create token
+	token, secret, err := h.feedService.CreateFeedToken(userID, req.Name)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	// The secret is only returned once - clients must store the URL
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message":   "Calendar feed created successfully",
+		"feedToken": token,
+		"url":       c.BaseURL() + "/api/calendar/feed/" + secret + ".ics",
+	})
+}
+
+// GetFeedTokens handles GET /api/calendar-feeds
+func (h *CalendarFeedHandler) GetFeedTokens(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Get tokens
+	tokens, err := h.feedService.GetUserFeedTokens(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to retrieve calendar feeds",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"feedTokens": tokens,
+	})
+}
+
+// RevokeFeedToken handles DELETE /api/calendar-feeds/:id
+func (h *CalendarFeedHandler) RevokeFeedToken(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Parse token ID
+	tokenID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid feed token ID",
+		})
+	}
+
+	// Revoke token
+	err = h.feedService.RevokeFeedToken(tokenID, userID)
+	if err != nil {
+		if err.Error() == "unauthorized: feed token does not belong to user" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Calendar feed not found",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Calendar feed revoked successfully",
+	})
+}
+
+// GetFeed handles GET /api/calendar/feed/:token.ics (public - authenticated by the secret token)
+func (h *CalendarFeedHandler) GetFeed(c *fiber.Ctx) error {
+	secret := strings.TrimSpace(c.Params("token"))
+
+	feed, err := h.feedService.GenerateFeed(secret)
+	if err != nil {
+		if err.Error() == "invalid feed token" {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Calendar feed not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to generate calendar feed",
+		})
+	}
+
+	c.Set(fiber.HeaderContentType, "text/calendar; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, `inline; filename="mylifeos.ics"`)
+	c.Set(fiber.HeaderCacheControl, "private, max-age=300")
+	return c.Send(feed)
+}
@@ -0,0 +1,72 @@
+package http
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type CalendarImportHandler struct {
+	importService interfaces.CalendarImportService
+}
+
+// NewCalendarImportHandler creates a new calendar import handler
+func NewCalendarImportHandler(importService interfaces.CalendarImportService) *CalendarImportHandler {
+	return &CalendarImportHandler{
+		importService: importService,
+	}
+}
+
+// ImportEvents handles POST /api/events/import?dryRun=true&domain=Personal
+// Accepts either a multipart upload (field "file") or a raw text/calendar body.
+func (h *CalendarImportHandler) ImportEvents(c *fiber.Ctx) error {
+	// Get user ID from context (set by auth middleware)
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Get query parameters
+	dryRun := c.QueryBool("dryRun", false)
+	defaultDomain := c.Query("domain", "Personal")
+
+	// Read the calendar file
+	var reader io.Reader
+	if fileHeader, err := c.FormFile("file"); err == nil {
+		file, err := fileHeader.Open()
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Failed to read uploaded file",
+			})
+		}
+		defer file.Close()
+		reader = file
+	} else {
+		body := c.Body()
+		if len(body) == 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Calendar file is required",
+			})
+		}
+		reader = bytes.NewReader(body)
+	}
+
+	// Import events
+	report, err := h.importService.ImportICS(userID, reader, defaultDomain, dryRun)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	message := "Events imported successfully"
+	if dryRun {
+		message = "Dry run completed, no events were imported"
+	}
+
+	return c.JSON(fiber.Map{
+		"message": message,
+		"report":  report,
+	})
+}
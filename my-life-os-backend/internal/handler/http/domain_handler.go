@@ -0,0 +1,240 @@
+package http
+
+import (
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type DomainHandler struct {
+	domainService interfaces.DomainService
+}
+
+// NewDomainHandler creates a new domain handler
+func NewDomainHandler(domainService interfaces.DomainService) *DomainHandler {
+	return &DomainHandler{
+		domainService: domainService,
+	}
+}
+
+// CreateDomainRequest represents the request body for creating a domain
+type CreateDomainRequest struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+	Icon  string `json:"icon"`
+}
+
+// UpdateDomainRequest represents the request body for updating a domain
+type UpdateDomainRequest struct {
+	Name     string `json:"name"`
+	Color    string `json:"color"`
+	Icon     string `json:"icon"`
+	Archived bool   `json:"archived"`
+}
+
+// MergeDomainRequest represents the request body for merging a domain into another one
+type MergeDomainRequest struct {
+	TargetID string `json:"targetId"`
+}
+
+// CreateDomain handles POST /api/domains
+func (h *DomainHandler) CreateDomain(c *fiber.Ctx) error {
+	// Get user ID from context (set by auth middleware)
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Parse request body
+	var req CreateDomainRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Validate required fields
+	if req.Name == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Name is required",
+		})
+	}
+
+	// Create domain
+	domain, err := h.domainService.CreateDomain(userID, req.Name, req.Color, req.Icon)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Domain created successfully",
+		"domain":  domain,
+	})
+}
+
+// GetDomains handles GET /api/domains (with optional ?includeArchived=true)
+func (h *DomainHandler) GetDomains(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Get domains
+	domains, err := h.domainService.GetUserDomains(userID, c.QueryBool("includeArchived", false))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to retrieve domains",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"domains": domains,
+	})
+}
+
+// GetDomain handles GET /api/domains/:id
+func (h *DomainHandler) GetDomain(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Parse domain ID
+	domainID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid domain ID",
+		})
+	}
+
+	// Get domain
+	domain, err := h.domainService.GetDomain(domainID, userID)
+	if err != nil {
+		if err.Error() == "unauthorized: domain does not belong to user" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Domain not found",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"domain": domain,
+	})
+}
+
+// UpdateDomain handles PUT /api/domains/:id
+func (h *DomainHandler) UpdateDomain(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Parse domain ID
+	domainID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid domain ID",
+		})
+	}
+
+	// Parse request body
+	var req UpdateDomainRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Update domain (renaming rewrites tasks and events)
+	domain, err := h.domainService.UpdateDomain(domainID, userID, req.Name, req.Color, req.Icon, req.Archived)
+	if err != nil {
+		if err.Error() == "unauthorized: domain does not belong to user" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Domain updated successfully",
+		"domain":  domain,
+	})
+}
+
+// MergeDomain handles POST /api/domains/:id/merge
+func (h *DomainHandler) MergeDomain(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Parse domain ID
+	sourceID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid domain ID",
+		})
+	}
+
+	// Parse request body
+	var req MergeDomainRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	targetID, err := uuid.Parse(req.TargetID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid target domain ID",
+		})
+	}
+
+	// Merge source into target
+	domain, err := h.domainService.MergeDomain(sourceID, targetID, userID)
+	if err != nil {
+		if err.Error() == "unauthorized: domain does not belong to user" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Domains merged successfully",
+		"domain":  domain,
+	})
+}
+
+// DeleteDomain handles DELETE /api/domains/:id
+func (h *DomainHandler) DeleteDomain(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Parse domain ID
+	domainID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid domain ID",
+		})
+	}
+
+	// Delete domain
+	err = h.domainService.DeleteDomain(domainID, userID)
+	if err != nil {
+		if err.Error() == "unauthorized: domain does not belong to user" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Domain deleted successfully",
+	})
+}
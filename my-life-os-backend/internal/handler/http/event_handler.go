@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/rrule"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -26,12 +27,15 @@ type CreateEventRequest struct {
 	StartDate      string  `json:"startDate"` // ISO 8601 format
 	EndDate        *string `json:"endDate"`   // Optional, ISO 8601 format
 	AllDay         bool    `json:"allDay"`
+	Timezone       string  `json:"timezone"` // Optional IANA timezone (e.g. Europe/Berlin), defaults to the user's timezone
 	Domain         string  `json:"domain"`
 	IsRecurring    bool    `json:"isRecurring"`
-	RecurrenceType *string `json:"recurrenceType"` // daily, weekly, monthly, yearly
-	RecurrenceEnd  *string `json:"recurrenceEnd"`  // Optional, ISO 8601 format
-	RecurrenceDays *string `json:"recurrenceDays"` // JSON array for weekly: ["monday","wednesday"]
+	RecurrenceRule *string `json:"recurrenceRule"` // RFC 5545 RRULE: "FREQ=MONTHLY;BYDAY=-1FR;COUNT=10"
+	RecurrenceType *string `json:"recurrenceType"` // Legacy (used if recurrenceRule is empty): daily, weekly, monthly, yearly
+	RecurrenceEnd  *string `json:"recurrenceEnd"`  // Legacy: optional, ISO 8601 format
+	RecurrenceDays *string `json:"recurrenceDays"` // Legacy: JSON array for weekly: ["monday","wednesday"]
 	HideFromAgenda bool    `json:"hideFromAgenda"`
+	AllowConflict  bool    `json:"allowConflict"` // Create even if the event overlaps existing events
 }
 
 // UpdateEventRequest represents the request body for updating an event
@@ -42,11 +46,14 @@ type UpdateEventRequest struct {
 	StartDate      string  `json:"startDate"` // ISO 8601 format
 	EndDate        *string `json:"endDate"`   // Optional, ISO 8601 format
 	AllDay         bool    `json:"allDay"`
+	Timezone       string  `json:"timezone"` // Optional, keeps the event's timezone if empty
 	Domain         string  `json:"domain"`
-	RecurrenceType *string `json:"recurrenceType"` // For "following" and "all" scope
-	RecurrenceEnd  *string `json:"recurrenceEnd"`  // Optional, ISO 8601 format
-	RecurrenceDays *string `json:"recurrenceDays"` // JSON array for weekly
+	RecurrenceRule *string `json:"recurrenceRule"` // For "following" and "all" scope
+	RecurrenceType *string `json:"recurrenceType"` // Legacy (used if recurrenceRule is empty)
+	RecurrenceEnd  *string `json:"recurrenceEnd"`  // Legacy: optional, ISO 8601 format
+	RecurrenceDays *string `json:"recurrenceDays"` // Legacy: JSON array for weekly
 	HideFromAgenda bool    `json:"hideFromAgenda"`
+	AllowConflict  bool    `json:"allowConflict"` // Save even if the event overlaps existing events
 }
 
 // DeleteEventRequest represents the request body for deleting an event
@@ -117,6 +124,13 @@ func (h *EventHandler) CreateEvent(c *fiber.Ctx) error {
 		recurrenceEnd = &parsedRecurrenceEnd
 	}
 
+	recurrenceRule, err := resolveRecurrenceRule(req.RecurrenceRule, req.RecurrenceType, req.RecurrenceDays, recurrenceEnd)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
 	// Create event
 	event, err := h.eventService.CreateEvent(
 		userID,
@@ -124,14 +138,20 @@ func (h *EventHandler) CreateEvent(c *fiber.Ctx) error {
 		startDate,
 		endDate,
 		req.AllDay,
+		req.Timezone,
 		req.Domain,
 		req.IsRecurring,
-		req.RecurrenceType,
-		recurrenceEnd,
-		req.RecurrenceDays,
+		recurrenceRule,
 		req.HideFromAgenda,
+		req.AllowConflict,
 	)
 	if err != nil {
+		if conflictErr, ok := err.(*interfaces.EventConflictError); ok {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error":     conflictErr.Error(),
+				"conflicts": conflictErr.Conflicts,
+			})
+		}
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error": err.Error(),
 		})
@@ -143,7 +163,26 @@ func (h *EventHandler) CreateEvent(c *fiber.Ctx) error {
 	})
 }
 
-// GetEvents handles GET /api/events?start=...&end=...
+// resolveRecurrenceRule returns the RRULE of a request, converting the legacy recurrence fields if no rule is given
+func resolveRecurrenceRule(recurrenceRule, recurrenceType, recurrenceDays *string, recurrenceEnd *time.Time) (*string, error) {
+	if recurrenceRule != nil && *recurrenceRule != "" {
+		return recurrenceRule, nil
+	}
+
+	if recurrenceType == nil || *recurrenceType == "" {
+		return nil, nil
+	}
+
+	rule, err := rrule.FromLegacy(*recurrenceType, recurrenceDays, recurrenceEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	value := rule.String()
+	return &value, nil
+}
+
+// GetEvents handles GET /api/events?start=...&end=...&timezone=...
 func (h *EventHandler) GetEvents(c *fiber.Ctx) error {
 	// Get user ID from context
 	userID := c.Locals("userID").(uuid.UUID)
@@ -175,8 +214,13 @@ func (h *EventHandler) GetEvents(c *fiber.Ctx) error {
 	}
 
 	// Get events (expanded with occurrences)
-	events, err := h.eventService.GetUserEventsInRange(userID, start, end)
+	events, err := h.eventService.GetUserEventsInRange(userID, start, end, c.Query("timezone"))
 	if err != nil {
+		if err.Error() == "invalid timezone" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to retrieve events",
 		})
@@ -187,6 +231,72 @@ func (h *EventHandler) GetEvents(c *fiber.Ctx) error {
 	})
 }
 
+// GetConflicts handles GET /api/events/conflicts?start=...&end=...&recurrenceRule=...&excludeEventId=...&occurrenceDate=...
+func (h *EventHandler) GetConflicts(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Parse planned time range
+	start, err := time.Parse(time.RFC3339, c.Query("start"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid start date format",
+		})
+	}
+	end, err := time.Parse(time.RFC3339, c.Query("end"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid end date format",
+		})
+	}
+
+	query := interfaces.ConflictQuery{
+		StartDate: start,
+		EndDate:   &end,
+		AllDay:    c.QueryBool("allDay", false),
+		Timezone:  c.Query("timezone"),
+	}
+
+	if rule := c.Query("recurrenceRule"); rule != "" {
+		query.RecurrenceRule = &rule
+	}
+
+	// Event (or occurrence) being edited
+	if value := c.Query("excludeEventId"); value != "" {
+		eventID, err := uuid.Parse(value)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid event ID",
+			})
+		}
+		query.ExcludeEventID = &eventID
+	}
+	if value := c.Query("occurrenceDate"); value != "" {
+		occurrenceDate, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid occurrence date format",
+			})
+		}
+		query.ExcludeOccurrence = &occurrenceDate
+	}
+
+	conflicts, err := h.eventService.FindConflicts(userID, query)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if conflicts == nil {
+		conflicts = []*interfaces.EventConflict{}
+	}
+
+	return c.JSON(fiber.Map{
+		"hasConflict": len(conflicts) > 0,
+		"conflicts":   conflicts,
+	})
+}
+
 // GetEvent handles GET /api/events/:id
 func (h *EventHandler) GetEvent(c *fiber.Ctx) error {
 	// Get user ID from context
@@ -288,6 +398,13 @@ func (h *EventHandler) UpdateEvent(c *fiber.Ctx) error {
 		recurrenceEnd = &parsedRecurrenceEnd
 	}
 
+	recurrenceRule, err := resolveRecurrenceRule(req.RecurrenceRule, req.RecurrenceType, req.RecurrenceDays, recurrenceEnd)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
 	// Update event
 	event, err := h.eventService.UpdateEvent(
 		eventID,
@@ -298,11 +415,11 @@ func (h *EventHandler) UpdateEvent(c *fiber.Ctx) error {
 		startDate,
 		endDate,
 		req.AllDay,
+		req.Timezone,
 		req.Domain,
-		req.RecurrenceType,
-		recurrenceEnd,
-		req.RecurrenceDays,
+		recurrenceRule,
 		req.HideFromAgenda,
+		req.AllowConflict,
 	)
 	if err != nil {
 		if err.Error() == "unauthorized: event does not belong to user" {
@@ -310,6 +427,12 @@ func (h *EventHandler) UpdateEvent(c *fiber.Ctx) error {
 				"error": err.Error(),
 			})
 		}
+		if conflictErr, ok := err.(*interfaces.EventConflictError); ok {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error":     conflictErr.Error(),
+				"conflicts": conflictErr.Conflicts,
+			})
+		}
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error": err.Error(),
 		})
@@ -378,3 +501,114 @@ func (h *EventHandler) DeleteEvent(c *fiber.Ctx) error {
 		"message": "Event deleted successfully",
 	})
 }
+
+// GetEventExceptions handles GET /api/events/:id/exceptions
+func (h *EventHandler) GetEventExceptions(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Parse event ID
+	eventID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid event ID",
+		})
+	}
+
+	// Get exceptions
+	exceptions, err := h.eventService.GetEventExceptions(eventID, userID)
+	if err != nil {
+		if err.Error() == "unauthorized: event does not belong to user" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Event not found",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"exceptions": exceptions,
+	})
+}
+
+// RestoreOccurrence handles POST /api/events/:id/exceptions/:exceptionId/restore
+func (h *EventHandler) RestoreOccurrence(c *fiber.Ctx) error {
+	return h.removeException(c, h.eventService.RestoreOccurrence, "Occurrence restored successfully")
+}
+
+// RevertOccurrence handles POST /api/events/:id/exceptions/:exceptionId/revert
+func (h *EventHandler) RevertOccurrence(c *fiber.Ctx) error {
+	return h.removeException(c, h.eventService.RevertOccurrence, "Occurrence reverted successfully")
+}
+
+// removeException parses the event and exception IDs shared by restore and revert
+func (h *EventHandler) removeException(c *fiber.Ctx, remove func(eventID, exceptionID, userID uuid.UUID) error, message string) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Parse event ID
+	eventID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid event ID",
+		})
+	}
+
+	// Parse exception ID
+	exceptionID, err := uuid.Parse(c.Params("exceptionId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid exception ID",
+		})
+	}
+
+	err = remove(eventID, exceptionID, userID)
+	if err != nil {
+		if err.Error() == "unauthorized: event does not belong to user" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": message,
+	})
+}
+
+// MergeSplit handles POST /api/events/:id/merge (merges a split series back into its parent)
+func (h *EventHandler) MergeSplit(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Parse event ID
+	eventID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid event ID",
+		})
+	}
+
+	// Merge into parent series
+	event, err := h.eventService.MergeSplit(eventID, userID)
+	if err != nil {
+		if err.Error() == "unauthorized: event does not belong to user" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Events merged successfully",
+		"event":   event,
+	})
+}
@@ -0,0 +1,160 @@
+package http
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type FreeBusyHandler struct {
+	freeBusyService interfaces.FreeBusyService
+}
+
+// NewFreeBusyHandler creates a new free/busy handler
+func NewFreeBusyHandler(freeBusyService interfaces.FreeBusyService) *FreeBusyHandler {
+	return &FreeBusyHandler{
+		freeBusyService: freeBusyService,
+	}
+}
+
+// GetBusy handles GET /api/events/busy?start=...&end=...&ignoreDomains=Holidays&ignoreAllDay=true
+// (events that started more than 31 days before start are not included)
+func (h *FreeBusyHandler) GetBusy(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	query, err := parseFreeBusyQuery(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	busy, err := h.freeBusyService.GetBusyIntervals(userID, query)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"busy": busy,
+	})
+}
+
+// FindFreeSlots handles GET /api/events/free-slots?start=...&end=...&duration=90&from=09:00&to=18:00&buffer=15
+func (h *FreeBusyHandler) FindFreeSlots(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	query, err := parseFreeBusyQuery(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	// Duration and buffer in minutes
+	duration, err := strconv.Atoi(c.Query("duration"))
+	if err != nil || duration <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Duration (minutes) is required",
+		})
+	}
+	query.Duration = time.Duration(duration) * time.Minute
+
+	buffer, err := strconv.Atoi(c.Query("buffer", "0"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid buffer",
+		})
+	}
+	query.Buffer = time.Duration(buffer) * time.Minute
+
+	// Working hours (default 09:00 - 18:00)
+	query.WorkdayStart, err = parseClockTime(c.Query("from", "09:00"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid working hours start",
+		})
+	}
+	query.WorkdayEnd, err = parseClockTime(c.Query("to", "18:00"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid working hours end",
+		})
+	}
+
+	query.Limit = c.QueryInt("limit", 0)
+
+	slots, err := h.freeBusyService.FindFreeSlots(userID, query)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"slots": slots,
+	})
+}
+
+// parseFreeBusyQuery reads the range, timezone and ignore options shared by both endpoints
+func parseFreeBusyQuery(c *fiber.Ctx) (interfaces.FreeBusyQuery, error) {
+	var query interfaces.FreeBusyQuery
+
+	startStr := c.Query("start")
+	endStr := c.Query("end")
+	if startStr == "" || endStr == "" {
+		return query, errors.New("Start and end dates are required")
+	}
+
+	start, err := time.Parse(time.RFC3339, startStr)
+	if err != nil {
+		return query, errors.New("Invalid start date format")
+	}
+	end, err := time.Parse(time.RFC3339, endStr)
+	if err != nil {
+		return query, errors.New("Invalid end date format")
+	}
+
+	query.Start = start
+	query.End = end
+	query.Timezone = c.Query("timezone")
+	query.IgnoreAllDay = c.QueryBool("ignoreAllDay", false)
+
+	if domains := c.Query("ignoreDomains"); domains != "" {
+		query.IgnoreDomains = strings.Split(domains, ",")
+	}
+
+	return query, nil
+}
+
+// parseClockTime parses "HH:MM" into minutes after midnight ("24:00" is allowed as end of day)
+func parseClockTime(value string) (int, error) {
+	parts := strings.Split(value, ":")
+	if len(parts) != 2 {
+		return 0, errors.New("invalid time")
+	}
+
+	hours, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, err
+	}
+	minutes, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, err
+	}
+
+	total := hours*60 + minutes
+	if hours < 0 || minutes < 0 || minutes > 59 || total > 24*60 {
+		return 0, errors.New("invalid time")
+	}
+	return total, nil
+}
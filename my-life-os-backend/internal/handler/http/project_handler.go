@@ -367,8 +367,8 @@ func (h *ProjectHandler) GetProjectTasks(c *fiber.Ctx) error {
 		})
 	}
 
-	// Get project tasks
-	tasks, err := h.projectService.GetProjectTasks(projectID, userID)
+	// Get project tasks with their dependency graph
+	graph, err := h.projectService.GetProjectTasks(projectID, userID)
 	if err != nil {
 		if err.Error() == "unauthorized: project does not belong to user" {
 			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
@@ -381,6 +381,39 @@ func (h *ProjectHandler) GetProjectTasks(c *fiber.Ctx) error {
 	}
 
 	return c.JSON(fiber.Map{
-		"tasks": tasks,
+		"tasks":         graph.Tasks,
+		"dependencies":  graph.Dependencies,
+		"externalTasks": graph.ExternalTasks,
+	})
+}
+
+// GetProjectProgress handles GET /api/projects/:id/progress?includeSubtasks=true
+func (h *ProjectHandler) GetProjectProgress(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Parse project ID
+	projectID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	// Get project (with its tasks and their subtasks)
+	project, err := h.projectService.GetProject(projectID, userID)
+	if err != nil {
+		if err.Error() == "unauthorized: project does not belong to user" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Project not found",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"progress": project.GetProgress(c.QueryBool("includeSubtasks", false)),
 	})
 }
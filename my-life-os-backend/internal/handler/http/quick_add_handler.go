@@ -0,0 +1,105 @@
+package http
+
+import (
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type QuickAddHandler struct {
+	quickAddService interfaces.QuickAddService
+}
+
+// NewQuickAddHandler creates a new quick-add handler
+func NewQuickAddHandler(quickAddService interfaces.QuickAddService) *QuickAddHandler {
+	return &QuickAddHandler{
+		quickAddService: quickAddService,
+	}
+}
+
+// QuickAddRequest represents the request body for a natural-language quick-add
+type QuickAddRequest struct {
+	Text          string `json:"text"`          // e.g. "Submit thesis draft fri 17:00 !high #University"
+	Type          string `json:"type"`          // Optional: task or event (detected from the text if empty)
+	Timezone      string `json:"timezone"`      // Optional IANA timezone, defaults to the user's timezone
+	Domain        string `json:"domain"`        // Optional fallback if the text names no domain
+	AllowConflict bool   `json:"allowConflict"` // Events only
+}
+
+// toInput converts the request body to a quick-add input
+func (r QuickAddRequest) toInput() interfaces.QuickAddInput {
+	return interfaces.QuickAddInput{
+		Text:          r.Text,
+		Type:          r.Type,
+		Timezone:      r.Timezone,
+		Domain:        r.Domain,
+		AllowConflict: r.AllowConflict,
+	}
+}
+
+// PreviewQuickAdd handles POST /api/quick-add/preview
+func (h *QuickAddHandler) PreviewQuickAdd(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Parse request body
+	var req QuickAddRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Interpret text without creating anything
+	interpretation, err := h.quickAddService.PreviewQuickAdd(userID, req.toInput())
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"interpretation": interpretation,
+	})
+}
+
+// QuickAdd handles POST /api/quick-add
+func (h *QuickAddHandler) QuickAdd(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Parse request body
+	var req QuickAddRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Interpret text and create the task or event
+	result, err := h.quickAddService.QuickAdd(userID, req.toInput())
+	if err != nil {
+		if conflictErr, ok := err.(*interfaces.EventConflictError); ok {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error":     conflictErr.Error(),
+				"conflicts": conflictErr.Conflicts,
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	message := "Task created successfully"
+	if result.Event != nil {
+		message = "Event created successfully"
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message":        message,
+		"interpretation": result.Interpretation,
+		"task":           result.Task,
+		"event":          result.Event,
+	})
+}
@@ -0,0 +1,238 @@
+package http
+
+import (
+	"strings"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type ReminderHandler struct {
+	reminderService interfaces.ReminderService
+}
+
+// NewReminderHandler creates a new reminder handler
+func NewReminderHandler(reminderService interfaces.ReminderService) *ReminderHandler {
+	return &ReminderHandler{
+		reminderService: reminderService,
+	}
+}
+
+// CreateReminderRequest represents the request body for creating a reminder rule
+type CreateReminderRequest struct {
+	EntityType    string  `json:"entityType"`    // event, task, routine
+	EntityID      string  `json:"entityId"`      // UUID of the event, task or routine
+	OffsetMinutes int     `json:"offsetMinutes"` // Minutes before the due time
+	Channel       string  `json:"channel"`       // log, email, webhook, webpush
+	Target        *string `json:"target"`        // Email address (optional) or webhook URL
+}
+
+// PushSubscriptionRequest represents a browser PushSubscription (PushSubscription.toJSON())
+type PushSubscriptionRequest struct {
+	Endpoint string `json:"endpoint"`
+	Keys     struct {
+		P256dh string `json:"p256dh"`
+		Auth   string `json:"auth"`
+	} `json:"keys"`
+}
+
+// CreateReminder handles POST /api/reminders
+func (h *ReminderHandler) CreateReminder(c *fiber.Ctx) error {
+	// Get user ID from context (set by auth middleware)
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Parse request body
+	var req CreateReminderRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Validate required fields
+	entityID, err := uuid.Parse(req.EntityID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid entity ID",
+		})
+	}
+	if req.Channel == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Channel is required",
+		})
+	}
+
+	// Create rule
+	rule, err := h.reminderService.CreateRule(userID, req.EntityType, entityID, req.OffsetMinutes, req.Channel, req.Target)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "unauthorized:") {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message":  "Reminder created successfully",
+		"reminder": rule,
+	})
+}
+
+// GetReminders handles GET /api/reminders?entityType=event&entityId=...
+func (h *ReminderHandler) GetReminders(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Optional entity filter
+	var entityType *string
+	if value := c.Query("entityType"); value != "" {
+		entityType = &value
+	}
+
+	var entityID *uuid.UUID
+	if value := c.Query("entityId"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid entity ID",
+			})
+		}
+		entityID = &id
+	}
+
+	// Get rules
+	rules, err := h.reminderService.GetRules(userID, entityType, entityID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to retrieve reminders",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"reminders": rules,
+	})
+}
+
+// DeleteReminder handles DELETE /api/reminders/:id
+func (h *ReminderHandler) DeleteReminder(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Parse rule ID
+	ruleID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid reminder ID",
+		})
+	}
+
+	// Delete rule
+	err = h.reminderService.DeleteRule(ruleID, userID)
+	if err != nil {
+		if err.Error() == "unauthorized: reminder does not belong to user" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Reminder not found",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Reminder deleted successfully",
+	})
+}
+
+// GetDeliveries handles GET /api/reminders/deliveries?limit=50
+func (h *ReminderHandler) GetDeliveries(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	deliveries, err := h.reminderService.GetDeliveries(userID, c.QueryInt("limit", 0))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to retrieve deliveries",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"deliveries": deliveries,
+	})
+}
+
+// GetChannels handles GET /api/reminders/channels
+func (h *ReminderHandler) GetChannels(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{
+		"channels": h.reminderService.GetChannels(),
+	})
+}
+
+// GetVAPIDPublicKey handles GET /api/push/vapid-public-key
+func (h *ReminderHandler) GetVAPIDPublicKey(c *fiber.Ctx) error {
+	key := h.reminderService.GetVAPIDPublicKey()
+	if key == "" {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Web Push is not configured",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"publicKey": key,
+	})
+}
+
+// SubscribePush handles POST /api/push/subscriptions
+func (h *ReminderHandler) SubscribePush(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Parse request body
+	var req PushSubscriptionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	subscription, err := h.reminderService.SubscribePush(userID, req.Endpoint, req.Keys.P256dh, req.Keys.Auth)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message":      "Push subscription saved successfully",
+		"subscription": subscription,
+	})
+}
+
+// UnsubscribePush handles DELETE /api/push/subscriptions (body: { endpoint })
+func (h *ReminderHandler) UnsubscribePush(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Parse request body
+	var req PushSubscriptionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if err := h.reminderService.UnsubscribePush(userID, req.Endpoint); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Push subscription removed successfully",
+	})
+}
@@ -1,11 +1,15 @@
 package http
 
 import (
+	"errors"
+	"strings"
+
 	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
 	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type RoutineHandler struct {
@@ -31,6 +35,9 @@ type CreateRoutineRequest struct {
 	ShowStreak   bool                 `json:"showStreak"`
 	TimeType     string               `json:"timeType"`
 	SpecificTime *string              `json:"specificTime"`
+
+	// Extended schedule (weekdays, intervalDays, startDate, monthlyRule, weekOfMonth, quotaCount, quotaPeriod, completionWindow)
+	entities.RoutineSchedule
 }
 
 // UpdateRoutineRequest represents the request body for updating a routine
@@ -45,6 +52,9 @@ type UpdateRoutineRequest struct {
 	ShowStreak   *bool                `json:"showStreak"`
 	TimeType     *string              `json:"timeType"`
 	SpecificTime *string              `json:"specificTime"`
+
+	// Extended schedule (weekdays, intervalDays, startDate, monthlyRule, weekOfMonth, quotaCount, quotaPeriod, completionWindow)
+	entities.RoutineSchedule
 }
 
 // CreateRoutine handles POST /api/routines
@@ -88,6 +98,7 @@ func (h *RoutineHandler) CreateRoutine(c *fiber.Ctx) error {
 		req.DayOfMonth,
 		req.QuarterlyDay,
 		req.YearlyDate,
+		req.RoutineSchedule,
 		req.IsSkippable,
 		req.ShowStreak,
 		req.TimeType,
@@ -193,6 +204,7 @@ func (h *RoutineHandler) UpdateRoutine(c *fiber.Ctx) error {
 		req.DayOfMonth,
 		req.QuarterlyDay,
 		req.YearlyDate,
+		req.RoutineSchedule,
 		req.IsSkippable,
 		req.ShowStreak,
 		req.TimeType,
@@ -335,3 +347,201 @@ func (h *RoutineHandler) GetTodaysRoutines(c *fiber.Ctx) error {
 		"routines": routines,
 	})
 }
+
+// CompletionRequest represents the request body for logging or changing a completion/skip
+type CompletionRequest struct {
+	Date   *string `json:"date"`   // YYYY-MM-DD (today or earlier)
+	Status *string `json:"status"` // completed or skipped
+}
+
+// completionError maps errors of completion changes to responses
+func completionError(c *fiber.Ctx, err error) error {
+	if err.Error() == "unauthorized: routine does not belong to user" {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Routine or completion not found",
+		})
+	}
+	if strings.HasPrefix(err.Error(), "routine already completed or skipped") {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"error": err.Error(),
+	})
+}
+
+// GetCompletions handles GET /api/routines/:id/completions (?from=YYYY-MM-DD&to=YYYY-MM-DD, default last 30 days)
+func (h *RoutineHandler) GetCompletions(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Parse routine ID
+	routineID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid routine ID",
+		})
+	}
+
+	// Get history
+	completions, err := h.routineService.GetCompletions(routineID, userID, c.Query("from"), c.Query("to"))
+	if err != nil {
+		return completionError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"completions": completions,
+	})
+}
+
+// LogCompletion handles POST /api/routines/:id/completions
+func (h *RoutineHandler) LogCompletion(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Parse routine ID
+	routineID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid routine ID",
+		})
+	}
+
+	// Parse request body
+	var req CompletionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+	if req.Date == nil || req.Status == nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Date and status are required",
+		})
+	}
+
+	// Record completion/skip and recompute streaks
+	completion, routine, err := h.routineService.LogCompletion(routineID, userID, *req.Date, *req.Status)
+	if err != nil {
+		return completionError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"completion": completion,
+		"routine":    routine,
+	})
+}
+
+// UpdateCompletion handles PUT /api/routines/:id/completions/:completionId
+func (h *RoutineHandler) UpdateCompletion(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Parse IDs
+	routineID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid routine ID",
+		})
+	}
+	completionID, err := uuid.Parse(c.Params("completionId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid completion ID",
+		})
+	}
+
+	// Parse request body
+	var req CompletionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Change completion/skip and recompute streaks
+	completion, routine, err := h.routineService.UpdateCompletion(routineID, completionID, userID, req.Date, req.Status)
+	if err != nil {
+		return completionError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"completion": completion,
+		"routine":    routine,
+	})
+}
+
+// DeleteCompletion handles DELETE /api/routines/:id/completions/:completionId
+func (h *RoutineHandler) DeleteCompletion(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Parse IDs
+	routineID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid routine ID",
+		})
+	}
+	completionID, err := uuid.Parse(c.Params("completionId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid completion ID",
+		})
+	}
+
+	// Undo completion/skip and recompute streaks
+	routine, err := h.routineService.DeleteCompletion(routineID, completionID, userID)
+	if err != nil {
+		return completionError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Completion deleted successfully",
+		"routine": routine,
+	})
+}
+
+// GetAllRoutineStats handles GET /api/routines/stats (?from=YYYY-MM-DD&to=YYYY-MM-DD, default last year)
+func (h *RoutineHandler) GetAllRoutineStats(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Get statistics across all routines
+	stats, err := h.routineService.GetAllRoutineStats(userID, c.Query("from"), c.Query("to"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(stats)
+}
+
+// GetRoutineStats handles GET /api/routines/:id/stats (?from=YYYY-MM-DD&to=YYYY-MM-DD, default last year)
+func (h *RoutineHandler) GetRoutineStats(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Parse routine ID
+	routineID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid routine ID",
+		})
+	}
+
+	// Get statistics of the routine
+	stats, err := h.routineService.GetRoutineStats(routineID, userID, c.Query("from"), c.Query("to"))
+	if err != nil {
+		return completionError(c, err)
+	}
+
+	return c.JSON(stats)
+}
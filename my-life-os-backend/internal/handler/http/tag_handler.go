@@ -0,0 +1,165 @@
+package http
+
+import (
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type TagHandler struct {
+	tagService interfaces.TagService
+}
+
+// NewTagHandler creates a new tag handler
+func NewTagHandler(tagService interfaces.TagService) *TagHandler {
+	return &TagHandler{
+		tagService: tagService,
+	}
+}
+
+// RenameTagRequest represents the request body for renaming a tag
+type RenameTagRequest struct {
+	Name string `json:"name"`
+}
+
+// MergeTagRequest represents the request body for merging a tag into another one
+type MergeTagRequest struct {
+	TargetID string `json:"targetId"`
+}
+
+// GetTags handles GET /api/tags (with optional ?q=prefix&limit=10 for autocomplete)
+func (h *TagHandler) GetTags(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Get tags with usage counts
+	tags, err := h.tagService.GetUserTags(userID, c.Query("q"), c.QueryInt("limit", 0))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to retrieve tags",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"tags": tags,
+	})
+}
+
+// RenameTag handles PUT /api/tags/:id
+func (h *TagHandler) RenameTag(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Parse tag ID
+	tagID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid tag ID",
+		})
+	}
+
+	// Parse request body
+	var req RenameTagRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Rename tag (applies to all tasks using it)
+	tag, err := h.tagService.RenameTag(tagID, userID, req.Name)
+	if err != nil {
+		if err.Error() == "unauthorized: tag does not belong to user" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Tag renamed successfully",
+		"tag":     tag,
+	})
+}
+
+// MergeTag handles POST /api/tags/:id/merge
+func (h *TagHandler) MergeTag(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Parse tag ID
+	sourceID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid tag ID",
+		})
+	}
+
+	// Parse request body
+	var req MergeTagRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	targetID, err := uuid.Parse(req.TargetID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid target tag ID",
+		})
+	}
+
+	// Merge source into target
+	tag, err := h.tagService.MergeTag(sourceID, targetID, userID)
+	if err != nil {
+		if err.Error() == "unauthorized: tag does not belong to user" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Tags merged successfully",
+		"tag":     tag,
+	})
+}
+
+// DeleteTag handles DELETE /api/tags/:id
+func (h *TagHandler) DeleteTag(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Parse tag ID
+	tagID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid tag ID",
+		})
+	}
+
+	// Delete tag
+	err = h.tagService.DeleteTag(tagID, userID)
+	if err != nil {
+		if err.Error() == "unauthorized: tag does not belong to user" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Tag not found",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Tag deleted successfully",
+	})
+}
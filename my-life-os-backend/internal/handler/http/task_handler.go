@@ -1,7 +1,8 @@
 package http
 
 import (
-	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
+	"strings"
+
 	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
 
 	"github.com/gofiber/fiber/v2"
@@ -26,6 +27,14 @@ type CreateTaskRequest struct {
 	Priority    string  `json:"priority"`
 	Domain      string  `json:"domain"`
 	Deadline    *string `json:"deadline"` // Optional, ISO 8601 format
+
+	// Optional, creates a subtask (domain defaults to the parent's domain)
+	ParentTaskID *string `json:"parentTaskId"`
+
+	// Optional, makes the task the first instance of a recurring series (requires a deadline)
+	Recurrence *interfaces.TaskRecurrence `json:"recurrence"`
+
+	Tags []string `json:"tags"` // Optional, unknown tags are created
 }
 
 // UpdateTaskRequest represents the request body for updating a task
@@ -35,6 +44,22 @@ type UpdateTaskRequest struct {
 	Priority    string  `json:"priority"`
 	Domain      string  `json:"domain"`
 	Deadline    *string `json:"deadline"` // Optional, ISO 8601 format
+
+	Tags []string `json:"tags"` // Optional, replaces all tags (omit to keep them)
+}
+
+// SetTaskTagsRequest represents the request body for replacing the tags of a task
+type SetTaskTagsRequest struct {
+	Tags []string `json:"tags"`
+}
+
+// UpdateTaskSeriesRequest represents the request body for updating all open and future instances of a recurring task
+type UpdateTaskSeriesRequest struct {
+	Title       string                     `json:"title"`
+	Description *string                    `json:"description"` // Optional, nil keeps each instance's description
+	Priority    string                     `json:"priority"`
+	Domain      string                     `json:"domain"`
+	Recurrence  *interfaces.TaskRecurrence `json:"recurrence"` // Optional, nil keeps the current schedule
 }
 
 // CreateTask handles POST /api/tasks
@@ -57,7 +82,19 @@ func (h *TaskHandler) CreateTask(c *fiber.Ctx) error {
 		})
 	}
 
-	if req.Domain == "" {
+	// Parse parent task ID if provided
+	var parentTaskID *uuid.UUID
+	if req.ParentTaskID != nil && *req.ParentTaskID != "" {
+		parsedParentID, err := uuid.Parse(*req.ParentTaskID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid parent task ID",
+			})
+		}
+		parentTaskID = &parsedParentID
+	}
+
+	if req.Domain == "" && parentTaskID == nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error": "Domain is required",
 		})
@@ -71,8 +108,16 @@ func (h *TaskHandler) CreateTask(c *fiber.Ctx) error {
 		req.Priority,
 		req.Domain,
 		req.Deadline,
+		parentTaskID,
+		req.Recurrence,
+		req.Tags,
 	)
 	if err != nil {
+		if err.Error() == "unauthorized: task does not belong to user" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error": err.Error(),
 		})
@@ -85,34 +130,37 @@ func (h *TaskHandler) CreateTask(c *fiber.Ctx) error {
 }
 
 // GetTasks handles GET /api/tasks
+// (filters: domain, status, time_filter, tags - sort=deadline,-priority,createdAt - paging: limit, cursor)
 func (h *TaskHandler) GetTasks(c *fiber.Ctx) error {
 	// Get user ID from context
 	userID := c.Locals("userID").(uuid.UUID)
 
 	// Get query parameters
-	domain := c.Query("domain")
-	status := c.Query("status")
-	timeFilter := c.Query("time_filter")
-
-	// Get tasks
-	var tasks []*entities.Task
-	var err error
-
-	if domain != "" || status != "" || timeFilter != "" {
-		tasks, err = h.taskService.GetUserTasksWithFilters(userID, domain, status, timeFilter)
-	} else {
-		tasks, err = h.taskService.GetUserTasks(userID)
+	query := interfaces.TaskQuery{
+		Domain:     c.Query("domain"),
+		Status:     c.Query("status"),
+		TimeFilter: c.Query("time_filter"),
+		Dependency: c.Query("dependency"), // blocked, actionable
+		TagFilter:  c.Query("tags"),       // e.g. "tag:errand -tag:waiting OR tag:urgent"
+		Sort:       c.Query("sort"),
+		Cursor:     c.Query("cursor"),
+		Limit:      c.QueryInt("limit", 0),
 	}
 
+	// Get tasks
+	page, err := h.taskService.GetUserTasksWithFilters(userID, query)
 	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid") {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to retrieve tasks",
 		})
 	}
 
-	return c.JSON(fiber.Map{
-		"tasks": tasks,
-	})
+	return c.JSON(page)
 }
 
 // GetTask handles GET /api/tasks/:id
@@ -128,8 +176,8 @@ func (h *TaskHandler) GetTask(c *fiber.Ctx) error {
 		})
 	}
 
-	// Get task
-	task, err := h.taskService.GetTask(taskID, userID)
+	// Get task (with its subtasks)
+	task, err := h.taskService.GetTaskWithSubtasks(taskID, userID)
 	if err != nil {
 		if err.Error() == "unauthorized: task does not belong to user" {
 			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
@@ -188,13 +236,287 @@ func (h *TaskHandler) UpdateTask(c *fiber.Ctx) error {
 		})
 	}
 
+	// Replace tags if provided
+	if req.Tags != nil {
+		task, err = h.taskService.SetTaskTags(task.ID, userID, req.Tags)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+
 	return c.JSON(fiber.Map{
 		"message": "Task updated successfully",
 		"task":    task,
 	})
 }
 
+// SetTaskTags handles PUT /api/tasks/:id/tags
+func (h *TaskHandler) SetTaskTags(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Parse task ID
+	taskID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid task ID",
+		})
+	}
+
+	// Parse request body
+	var req SetTaskTagsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Replace tags
+	task, err := h.taskService.SetTaskTags(taskID, userID, req.Tags)
+	if err != nil {
+		if err.Error() == "unauthorized: task does not belong to user" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Task tags updated successfully",
+		"task":    task,
+	})
+}
+
+// AddTaskDependencyRequest represents the request body for adding a dependency
+type AddTaskDependencyRequest struct {
+	BlockedByID string `json:"blockedById"` // Task that has to be done first
+}
+
+// GetTaskDependencies handles GET /api/tasks/:id/dependencies
+func (h *TaskHandler) GetTaskDependencies(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Parse task ID
+	taskID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid task ID",
+		})
+	}
+
+	// Get blockers and blocked tasks
+	dependencies, err := h.taskService.GetTaskDependencies(taskID, userID)
+	if err != nil {
+		if err.Error() == "unauthorized: task does not belong to user" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Task not found",
+		})
+	}
+
+	return c.JSON(dependencies)
+}
+
+// AddTaskDependency handles POST /api/tasks/:id/dependencies
+func (h *TaskHandler) AddTaskDependency(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Parse task ID
+	taskID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid task ID",
+		})
+	}
+
+	// Parse request body
+	var req AddTaskDependencyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	blockedByID, err := uuid.Parse(req.BlockedByID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid blocking task ID",
+		})
+	}
+
+	// Add dependency (cycles are rejected)
+	dependency, err := h.taskService.AddTaskDependency(taskID, blockedByID, userID)
+	if err != nil {
+		if err.Error() == "unauthorized: task does not belong to user" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		if err.Error() == "dependency would create a cycle" {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message":    "Task dependency added successfully",
+		"dependency": dependency,
+	})
+}
+
+// RemoveTaskDependency handles DELETE /api/tasks/:id/dependencies/:blockedById
+func (h *TaskHandler) RemoveTaskDependency(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Parse task IDs
+	taskID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid task ID",
+		})
+	}
+	blockedByID, err := uuid.Parse(c.Params("blockedById"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid blocking task ID",
+		})
+	}
+
+	// Remove dependency
+	if err := h.taskService.RemoveTaskDependency(taskID, blockedByID, userID); err != nil {
+		if err.Error() == "unauthorized: task does not belong to user" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Task not found",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Task dependency removed successfully",
+	})
+}
+
+// ReorderSubtasksRequest represents the request body for reordering subtasks
+type ReorderSubtasksRequest struct {
+	SubtaskIDs []string `json:"subtaskIds"` // All subtasks of the task in their new order
+}
+
+// ReorderSubtasks handles PUT /api/tasks/:id/subtasks/order
+func (h *TaskHandler) ReorderSubtasks(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Parse task ID
+	taskID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid task ID",
+		})
+	}
+
+	// Parse request body
+	var req ReorderSubtasksRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	subtaskIDs := make([]uuid.UUID, len(req.SubtaskIDs))
+	for i, id := range req.SubtaskIDs {
+		subtaskIDs[i], err = uuid.Parse(id)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid subtask ID",
+			})
+		}
+	}
+
+	// Reorder subtasks
+	subtasks, err := h.taskService.ReorderSubtasks(taskID, userID, subtaskIDs)
+	if err != nil {
+		if err.Error() == "unauthorized: task does not belong to user" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message":  "Subtasks reordered successfully",
+		"subtasks": subtasks,
+	})
+}
+
+// SetTaskStatusRequest represents the request body for moving a task to another status
+type SetTaskStatusRequest struct {
+	Status string `json:"status"` // Todo, In Progress, Waiting, Blocked, Done, Cancelled
+}
+
+// taskStatusError maps errors of status changes to responses
+func taskStatusError(c *fiber.Ctx, err error) error {
+	if err.Error() == "unauthorized: task does not belong to user" {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if err.Error() == "task has open subtasks" {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if strings.HasPrefix(err.Error(), "invalid") {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+		"error": "Task not found",
+	})
+}
+
+// taskStatusResponse returns a task after a status change with the next instance and open blockers (if any)
+func taskStatusResponse(c *fiber.Ctx, message string, result *interfaces.TaskStatusResult) error {
+	response := fiber.Map{
+		"message":  message,
+		"task":     result.Task,
+		"warnings": result.Warnings,
+	}
+
+	// Completing a recurring task also returns its next instance
+	if result.NextTask != nil {
+		response["nextTask"] = result.NextTask
+	}
+	if len(result.Blockers) > 0 {
+		response["blockers"] = result.Blockers
+	}
+
+	return c.JSON(response)
+}
+
 // ToggleTaskStatus handles PATCH /api/tasks/:id/status
+// (?force=true completes open subtasks too, open blockers only cause a warning)
 func (h *TaskHandler) ToggleTaskStatus(c *fiber.Ctx) error {
 	// Get user ID from context
 	userID := c.Locals("userID").(uuid.UUID)
@@ -208,7 +530,60 @@ func (h *TaskHandler) ToggleTaskStatus(c *fiber.Ctx) error {
 	}
 
 	// Toggle status
-	task, err := h.taskService.ToggleTaskStatus(taskID, userID)
+	result, err := h.taskService.ToggleTaskStatus(taskID, userID, c.QueryBool("force", false))
+	if err != nil {
+		return taskStatusError(c, err)
+	}
+
+	return taskStatusResponse(c, "Task status toggled successfully", result)
+}
+
+// SetTaskStatus handles PUT /api/tasks/:id/status
+// (?force=true closes open subtasks too, open blockers only cause a warning)
+func (h *TaskHandler) SetTaskStatus(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Parse task ID
+	taskID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid task ID",
+		})
+	}
+
+	// Parse request body
+	var req SetTaskStatusRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Move task within the workflow
+	result, err := h.taskService.SetTaskStatus(taskID, userID, req.Status, c.QueryBool("force", false))
+	if err != nil {
+		return taskStatusError(c, err)
+	}
+
+	return taskStatusResponse(c, "Task status updated successfully", result)
+}
+
+// GetTaskHistory handles GET /api/tasks/:id/history
+func (h *TaskHandler) GetTaskHistory(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Parse task ID
+	taskID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid task ID",
+		})
+	}
+
+	// Get status history with lead and cycle time
+	history, err := h.taskService.GetTaskHistory(taskID, userID)
 	if err != nil {
 		if err.Error() == "unauthorized: task does not belong to user" {
 			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
@@ -220,10 +595,7 @@ func (h *TaskHandler) ToggleTaskStatus(c *fiber.Ctx) error {
 		})
 	}
 
-	return c.JSON(fiber.Map{
-		"message": "Task status toggled successfully",
-		"task":    task,
-	})
+	return c.JSON(history)
 }
 
 // DeleteTask handles DELETE /api/tasks/:id
@@ -256,3 +628,114 @@ func (h *TaskHandler) DeleteTask(c *fiber.Ctx) error {
 		"message": "Task deleted successfully",
 	})
 }
+
+// GetTaskSeries handles GET /api/tasks/series/:id
+func (h *TaskHandler) GetTaskSeries(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Parse series ID
+	seriesID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid series ID",
+		})
+	}
+
+	// Get series with its instances
+	series, err := h.taskService.GetTaskSeries(seriesID, userID)
+	if err != nil {
+		if err.Error() == "unauthorized: task series does not belong to user" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Task series not found",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"series": series,
+	})
+}
+
+// UpdateTaskSeries handles PUT /api/tasks/series/:id
+func (h *TaskHandler) UpdateTaskSeries(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Parse series ID
+	seriesID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid series ID",
+		})
+	}
+
+	// Parse request body
+	var req UpdateTaskSeriesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Update open instances (and so all future ones)
+	series, err := h.taskService.UpdateTaskSeries(
+		seriesID,
+		userID,
+		req.Title,
+		req.Description,
+		req.Priority,
+		req.Domain,
+		req.Recurrence,
+	)
+	if err != nil {
+		if err.Error() == "unauthorized: task series does not belong to user" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Task series updated successfully",
+		"series":  series,
+	})
+}
+
+// StopTaskSeries handles POST /api/tasks/series/:id/stop (?deleteOpen=true also deletes open instances)
+func (h *TaskHandler) StopTaskSeries(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Parse series ID
+	seriesID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid series ID",
+		})
+	}
+
+	// Stop series
+	series, err := h.taskService.StopTaskSeries(seriesID, userID, c.QueryBool("deleteOpen", false))
+	if err != nil {
+		if err.Error() == "unauthorized: task series does not belong to user" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Task series not found",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Task series stopped successfully",
+		"series":  series,
+	})
+}
@@ -0,0 +1,316 @@
+package http
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TimeEntryHandler struct {
+	timeEntryService interfaces.TimeEntryService
+}
+
+// NewTimeEntryHandler creates a new time entry handler
+func NewTimeEntryHandler(timeEntryService interfaces.TimeEntryService) *TimeEntryHandler {
+	return &TimeEntryHandler{
+		timeEntryService: timeEntryService,
+	}
+}
+
+// StartTimerRequest represents the request body for starting a timer
+type StartTimerRequest struct {
+	TaskID string `json:"taskId"`
+	Note   string `json:"note"`
+}
+
+// TimeEntryRequest represents the request body for creating or editing a time entry
+type TimeEntryRequest struct {
+	TaskID    string  `json:"taskId"`
+	StartedAt string  `json:"startedAt"` // ISO 8601 format
+	EndedAt   *string `json:"endedAt"`   // ISO 8601 format, omitted for a running timer
+	Note      string  `json:"note"`
+}
+
+// parse validates the request body
+func (r TimeEntryRequest) parse() (uuid.UUID, time.Time, *time.Time, error) {
+	taskID, err := uuid.Parse(r.TaskID)
+	if err != nil {
+		return uuid.Nil, time.Time{}, nil, errors.New("Invalid task ID")
+	}
+
+	startedAt, err := time.Parse(time.RFC3339, r.StartedAt)
+	if err != nil {
+		return uuid.Nil, time.Time{}, nil, errors.New("Invalid start time format (use ISO 8601)")
+	}
+
+	var endedAt *time.Time
+	if r.EndedAt != nil && *r.EndedAt != "" {
+		parsed, err := time.Parse(time.RFC3339, *r.EndedAt)
+		if err != nil {
+			return uuid.Nil, time.Time{}, nil, errors.New("Invalid end time format (use ISO 8601)")
+		}
+		endedAt = &parsed
+	}
+
+	return taskID, startedAt, endedAt, nil
+}
+
+// timeEntryError maps time entry service errors to responses
+func timeEntryError(c *fiber.Ctx, err error) error {
+	if strings.HasPrefix(err.Error(), "unauthorized:") {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Not found",
+		})
+	}
+	if err.Error() == "time entry overlaps an existing entry" {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"error": err.Error(),
+	})
+}
+
+// GetRunningTimer handles GET /api/time/running
+func (h *TimeEntryHandler) GetRunningTimer(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Get running timer (null if none)
+	entry, err := h.timeEntryService.GetRunningTimer(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to retrieve running timer",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"entry": entry,
+	})
+}
+
+// StartTimer handles POST /api/time/start
+func (h *TimeEntryHandler) StartTimer(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Parse request body
+	var req StartTimerRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	taskID, err := uuid.Parse(req.TaskID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid task ID",
+		})
+	}
+
+	// Start timer at the server time (stops the running timer)
+	entry, err := h.timeEntryService.StartTimer(userID, taskID, req.Note)
+	if err != nil {
+		return timeEntryError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Timer started",
+		"entry":   entry,
+	})
+}
+
+// StopTimer handles POST /api/time/stop
+func (h *TimeEntryHandler) StopTimer(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Stop running timer at the server time
+	entry, err := h.timeEntryService.StopTimer(userID)
+	if err != nil {
+		if err.Error() == "no timer is running" {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to stop timer",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Timer stopped",
+		"entry":   entry,
+	})
+}
+
+// GetTimeEntries handles GET /api/time/entries?from=YYYY-MM-DD&to=YYYY-MM-DD&taskId=...&timezone=...
+func (h *TimeEntryHandler) GetTimeEntries(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Optional task filter
+	var taskID *uuid.UUID
+	if value := c.Query("taskId"); value != "" {
+		parsed, err := uuid.Parse(value)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid task ID",
+			})
+		}
+		taskID = &parsed
+	}
+
+	// Get entries (defaults to the current week)
+	entries, err := h.timeEntryService.GetTimeEntries(userID, c.Query("from"), c.Query("to"), c.Query("timezone"), taskID)
+	if err != nil {
+		return timeEntryError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"entries": entries,
+	})
+}
+
+// CreateTimeEntry handles POST /api/time/entries
+func (h *TimeEntryHandler) CreateTimeEntry(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Parse request body
+	var req TimeEntryRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	taskID, startedAt, endedAt, err := req.parse()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if endedAt == nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "End time is required (use the timer for running entries)",
+		})
+	}
+
+	// Create manual entry
+	entry, err := h.timeEntryService.CreateTimeEntry(userID, taskID, startedAt, *endedAt, req.Note)
+	if err != nil {
+		return timeEntryError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Time entry created successfully",
+		"entry":   entry,
+	})
+}
+
+// UpdateTimeEntry handles PUT /api/time/entries/:id
+func (h *TimeEntryHandler) UpdateTimeEntry(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Parse entry ID
+	entryID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid time entry ID",
+		})
+	}
+
+	// Parse request body
+	var req TimeEntryRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	taskID, startedAt, endedAt, err := req.parse()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	// Update entry
+	entry, err := h.timeEntryService.UpdateTimeEntry(entryID, userID, taskID, startedAt, endedAt, req.Note)
+	if err != nil {
+		return timeEntryError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Time entry updated successfully",
+		"entry":   entry,
+	})
+}
+
+// DeleteTimeEntry handles DELETE /api/time/entries/:id
+func (h *TimeEntryHandler) DeleteTimeEntry(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Parse entry ID
+	entryID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid time entry ID",
+		})
+	}
+
+	// Delete entry
+	if err := h.timeEntryService.DeleteTimeEntry(entryID, userID); err != nil {
+		return timeEntryError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Time entry deleted successfully",
+	})
+}
+
+// GetTimeReport handles GET /api/time/report?from=YYYY-MM-DD&to=YYYY-MM-DD&timezone=...
+func (h *TimeEntryHandler) GetTimeReport(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Sum up entries per day, week, project, domain and task (defaults to the current week)
+	report, err := h.timeEntryService.GetTimeReport(userID, c.Query("from"), c.Query("to"), c.Query("timezone"))
+	if err != nil {
+		return timeEntryError(c, err)
+	}
+
+	return c.JSON(report)
+}
+
+// ExportTimeEntries handles GET /api/time/export?from=YYYY-MM-DD&to=YYYY-MM-DD&timezone=... (CSV)
+func (h *TimeEntryHandler) ExportTimeEntries(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Export entries (defaults to the current week)
+	export, err := h.timeEntryService.ExportTimeEntries(userID, c.Query("from"), c.Query("to"), c.Query("timezone"))
+	if err != nil {
+		return timeEntryError(c, err)
+	}
+
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="time-entries.csv"`)
+	return c.Send(export)
+}
@@ -0,0 +1,125 @@
+package http
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TrashHandler struct {
+	trashService interfaces.TrashService
+}
+
+// NewTrashHandler creates a new trash handler
+func NewTrashHandler(trashService interfaces.TrashService) *TrashHandler {
+	return &TrashHandler{
+		trashService: trashService,
+	}
+}
+
+// trashError maps trash service errors to responses
+func trashError(c *fiber.Ctx, err error) error {
+	if strings.HasPrefix(err.Error(), "unauthorized:") {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Item not found in trash",
+		})
+	}
+	if err.Error() == "restore the parent task first" {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	if strings.HasPrefix(err.Error(), "invalid") {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": "Failed to update trash",
+	})
+}
+
+// GetTrash handles GET /api/trash (?type=tasks|routines|projects|events)
+func (h *TrashHandler) GetTrash(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Get trashed items (all types without filter)
+	trash, err := h.trashService.GetTrash(userID, c.Query("type"))
+	if err != nil {
+		return trashError(c, err)
+	}
+
+	return c.JSON(trash)
+}
+
+// RestoreItem handles POST /api/trash/:type/:id/restore
+func (h *TrashHandler) RestoreItem(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Parse item ID
+	itemID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid item ID",
+		})
+	}
+
+	// Restore item with everything deleted together with it
+	if err := h.trashService.RestoreItem(userID, c.Params("type"), itemID); err != nil {
+		return trashError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Item restored successfully",
+	})
+}
+
+// DeleteItem handles DELETE /api/trash/:type/:id
+func (h *TrashHandler) DeleteItem(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Parse item ID
+	itemID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid item ID",
+		})
+	}
+
+	// Delete item permanently
+	if err := h.trashService.DeleteItem(userID, c.Params("type"), itemID); err != nil {
+		return trashError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Item deleted permanently",
+	})
+}
+
+// EmptyTrash handles DELETE /api/trash
+func (h *TrashHandler) EmptyTrash(c *fiber.Ctx) error {
+	// Get user ID from context
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Delete all trashed items permanently
+	if err := h.trashService.EmptyTrash(userID); err != nil {
+		return trashError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Trash emptied successfully",
+	})
+}
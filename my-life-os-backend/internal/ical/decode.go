@@ -0,0 +1,244 @@
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Decode parses an iCalendar stream and returns its top-level component (usually VCALENDAR)
+func Decode(r io.Reader) (*Component, error) {
+	lines, err := unfoldLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var root *Component
+	var stack []*Component
+
+	for i, line := range lines {
+		if line == "" {
+			continue
+		}
+
+		prop, err := parseContentLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		switch strings.ToUpper(prop.Name) {
+		case "BEGIN":
+			comp := NewComponent(strings.ToUpper(prop.Value))
+			if len(stack) > 0 {
+				stack[len(stack)-1].AddComponent(comp)
+			} else if root == nil {
+				root = comp
+			} else {
+				return nil, fmt.Errorf("line %d: multiple top-level components", i+1)
+			}
+			stack = append(stack, comp)
+
+		case "END":
+			if len(stack) == 0 || stack[len(stack)-1].Name != strings.ToUpper(prop.Value) {
+				return nil, fmt.Errorf("line %d: unexpected END:%s", i+1, prop.Value)
+			}
+			stack = stack[:len(stack)-1]
+
+		default:
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("line %d: property outside of component", i+1)
+			}
+			prop.Name = strings.ToUpper(prop.Name)
+			stack[len(stack)-1].Properties = append(stack[len(stack)-1].Properties, prop)
+		}
+	}
+
+	if root == nil {
+		return nil, errors.New("no calendar component found")
+	}
+	if len(stack) > 0 {
+		return nil, fmt.Errorf("missing END:%s", stack[len(stack)-1].Name)
+	}
+
+	return root, nil
+}
+
+// unfoldLines reads all content lines and joins folded continuation lines
+func unfoldLines(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		// Lines starting with a space or tab continue the previous line
+		if len(line) > 0 && (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// parseContentLine parses "NAME;PARAM=VALUE:value" into a property
+func parseContentLine(line string) (*Property, error) {
+	prop := &Property{}
+
+	// Name runs until the first ';' or ':'
+	i := strings.IndexAny(line, ";:")
+	if i <= 0 {
+		return nil, errors.New("invalid content line")
+	}
+	prop.Name = line[:i]
+	rest := line[i:]
+
+	// Parameters (values may be quoted and contain ':' or ';')
+	for len(rest) > 0 && rest[0] == ';' {
+		rest = rest[1:]
+		eq := strings.IndexByte(rest, '=')
+		if eq <= 0 {
+			return nil, errors.New("invalid parameter")
+		}
+		name := strings.ToUpper(rest[:eq])
+		rest = rest[eq+1:]
+
+		var value string
+		if len(rest) > 0 && rest[0] == '"' {
+			end := strings.IndexByte(rest[1:], '"')
+			if end < 0 {
+				return nil, errors.New("unterminated quoted parameter")
+			}
+			value = rest[1 : end+1]
+			rest = rest[end+2:]
+		} else {
+			end := strings.IndexAny(rest, ";:")
+			if end < 0 {
+				return nil, errors.New("invalid parameter")
+			}
+			value = rest[:end]
+			rest = rest[end:]
+		}
+		prop.Params = append(prop.Params, Param{Name: name, Value: value})
+	}
+
+	if len(rest) == 0 || rest[0] != ':' {
+		return nil, errors.New("missing property value")
+	}
+	prop.Value = rest[1:]
+
+	return prop, nil
+}
+
+// UnescapeText reverses EscapeText
+func UnescapeText(s string) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+			switch s[i] {
+			case 'n', 'N':
+				sb.WriteByte('\n')
+			default:
+				sb.WriteByte(s[i])
+			}
+			continue
+		}
+		sb.WriteByte(s[i])
+	}
+	return sb.String()
+}
+
+// ParseTime parses a DATE or DATE-TIME property value.
+// It returns the time and whether the value was a DATE (all-day).
+// Floating times and unknown TZIDs are interpreted as UTC (use Timezones to detect unknown TZIDs
+// and to resolve the VTIMEZONEs of a file).
+func ParseTime(prop *Property) (time.Time, bool, error) {
+	return ParseTimeValue(prop.Value, prop.Param("VALUE"), prop.Param("TZID"))
+}
+
+// ParseTimeValue parses a single DATE or DATE-TIME value with its VALUE and TZID parameters
+func ParseTimeValue(value, valueType, tzid string) (time.Time, bool, error) {
+	return (*Timezones)(nil).ParseTimeValue(value, valueType, tzid)
+}
+
+// parseTimeIn parses a DATE or DATE-TIME value, floating times are in loc
+func parseTimeIn(value, valueType string, loc *time.Location) (time.Time, bool, error) {
+	value = strings.TrimSpace(value)
+
+	if strings.EqualFold(valueType, "DATE") || len(value) == len(DateLayout) {
+		t, err := time.ParseInLocation(DateLayout, value, time.UTC)
+		return t, true, err
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.ParseInLocation(DateTimeUTCLayout, value, time.UTC)
+		return t, false, err
+	}
+
+	t, err := time.ParseInLocation(DateTimeLayout, value, loc)
+	return t, false, err
+}
+
+// ParseDuration parses an RFC 5545 DURATION value (e.g. "PT1H30M", "P1D", "-P1W")
+func ParseDuration(value string) (time.Duration, error) {
+	s := strings.TrimSpace(value)
+	sign := time.Duration(1)
+	if strings.HasPrefix(s, "-") {
+		sign = -1
+		s = s[1:]
+	} else if strings.HasPrefix(s, "+") {
+		s = s[1:]
+	}
+
+	if !strings.HasPrefix(s, "P") {
+		return 0, errors.New("invalid duration")
+	}
+	s = s[1:]
+
+	var total time.Duration
+	inTime := false
+	num := ""
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			num += string(r)
+		case r == 'T':
+			inTime = true
+		default:
+			n, err := strconv.Atoi(num)
+			if err != nil {
+				return 0, errors.New("invalid duration")
+			}
+			num = ""
+			switch {
+			case r == 'W' && !inTime:
+				total += time.Duration(n) * 7 * 24 * time.Hour
+			case r == 'D' && !inTime:
+				total += time.Duration(n) * 24 * time.Hour
+			case r == 'H' && inTime:
+				total += time.Duration(n) * time.Hour
+			case r == 'M' && inTime:
+				total += time.Duration(n) * time.Minute
+			case r == 'S' && inTime:
+				total += time.Duration(n) * time.Second
+			default:
+				return 0, errors.New("invalid duration")
+			}
+		}
+	}
+
+	if num != "" {
+		return 0, errors.New("invalid duration")
+	}
+
+	return sign * total, nil
+}
@@ -0,0 +1,184 @@
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+)
+
+// Date/time layouts used by iCalendar (RFC 5545)
+const (
+	DateLayout        = "20060102"
+	DateTimeLayout    = "20060102T150405"
+	DateTimeUTCLayout = "20060102T150405Z"
+)
+
+// maxLineOctets is the maximum length of a content line before folding
+const maxLineOctets = 75
+
+// Param represents a single property parameter (e.g. VALUE=DATE)
+type Param struct {
+	Name  string
+	Value string
+}
+
+// Property represents a single content line of a component
+type Property struct {
+	Name   string
+	Params []Param
+	Value  string
+}
+
+// Param returns the value of a parameter, or "" if it is not set
+func (p *Property) Param(name string) string {
+	for _, param := range p.Params {
+		if strings.EqualFold(param.Name, name) {
+			return param.Value
+		}
+	}
+	return ""
+}
+
+// Component represents a calendar component (VCALENDAR, VEVENT, ...)
+type Component struct {
+	Name       string
+	Properties []*Property
+	Components []*Component
+}
+
+// NewComponent creates an empty component with the given name
+func NewComponent(name string) *Component {
+	return &Component{Name: name}
+}
+
+// Add appends a property to the component
+func (c *Component) Add(name, value string, params ...Param) {
+	c.Properties = append(c.Properties, &Property{
+		Name:   name,
+		Params: params,
+		Value:  value,
+	})
+}
+
+// AddComponent appends a sub-component
+func (c *Component) AddComponent(child *Component) {
+	c.Components = append(c.Components, child)
+}
+
+// Get returns the first property with the given name, or nil
+func (c *Component) Get(name string) *Property {
+	for _, p := range c.Properties {
+		if strings.EqualFold(p.Name, name) {
+			return p
+		}
+	}
+	return nil
+}
+
+// GetAll returns all properties with the given name
+func (c *Component) GetAll(name string) []*Property {
+	var props []*Property
+	for _, p := range c.Properties {
+		if strings.EqualFold(p.Name, name) {
+			props = append(props, p)
+		}
+	}
+	return props
+}
+
+// Encode writes the component (and its children) in iCalendar format
+func Encode(w io.Writer, c *Component) error {
+	bw := bufio.NewWriter(w)
+	if err := encodeComponent(bw, c); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// encodeComponent writes a component recursively
+func encodeComponent(w *bufio.Writer, c *Component) error {
+	if err := writeLine(w, "BEGIN:"+c.Name); err != nil {
+		return err
+	}
+
+	for _, p := range c.Properties {
+		var sb strings.Builder
+		sb.WriteString(p.Name)
+		for _, param := range p.Params {
+			sb.WriteString(";")
+			sb.WriteString(param.Name)
+			sb.WriteString("=")
+			sb.WriteString(quoteParamValue(param.Value))
+		}
+		sb.WriteString(":")
+		sb.WriteString(p.Value)
+
+		if err := writeLine(w, sb.String()); err != nil {
+			return err
+		}
+	}
+
+	for _, child := range c.Components {
+		if err := encodeComponent(w, child); err != nil {
+			return err
+		}
+	}
+
+	return writeLine(w, "END:"+c.Name)
+}
+
+// writeLine writes a content line, folding it at 75 octets without splitting UTF-8 sequences
+func writeLine(w *bufio.Writer, line string) error {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		// Never cut inside a multi-byte character
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+		if _, err := w.WriteString(line[:cut] + "\r\n "); err != nil {
+			return err
+		}
+		line = line[cut:]
+		// Continuation lines start with a space, which counts towards the limit
+		limit = maxLineOctets - 1
+	}
+	_, err := w.WriteString(line + "\r\n")
+	return err
+}
+
+// isRuneStart reports whether b is the first byte of a UTF-8 sequence
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+// quoteParamValue quotes a parameter value if it contains special characters
+func quoteParamValue(value string) string {
+	if strings.ContainsAny(value, ";:,") {
+		return `"` + strings.ReplaceAll(value, `"`, "") + `"`
+	}
+	return value
+}
+
+// EscapeText escapes a TEXT value (backslash, semicolon, comma and newlines)
+func EscapeText(s string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	)
+	return replacer.Replace(s)
+}
+
+// FormatDateTime formats a time as a UTC DATE-TIME value
+func FormatDateTime(t time.Time) string {
+	return t.UTC().Format(DateTimeUTCLayout)
+}
+
+// FormatDate formats a time as a DATE value
+func FormatDate(t time.Time) string {
+	return t.Format(DateLayout)
+}
@@ -0,0 +1,89 @@
+package ical
+
+import (
+	"fmt"
+	"time"
+)
+
+// Timezone builds a VTIMEZONE for a location covering the transitions between two times.
+// Go does not expose the rules of a zone, so every transition is listed: transitions to the same
+// offset and name share one observance (the first as DTSTART, the later ones as RDATEs).
+func Timezone(loc *time.Location, from, to time.Time) *Component {
+	vtimezone := NewComponent("VTIMEZONE")
+	vtimezone.Add("TZID", loc.String())
+
+	observances := make(map[string]*Component)
+	add := func(at time.Time, offsetFrom int) {
+		local := at.In(loc)
+		name, offset := local.Zone()
+		kind := "STANDARD"
+		if local.IsDST() {
+			kind = "DAYLIGHT"
+		}
+
+		// Observance onsets are wall-clock times in the offset before the transition
+		onset := at.UTC().Add(time.Duration(offsetFrom) * time.Second).Format(DateTimeLayout)
+
+		key := fmt.Sprintf("%s/%s/%d/%d", kind, name, offsetFrom, offset)
+		if observance, ok := observances[key]; ok {
+			observance.Add("RDATE", onset)
+			return
+		}
+
+		observance := NewComponent(kind)
+		observance.Add("DTSTART", onset)
+		observance.Add("TZOFFSETFROM", formatUTCOffset(offsetFrom))
+		observance.Add("TZOFFSETTO", formatUTCOffset(offset))
+		observance.Add("TZNAME", EscapeText(name))
+		observances[key] = observance
+		vtimezone.AddComponent(observance)
+	}
+
+	// Start at the beginning of the year for a readable first onset
+	first := from.In(loc)
+	start := time.Date(first.Year(), time.January, 1, 0, 0, 0, 0, loc)
+	_, offset := start.Zone()
+	add(start, offset)
+
+	// Transitions are found day by day and narrowed down to the second
+	for t := start; t.Before(to); {
+		next := t.Add(24 * time.Hour)
+		if zoneKey(next, loc) != zoneKey(t, loc) {
+			lo, hi := t, next
+			for hi.Sub(lo) > time.Second {
+				mid := lo.Add(hi.Sub(lo) / time.Second / 2 * time.Second)
+				if zoneKey(mid, loc) == zoneKey(lo, loc) {
+					lo = mid
+				} else {
+					hi = mid
+				}
+			}
+			_, before := lo.In(loc).Zone()
+			add(hi, before)
+		}
+		t = next
+	}
+
+	return vtimezone
+}
+
+// zoneKey identifies the zone in effect at a time (name, offset and DST flag)
+func zoneKey(t time.Time, loc *time.Location) string {
+	local := t.In(loc)
+	name, offset := local.Zone()
+	return fmt.Sprintf("%s/%d/%t", name, offset, local.IsDST())
+}
+
+// formatUTCOffset formats an offset in seconds as a UTC-OFFSET value (+HHMM or +HHMMSS)
+func formatUTCOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+	hours, minutes, rest := seconds/3600, seconds/60%60, seconds%60
+	if rest != 0 {
+		return fmt.Sprintf("%s%02d%02d%02d", sign, hours, minutes, rest)
+	}
+	return fmt.Sprintf("%s%02d%02d", sign, hours, minutes)
+}
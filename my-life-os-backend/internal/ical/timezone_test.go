@@ -0,0 +1,68 @@
+package ical
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimezoneTransitions(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	vtimezone := Timezone(berlin, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2027, 12, 31, 0, 0, 0, 0, time.UTC))
+	if got := vtimezone.Get("TZID").Value; got != "Europe/Berlin" {
+		t.Fatalf("TZID = %s", got)
+	}
+
+	type observance struct {
+		kind, dtstart, from, to string
+		rdates                  []string
+	}
+	want := []observance{
+		{"STANDARD", "20260101T000000", "+0100", "+0100", nil},
+		{"DAYLIGHT", "20260329T020000", "+0100", "+0200", []string{"20270328T020000"}},
+		{"STANDARD", "20261025T030000", "+0200", "+0100", []string{"20271031T030000"}},
+	}
+
+	if len(vtimezone.Components) != len(want) {
+		t.Fatalf("got %d observances, want %d", len(vtimezone.Components), len(want))
+	}
+	for i, w := range want {
+		c := vtimezone.Components[i]
+		got := observance{c.Name, c.Get("DTSTART").Value, c.Get("TZOFFSETFROM").Value, c.Get("TZOFFSETTO").Value, nil}
+		for _, rdate := range c.GetAll("RDATE") {
+			got.rdates = append(got.rdates, rdate.Value)
+		}
+		if got.kind != w.kind || got.dtstart != w.dtstart || got.from != w.from || got.to != w.to || len(got.rdates) != len(w.rdates) {
+			t.Errorf("observance %d = %+v, want %+v", i, got, w)
+			continue
+		}
+		for j := range w.rdates {
+			if got.rdates[j] != w.rdates[j] {
+				t.Errorf("observance %d RDATE %d = %s, want %s", i, j, got.rdates[j], w.rdates[j])
+			}
+		}
+	}
+}
+
+func TestFormatUTCOffset(t *testing.T) {
+	tests := []struct {
+		seconds int
+		want    string
+	}{
+		{0, "+0000"},
+		{3600, "+0100"},
+		{-5 * 3600, "-0500"},
+		{5*3600 + 30*60, "+0530"},
+		{-(3*3600 + 30*60), "-0330"},
+		{3600 + 53*60 + 28, "+015328"}, // Local mean time
+	}
+
+	for _, tt := range tests {
+		if got := formatUTCOffset(tt.seconds); got != tt.want {
+			t.Errorf("formatUTCOffset(%d) = %s, want %s", tt.seconds, got, tt.want)
+		}
+	}
+}
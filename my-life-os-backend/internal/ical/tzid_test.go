@@ -0,0 +1,80 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWindowsZonesLoad(t *testing.T) {
+	for windows, iana := range windowsZones {
+		if _, err := time.LoadLocation(iana); err != nil {
+			t.Errorf("%s -> %s: %v", windows, iana, err)
+		}
+	}
+}
+
+func TestTimezonesLocation(t *testing.T) {
+	cal, err := Decode(strings.NewReader(strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VTIMEZONE",
+		"TZID:Custom Office Zone",
+		"X-LIC-LOCATION:Europe/Vienna",
+		"END:VTIMEZONE",
+		"BEGIN:VTIMEZONE",
+		"TZID:No Location",
+		"END:VTIMEZONE",
+		"END:VCALENDAR",
+	}, "\r\n")))
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	zones := NewTimezones(cal)
+
+	tests := []struct {
+		tzid  string
+		want  string
+		known bool
+	}{
+		{"", "UTC", true},
+		{"UTC", "UTC", true},
+		{"Europe/Berlin", "Europe/Berlin", true},
+		{`"America/New_York"`, "America/New_York", true},
+		{"W. Europe Standard Time", "Europe/Berlin", true},
+		{"Eastern Standard Time", "America/New_York", true},
+		{"/mozilla.org/20050126_1/Europe/Paris", "Europe/Paris", true},
+		{"/citadel.org/20190914_1/Asia/Tokyo", "Asia/Tokyo", true},
+		{"Custom Office Zone", "Europe/Vienna", true},
+		{"No Location", "UTC", false},
+		{"Local", "UTC", false},
+		{"Mars/Olympus_Mons", "UTC", false},
+	}
+
+	for _, tt := range tests {
+		loc, known := zones.Location(tt.tzid)
+		if loc.String() != tt.want || known != tt.known {
+			t.Errorf("Location(%q) = %s, %t, want %s, %t", tt.tzid, loc, known, tt.want, tt.known)
+		}
+	}
+}
+
+func TestTimezonesParseTime(t *testing.T) {
+	zones := NewTimezones(nil)
+
+	got, _, err := zones.ParseTimeValue("20261026T090000", "", "W. Europe Standard Time")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if want := time.Date(2026, 10, 26, 8, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("got %s, want %s", got.UTC(), want)
+	}
+
+	// Unknown zones fall back to UTC
+	got, _, err = ParseTimeValue("20261026T090000", "", "Unknown Standard Time")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if want := time.Date(2026, 10, 26, 9, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("got %s, want %s", got.UTC(), want)
+	}
+}
@@ -0,0 +1,70 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
+)
+
+type emailChannel struct {
+	addr string
+	from string
+}
+
+// NewEmailChannel creates a channel that sends reminders over SMTP (without auth, e.g. MailHog)
+func NewEmailChannel(host, port, from string) interfaces.NotificationChannel {
+	return &emailChannel{
+		addr: net.JoinHostPort(host, port),
+		from: from,
+	}
+}
+
+// Name returns the channel name
+func (c *emailChannel) Name() string {
+	return entities.ReminderChannelEmail
+}
+
+// ValidateTarget checks the recipient address (empty = the user's email)
+func (c *emailChannel) ValidateTarget(target *string) error {
+	if target == nil {
+		return nil
+	}
+	if _, err := mail.ParseAddress(*target); err != nil {
+		return errors.New("invalid email address")
+	}
+	return nil
+}
+
+// Send sends the reminder as a plain text email
+func (c *emailChannel) Send(ctx context.Context, notification *interfaces.Notification) error {
+	to := notification.Email
+	if notification.Target != nil {
+		to = *notification.Target
+	}
+	if to == "" {
+		return errors.New("no email recipient")
+	}
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", c.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", to)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", notification.Title))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&msg, "Message-ID: <%s@my-life-os>\r\n", notification.DeliveryID)
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(notification.Body)
+	msg.WriteString("\r\n")
+
+	return smtp.SendMail(c.addr, nil, c.from, []string{to}, []byte(msg.String()))
+}
@@ -0,0 +1,33 @@
+// Package notify contains the delivery channels for reminders.
+package notify
+
+import (
+	"context"
+	"log"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
+)
+
+type logChannel struct{}
+
+// NewLogChannel creates a channel that writes reminders to the server log
+func NewLogChannel() interfaces.NotificationChannel {
+	return &logChannel{}
+}
+
+// Name returns the channel name
+func (c *logChannel) Name() string {
+	return entities.ReminderChannelLog
+}
+
+// ValidateTarget accepts any target (it is ignored)
+func (c *logChannel) ValidateTarget(target *string) error {
+	return nil
+}
+
+// Send logs the reminder
+func (c *logChannel) Send(ctx context.Context, notification *interfaces.Notification) error {
+	log.Printf("Reminder for user %s: %s - %s", notification.UserID, notification.Title, notification.Body)
+	return nil
+}
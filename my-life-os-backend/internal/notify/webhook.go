@@ -0,0 +1,74 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
+)
+
+type webhookChannel struct {
+	client *http.Client
+}
+
+// NewWebhookChannel creates a channel that POSTs reminders as JSON to the rule's URL
+func NewWebhookChannel() interfaces.NotificationChannel {
+	return &webhookChannel{
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Name returns the channel name
+func (c *webhookChannel) Name() string {
+	return entities.ReminderChannelWebhook
+}
+
+// ValidateTarget checks that the target is an http(s) URL
+func (c *webhookChannel) ValidateTarget(target *string) error {
+	if target == nil || *target == "" {
+		return errors.New("webhook URL is required")
+	}
+	u, err := url.Parse(*target)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("invalid webhook URL")
+	}
+	return nil
+}
+
+// Send posts the reminder, any non-2xx response counts as failure
+func (c *webhookChannel) Send(ctx context.Context, notification *interfaces.Notification) error {
+	if notification.Target == nil {
+		return errors.New("webhook URL is missing")
+	}
+
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, *notification.Target, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	// Lets receivers deduplicate retries
+	req.Header.Set("Idempotency-Key", notification.DeliveryID.String())
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
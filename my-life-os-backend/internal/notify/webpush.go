@@ -0,0 +1,292 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// pushTTL is how long the push service keeps an undelivered message (seconds)
+	pushTTL = 24 * 60 * 60
+
+	// pushRecordSize is the aes128gcm record size (one record, the payload is small)
+	pushRecordSize = 4096
+)
+
+type webPushChannel struct {
+	pushRepo   interfaces.PushSubscriptionRepository
+	privateKey *ecdsa.PrivateKey
+	publicKey  string // base64url, sent as "k" in the VAPID header
+	subject    string // mailto: or https: contact for the push service
+	client     *http.Client
+}
+
+// NewWebPushChannel creates a channel that sends reminders to all Web Push subscriptions of the user.
+// privateKey is the raw P-256 VAPID key (base64url), see GenerateVAPIDKey.
+func NewWebPushChannel(pushRepo interfaces.PushSubscriptionRepository, privateKey, subject string) (interfaces.NotificationChannel, error) {
+	key, err := parseVAPIDKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	publicKey, err := VAPIDPublicKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &webPushChannel{
+		pushRepo:   pushRepo,
+		privateKey: key,
+		publicKey:  publicKey,
+		subject:    subject,
+		client:     &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// GenerateVAPIDKey creates a new VAPID private key (base64url)
+func GenerateVAPIDKey() (string, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return "", err
+	}
+	raw, err := key.Bytes()
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// VAPIDPublicKey derives the public key (base64url, uncompressed point) that browsers pass as applicationServerKey
+func VAPIDPublicKey(privateKey string) (string, error) {
+	key, err := parseVAPIDKey(privateKey)
+	if err != nil {
+		return "", err
+	}
+	raw, err := key.PublicKey.Bytes()
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// Name returns the channel name
+func (c *webPushChannel) Name() string {
+	return entities.ReminderChannelWebPush
+}
+
+// ValidateTarget accepts any target (all subscriptions of the user are notified)
+func (c *webPushChannel) ValidateTarget(target *string) error {
+	return nil
+}
+
+// Send pushes the reminder to every subscription of the user.
+// It succeeds if at least one subscription received it, expired subscriptions are removed.
+func (c *webPushChannel) Send(ctx context.Context, notification *interfaces.Notification) error {
+	subscriptions, err := c.pushRepo.FindSubscriptionsByUserID(notification.UserID)
+	if err != nil {
+		return err
+	}
+	if len(subscriptions) == 0 {
+		return errors.New("no push subscriptions")
+	}
+
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	var lastErr error
+	delivered := false
+	for _, subscription := range subscriptions {
+		status, err := c.push(ctx, subscription, payload)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		switch {
+		case status == http.StatusNotFound || status == http.StatusGone:
+			// Subscription expired or was revoked by the browser
+			if err := c.pushRepo.DeleteSubscription(subscription.ID); err != nil {
+				lastErr = err
+			}
+		case status < 200 || status > 299:
+			lastErr = fmt.Errorf("push service responded with status %d", status)
+		default:
+			delivered = true
+		}
+	}
+
+	if delivered {
+		return nil
+	}
+	if lastErr == nil {
+		lastErr = errors.New("no valid push subscriptions")
+	}
+	return lastErr
+}
+
+// push encrypts the payload for one subscription and posts it to the push service
+func (c *webPushChannel) push(ctx context.Context, subscription *entities.PushSubscription, payload []byte) (int, error) {
+	body, err := encryptPushPayload(subscription.P256dh, subscription.Auth, payload)
+	if err != nil {
+		return 0, err
+	}
+
+	authorization, err := c.vapidAuthorization(subscription.Endpoint)
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("TTL", fmt.Sprint(pushTTL))
+	req.Header.Set("Urgency", "high")
+	req.Header.Set("Authorization", authorization)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode, nil
+}
+
+// vapidAuthorization builds the VAPID (RFC 8292) Authorization header for the endpoint's origin
+func (c *webPushChannel) vapidAuthorization(endpoint string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return "", errors.New("invalid push endpoint")
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"aud": u.Scheme + "://" + u.Host,
+		"exp": time.Now().Add(12 * time.Hour).Unix(),
+		"sub": c.subject,
+	})
+	signed, err := token.SignedString(c.privateKey)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("vapid t=%s, k=%s", signed, c.publicKey), nil
+}
+
+// encryptPushPayload encrypts a message for a subscription (RFC 8291, aes128gcm content coding)
+func encryptPushPayload(p256dh, authSecret string, payload []byte) ([]byte, error) {
+	uaPublicRaw, err := decodeBase64URL(p256dh)
+	if err != nil {
+		return nil, errors.New("invalid subscription key")
+	}
+	auth, err := decodeBase64URL(authSecret)
+	if err != nil {
+		return nil, errors.New("invalid subscription auth secret")
+	}
+
+	curve := ecdh.P256()
+	uaPublic, err := curve.NewPublicKey(uaPublicRaw)
+	if err != nil {
+		return nil, errors.New("invalid subscription key")
+	}
+
+	// Ephemeral application server key pair
+	asPrivate, err := curve.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	asPublicRaw := asPrivate.PublicKey().Bytes()
+
+	sharedSecret, err := asPrivate.ECDH(uaPublic)
+	if err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	// IKM = HKDF(auth, ecdh_secret, "WebPush: info" || 0x00 || ua_public || as_public)
+	keyInfo := "WebPush: info\x00" + string(uaPublicRaw) + string(asPublicRaw)
+	ikm, err := hkdf.Key(sha256.New, sharedSecret, auth, keyInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	cek, err := hkdf.Key(sha256.New, ikm, salt, "Content-Encoding: aes128gcm\x00", 16)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := hkdf.Key(sha256.New, ikm, salt, "Content-Encoding: nonce\x00", 12)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	// Single record: payload followed by the last-record delimiter
+	plaintext := append(append([]byte{}, payload...), 0x02)
+	if len(plaintext)+gcm.Overhead() > pushRecordSize {
+		return nil, errors.New("push payload is too large")
+	}
+
+	// Header: salt (16) || record size (4) || key id length (1) || key id (as_public)
+	header := make([]byte, 0, 21+len(asPublicRaw))
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, pushRecordSize)
+	header = append(header, byte(len(asPublicRaw)))
+	header = append(header, asPublicRaw...)
+
+	return gcm.Seal(header, nonce, plaintext, nil), nil
+}
+
+// parseVAPIDKey parses a raw P-256 private key (base64url)
+func parseVAPIDKey(privateKey string) (*ecdsa.PrivateKey, error) {
+	raw, err := decodeBase64URL(privateKey)
+	if err != nil {
+		return nil, errors.New("invalid VAPID private key")
+	}
+	key, err := ecdsa.ParseRawPrivateKey(elliptic.P256(), raw)
+	if err != nil {
+		return nil, errors.New("invalid VAPID private key")
+	}
+	return key, nil
+}
+
+// decodeBase64URL decodes base64url with or without padding (browsers send both)
+func decodeBase64URL(value string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+}
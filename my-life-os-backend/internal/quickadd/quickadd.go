@@ -0,0 +1,810 @@
+// Package quickadd interprets natural-language input for creating tasks and events in English and German,
+// e.g. "Submit thesis draft fri 17:00 !high #University" or "Zahnarzt nächsten dienstag 9-10 @Health alle 6 monate".
+package quickadd
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/rrule"
+)
+
+// Item types
+const (
+	TypeTask  = "task"
+	TypeEvent = "event"
+)
+
+// Token kinds
+const (
+	KindPriority   = "priority"
+	KindDomain     = "domain"
+	KindTag        = "tag"
+	KindRecurrence = "recurrence"
+	KindTime       = "time"
+	KindDate       = "date"
+)
+
+// Token is a recognized part of the input
+type Token struct {
+	Text string `json:"text"`
+	Kind string `json:"kind"` // priority, domain, tag, recurrence, time, date
+}
+
+// Result is the interpretation of a quick-add input
+type Result struct {
+	Type           string     `json:"type"` // task, event
+	Title          string     `json:"title"`
+	Priority       string     `json:"priority,omitempty"` // Low, Medium, High (tasks)
+	Domain         string     `json:"domain,omitempty"`
+	Tags           []string   `json:"tags,omitempty"`           // Tasks only, hashtags that are not a domain
+	Deadline       *time.Time `json:"deadline,omitempty"`       // Tasks
+	Start          *time.Time `json:"start,omitempty"`          // Events
+	End            *time.Time `json:"end,omitempty"`            // Events with a time (nil for all-day events)
+	AllDay         bool       `json:"allDay"`                   // A date without a time
+	RecurrenceRule string     `json:"recurrenceRule,omitempty"` // RFC 5545 RRULE
+	Tokens         []Token    `json:"tokens"`                   // Recognized parts in input order
+}
+
+// Options configure the interpretation
+type Options struct {
+	Now  time.Time // Reference time, dates are interpreted in its location
+	Type string    // Force task or event (empty = events if a time range is given, tasks otherwise)
+
+	// Domain resolves a domain name written as @Name or #Name (nil = no domains)
+	Domain func(name string) (string, bool)
+}
+
+// defaultEventDuration is used for events with a start time only
+const defaultEventDuration = time.Hour
+
+var (
+	isoDatePattern    = regexp.MustCompile(`^(\d{4})-(\d{2})-(\d{2})$`)
+	dottedDatePattern = regexp.MustCompile(`^(\d{1,2})\.(\d{1,2})\.(\d{2}|\d{4})?$`)
+	dayPattern        = regexp.MustCompile(`^(\d{1,2})(st|nd|rd|th|\.)?$`)
+	yearPattern       = regexp.MustCompile(`^\d{4}$`)
+	clockPattern      = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(am|pm|h|uhr)?$`)
+	rangePattern      = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(am|pm)?[-–](\d{1,2})(?::(\d{2}))?(am|pm|h|uhr)?$`)
+	numberPattern     = regexp.MustCompile(`^\d{1,3}$`)
+)
+
+var weekdays = map[string]time.Weekday{
+	"monday": time.Monday, "mon": time.Monday, "montag": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday, "tues": time.Tuesday, "dienstag": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday, "mittwoch": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday, "donnerstag": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday, "freitag": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday, "samstag": time.Saturday, "sonnabend": time.Saturday,
+	"sunday": time.Sunday, "sun": time.Sunday, "sonntag": time.Sunday,
+}
+
+// pluralWeekdays mean "every <weekday>" ("mondays", "montags")
+var pluralWeekdays = map[string]time.Weekday{
+	"mondays": time.Monday, "montags": time.Monday,
+	"tuesdays": time.Tuesday, "dienstags": time.Tuesday,
+	"wednesdays": time.Wednesday, "mittwochs": time.Wednesday,
+	"thursdays": time.Thursday, "donnerstags": time.Thursday,
+	"fridays": time.Friday, "freitags": time.Friday,
+	"saturdays": time.Saturday, "samstags": time.Saturday,
+	"sundays": time.Sunday, "sonntags": time.Sunday,
+}
+
+var months = map[string]time.Month{
+	"jan": time.January, "january": time.January, "januar": time.January, "jänner": time.January,
+	"feb": time.February, "february": time.February, "februar": time.February,
+	"mar": time.March, "march": time.March, "märz": time.March, "maerz": time.March, "mrz": time.March,
+	"apr": time.April, "april": time.April,
+	"may": time.May, "mai": time.May,
+	"jun": time.June, "june": time.June, "juni": time.June,
+	"jul": time.July, "july": time.July, "juli": time.July,
+	"aug": time.August, "august": time.August,
+	"sep": time.September, "sept": time.September, "september": time.September,
+	"oct": time.October, "october": time.October, "okt": time.October, "oktober": time.October,
+	"nov": time.November, "november": time.November,
+	"dec": time.December, "december": time.December, "dez": time.December, "dezember": time.December,
+}
+
+// units maps duration words to d (days), w (weeks), m (months) and y (years)
+var units = map[string]byte{
+	"day": 'd', "days": 'd', "tag": 'd', "tage": 'd', "tagen": 'd',
+	"week": 'w', "weeks": 'w', "woche": 'w', "wochen": 'w',
+	"month": 'm', "months": 'm', "monat": 'm', "monate": 'm', "monaten": 'm',
+	"year": 'y', "years": 'y', "jahr": 'y', "jahre": 'y', "jahren": 'y',
+}
+
+// unitFrequencies maps units to recurrence frequencies
+var unitFrequencies = map[byte]string{'d': rrule.Daily, 'w': rrule.Weekly, 'm': rrule.Monthly, 'y': rrule.Yearly}
+
+// oneWords stand for the number 1 ("in a week", "in einer Woche")
+var oneWords = map[string]bool{
+	"a": true, "an": true, "one": true, "ein": true, "eine": true, "einen": true, "einem": true, "einer": true,
+}
+
+var priorities = map[string]string{
+	"high": "High", "hoch": "High", "h": "High", "3": "High", "!!": "High",
+	"medium": "Medium", "mittel": "Medium", "m": "Medium", "2": "Medium", "!": "Medium",
+	"low": "Low", "niedrig": "Low", "l": "Low", "1": "Low",
+}
+
+var workdays = []rrule.WeekdayNum{
+	{Weekday: time.Monday}, {Weekday: time.Tuesday}, {Weekday: time.Wednesday},
+	{Weekday: time.Thursday}, {Weekday: time.Friday},
+}
+
+// clock is a time of day
+type clock struct {
+	hour, minute int
+}
+
+type parser struct {
+	opts   Options
+	words  []string // Input words
+	norm   []string // Lowercase words without trailing punctuation
+	used   []bool   // Words recognized as part of a token
+	tokens []tokenSpan
+	result *Result
+
+	date    *time.Time    // Midnight of the recognized day
+	start   *clock        // Time or start of a time range
+	end     *clock        // End of a time range
+	rule    *rrule.Rule   // Recurrence
+	ruleDay *time.Weekday // Weekday of a weekly recurrence (the first occurrence is the default date)
+
+	labelKind string // Kind of the last recognized label (domain or tag)
+}
+
+// tokenSpan is a recognized token covering words [from, to)
+type tokenSpan struct {
+	from, to int
+	kind     string
+}
+
+// Parse interprets a quick-add input
+func Parse(input string, opts Options) (*Result, error) {
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	if opts.Type != "" && opts.Type != TypeTask && opts.Type != TypeEvent {
+		return nil, errors.New("invalid type")
+	}
+
+	words := strings.Fields(input)
+	p := &parser{
+		opts:   opts,
+		words:  words,
+		norm:   make([]string, len(words)),
+		used:   make([]bool, len(words)),
+		result: &Result{},
+	}
+	for i, word := range words {
+		p.norm[i] = normalize(word)
+	}
+
+	if err := p.scan(); err != nil {
+		return nil, err
+	}
+	return p.build()
+}
+
+// normalize lowercases a word and strips trailing punctuation (dots are kept on numbers, e.g. "3.11.")
+func normalize(word string) string {
+	word = strings.TrimRight(strings.ToLower(word), ",;")
+	if trimmed := strings.TrimRight(word, "."); trimmed != "" && !strings.ContainsAny(trimmed[len(trimmed)-1:], "0123456789") {
+		return trimmed
+	}
+	return word
+}
+
+// scan recognizes tokens from left to right, unrecognized words form the title
+func (p *parser) scan() error {
+	type matcher struct {
+		kind  string
+		match func(i int) (int, error)
+	}
+	matchers := []matcher{
+		{KindPriority, p.matchPriority},
+		{KindDomain, p.matchLabel},
+		{KindRecurrence, p.matchRecurrence},
+		{KindTime, p.matchTime},
+		{KindDate, p.matchDate},
+	}
+
+	for i := 0; i < len(p.words); {
+		consumed := 0
+		for _, m := range matchers {
+			n, err := m.match(i)
+			if err != nil {
+				return err
+			}
+			if n > 0 {
+				kind := m.kind
+				if kind == KindDomain {
+					kind = p.labelKind
+				}
+				p.tokens = append(p.tokens, tokenSpan{from: i, to: i + n, kind: kind})
+				for j := i; j < i+n; j++ {
+					p.used[j] = true
+				}
+				consumed = n
+				break
+			}
+		}
+		if consumed == 0 {
+			consumed = 1
+		}
+		i += consumed
+	}
+
+	return nil
+}
+
+// word returns the normalized word at i ("" past the end)
+func (p *parser) word(i int) string {
+	if i < 0 || i >= len(p.norm) {
+		return ""
+	}
+	return p.norm[i]
+}
+
+// matchPriority recognizes !high, !hoch, !h, !3, !!! (and the medium and low variants)
+func (p *parser) matchPriority(i int) (int, error) {
+	w := p.word(i)
+	if p.result.Priority != "" || !strings.HasPrefix(w, "!") {
+		return 0, nil
+	}
+	priority, ok := priorities[w[1:]]
+	if !ok {
+		return 0, nil
+	}
+	p.result.Priority = priority
+	return 1, nil
+}
+
+// labelName returns the name of a @ or # label ("#Coding_Time" -> "Coding_Time")
+func (p *parser) labelName(i int) string {
+	return strings.TrimRight(p.words[i][1:], ",;.")
+}
+
+// isTag checks if the label at i was recognized as a tag
+func (p *parser) isTag(i int) bool {
+	name := strings.ToLower(p.labelName(i))
+	for _, tag := range p.result.Tags {
+		if tag == name {
+			return true
+		}
+	}
+	return false
+}
+
+// matchLabel recognizes @Domain and #Domain, hashtags that are no domain become tags
+func (p *parser) matchLabel(i int) (int, error) {
+	word := p.words[i]
+	if len(word) < 2 || (word[0] != '@' && word[0] != '#') {
+		return 0, nil
+	}
+	name := p.labelName(i)
+	if name == "" {
+		return 0, nil
+	}
+
+	if p.result.Domain == "" && p.opts.Domain != nil {
+		if domain, ok := p.opts.Domain(name); ok {
+			p.result.Domain = domain
+			p.labelKind = KindDomain
+			return 1, nil
+		}
+	}
+
+	if word[0] == '@' {
+		if p.result.Domain != "" {
+			return 0, nil
+		}
+		return 0, errors.New("unknown domain: " + name)
+	}
+
+	if !p.isTag(i) {
+		p.result.Tags = append(p.result.Tags, strings.ToLower(name))
+	}
+	p.labelKind = KindTag
+	return 1, nil
+}
+
+// matchRecurrence recognizes "daily", "every 6 months", "every other week", "every monday", "jeden Werktag",
+// "alle 2 Wochen", "montags" and similar
+func (p *parser) matchRecurrence(i int) (int, error) {
+	if p.rule != nil {
+		return 0, nil
+	}
+	w := p.word(i)
+
+	switch w {
+	case "daily", "täglich", "taeglich":
+		p.setRule(rrule.Daily, 1, nil)
+		return 1, nil
+	case "weekly", "wöchentlich", "woechentlich":
+		p.setRule(rrule.Weekly, 1, nil)
+		return 1, nil
+	case "monthly", "monatlich":
+		p.setRule(rrule.Monthly, 1, nil)
+		return 1, nil
+	case "yearly", "annually", "jährlich", "jaehrlich":
+		p.setRule(rrule.Yearly, 1, nil)
+		return 1, nil
+	case "weekdays", "werktags":
+		p.setRule(rrule.Weekly, 1, workdays)
+		return 1, nil
+	}
+	if wd, ok := pluralWeekdays[w]; ok {
+		p.setWeekdayRule(1, wd)
+		return 1, nil
+	}
+
+	switch w {
+	case "every", "jeden", "jede", "jedes", "jeder", "alle":
+	default:
+		return 0, nil
+	}
+
+	j := i + 1
+	interval := 1
+	switch next := p.word(j); {
+	case next == "other" || next == "zweite" || next == "zweiten" || next == "zweites":
+		interval = 2
+		j++
+	case numberPattern.MatchString(next):
+		interval, _ = strconv.Atoi(next)
+		j++
+	}
+	if interval < 1 {
+		return 0, nil
+	}
+
+	unit := p.word(j)
+	if u, ok := units[unit]; ok {
+		p.setRule(unitFrequencies[u], interval, nil)
+		return j + 1 - i, nil
+	}
+	if wd, ok := weekdays[unit]; ok {
+		p.setWeekdayRule(interval, wd)
+		return j + 1 - i, nil
+	}
+	if unit == "weekday" || unit == "werktag" {
+		p.setRule(rrule.Weekly, interval, workdays)
+		return j + 1 - i, nil
+	}
+
+	return 0, nil
+}
+
+// setRule sets the recurrence
+func (p *parser) setRule(freq string, interval int, byDay []rrule.WeekdayNum) {
+	p.rule = &rrule.Rule{Freq: freq, Interval: interval, ByDay: byDay, Wkst: time.Monday}
+}
+
+// setWeekdayRule sets a weekly recurrence on one weekday
+func (p *parser) setWeekdayRule(interval int, wd time.Weekday) {
+	p.setRule(rrule.Weekly, interval, []rrule.WeekdayNum{{Weekday: wd}})
+	p.ruleDay = &wd
+}
+
+// matchTime recognizes "17:00", "5pm", "5 pm", "17 Uhr", "9-10", "9:30-11am", "at 5", "um 17",
+// "from 9 to 10" and "von 9 bis 10"
+func (p *parser) matchTime(i int) (int, error) {
+	if p.start != nil {
+		return 0, nil
+	}
+	w := p.word(i)
+
+	// Explicit prefixes also allow bare hours
+	prefixed := w == "at" || w == "um" || w == "from" || w == "von" || w == "ab"
+	j := i
+	if prefixed {
+		j++
+	}
+
+	if start, end, n := p.timeRange(j); n > 0 {
+		p.start, p.end = &start, &end
+		return j + n - i, nil
+	}
+
+	start, n := p.timeAt(j, prefixed)
+	if n == 0 {
+		return 0, nil
+	}
+
+	// "from 9 to 10", "von 9 bis 10"
+	k := j + n
+	switch p.word(k) {
+	case "to", "until", "till", "bis", "-":
+		if end, m := p.timeAt(k+1, true); m > 0 {
+			start = inheritMeridiem(start, end)
+			p.start, p.end = &start, &end
+			return k + 1 + m - i, nil
+		}
+	}
+
+	p.start = &start
+	return k - i, nil
+}
+
+// timeRange parses a range written as one word ("9-10", "14:30-16 Uhr") at i
+func (p *parser) timeRange(i int) (clock, clock, int) {
+	m := rangePattern.FindStringSubmatch(p.word(i))
+	if m == nil {
+		return clock{}, clock{}, 0
+	}
+
+	n := 1
+	endSuffix := m[6]
+	if endSuffix == "" {
+		switch p.word(i + 1) {
+		case "am", "pm", "uhr", "h":
+			endSuffix = p.word(i + 1)
+			n++
+		}
+	}
+
+	start, ok := toClock(m[1], m[2], m[3])
+	if !ok {
+		return clock{}, clock{}, 0
+	}
+	end, ok := toClock(m[4], m[5], endSuffix)
+	if !ok {
+		return clock{}, clock{}, 0
+	}
+	if m[3] == "" {
+		start = inheritMeridiem(start, end)
+	}
+
+	return start, end, n
+}
+
+// timeAt parses a single time at i (bare hours like "5" only if allowed)
+func (p *parser) timeAt(i int, allowBare bool) (clock, int) {
+	m := clockPattern.FindStringSubmatch(p.word(i))
+	if m == nil {
+		return clock{}, 0
+	}
+
+	n := 1
+	suffix := m[3]
+	if suffix == "" {
+		switch p.word(i + 1) {
+		case "am", "pm", "uhr", "h":
+			suffix = p.word(i + 1)
+			n++
+		}
+	}
+
+	// A number alone is only a time with a prefix or minutes ("at 5", "17:00")
+	if suffix == "" && m[2] == "" && !allowBare {
+		return clock{}, 0
+	}
+
+	c, ok := toClock(m[1], m[2], suffix)
+	if !ok {
+		return clock{}, 0
+	}
+	return c, n
+}
+
+// toClock converts hour, minute and an optional am/pm/h/uhr suffix
+func toClock(hour, minute, suffix string) (clock, bool) {
+	h, _ := strconv.Atoi(hour)
+	min := 0
+	if minute != "" {
+		min, _ = strconv.Atoi(minute)
+	}
+
+	switch suffix {
+	case "am", "pm":
+		if h < 1 || h > 12 {
+			return clock{}, false
+		}
+		h %= 12
+		if suffix == "pm" {
+			h += 12
+		}
+	}
+
+	if h > 23 || min > 59 {
+		return clock{}, false
+	}
+	return clock{hour: h, minute: min}, true
+}
+
+// inheritMeridiem moves a start without am/pm to the afternoon if the end is ("2-4pm" = 14:00-16:00)
+func inheritMeridiem(start, end clock) clock {
+	if start.hour < 12 && end.hour >= 12 && start.hour+12 <= end.hour {
+		start.hour += 12
+	}
+	return start
+}
+
+// matchDate recognizes today/heute, tomorrow/morgen, übermorgen, weekdays, "next tuesday", "nächste Woche",
+// "in 3 days", "in 2 Wochen", "2026-11-03", "3.11.", "3 nov", "nov 3rd", "3. November 2027" and similar
+func (p *parser) matchDate(i int) (int, error) {
+	if p.date != nil {
+		return 0, nil
+	}
+	today := p.today()
+	w := p.word(i)
+
+	switch w {
+	case "today", "heute":
+		return p.setDate(today, 1)
+	case "tomorrow", "tmrw", "tmr", "morgen":
+		return p.setDate(today.AddDate(0, 0, 1), 1)
+	case "übermorgen", "uebermorgen":
+		return p.setDate(today.AddDate(0, 0, 2), 1)
+	case "day":
+		if p.word(i+1) == "after" && p.word(i+2) == "tomorrow" {
+			return p.setDate(today.AddDate(0, 0, 2), 3)
+		}
+	case "next", "nächste", "nächsten", "nächster", "naechste", "naechsten", "naechster", "kommende", "kommenden":
+		next := p.word(i + 1)
+		if wd, ok := weekdays[next]; ok {
+			return p.setDate(nextWeekday(today, wd, false), 2)
+		}
+		switch units[next] {
+		case 'w':
+			// Monday of next week
+			return p.setDate(today.AddDate(0, 0, 7-(int(today.Weekday())+6)%7), 2)
+		case 'm':
+			return p.setDate(time.Date(today.Year(), today.Month()+1, 1, 0, 0, 0, 0, today.Location()), 2)
+		case 'y':
+			return p.setDate(time.Date(today.Year()+1, time.January, 1, 0, 0, 0, 0, today.Location()), 2)
+		}
+		return 0, nil
+	case "on", "am", "this", "diesen", "diese", "dieser":
+		if wd, ok := weekdays[p.word(i+1)]; ok {
+			return p.setDate(nextWeekday(today, wd, true), 2)
+		}
+		if date, n := p.calendarDate(i + 1); n > 0 {
+			return p.setDate(date, n+1)
+		}
+		return 0, nil
+	case "in":
+		count := p.word(i + 1)
+		amount := 0
+		if oneWords[count] {
+			amount = 1
+		} else if numberPattern.MatchString(count) {
+			amount, _ = strconv.Atoi(count)
+		}
+		if amount > 0 {
+			switch units[p.word(i+2)] {
+			case 'd':
+				return p.setDate(today.AddDate(0, 0, amount), 3)
+			case 'w':
+				return p.setDate(today.AddDate(0, 0, 7*amount), 3)
+			case 'm':
+				return p.setDate(today.AddDate(0, amount, 0), 3)
+			case 'y':
+				return p.setDate(today.AddDate(amount, 0, 0), 3)
+			}
+		}
+		return 0, nil
+	}
+
+	if wd, ok := weekdays[w]; ok {
+		return p.setDate(nextWeekday(today, wd, true), 1)
+	}
+
+	if date, n := p.calendarDate(i); n > 0 {
+		return p.setDate(date, n)
+	}
+	return 0, nil
+}
+
+// setDate stores the recognized date
+func (p *parser) setDate(date time.Time, consumed int) (int, error) {
+	p.date = &date
+	return consumed, nil
+}
+
+// today returns midnight of the reference day
+func (p *parser) today() time.Time {
+	now := p.opts.Now
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+}
+
+// calendarDate parses an explicit date at i; dates without a year are the next such day from today
+func (p *parser) calendarDate(i int) (time.Time, int) {
+	w := p.word(i)
+	today := p.today()
+
+	if m := isoDatePattern.FindStringSubmatch(w); m != nil {
+		if date, ok := p.makeDate(m[1], m[2], m[3]); ok {
+			return date, 1
+		}
+		return time.Time{}, 0
+	}
+
+	if m := dottedDatePattern.FindStringSubmatch(w); m != nil {
+		if m[3] == "" {
+			return p.nextDate(m[2], m[1], 1)
+		}
+		year := m[3]
+		if len(year) == 2 {
+			year = strconv.Itoa(today.Year()/100*100) + year
+		}
+		if date, ok := p.makeDate(year, m[2], m[1]); ok {
+			return date, 1
+		}
+		return time.Time{}, 0
+	}
+
+	// "3 nov", "3. November 2027"
+	if m := dayPattern.FindStringSubmatch(w); m != nil {
+		if month, ok := months[p.word(i+1)]; ok {
+			return p.dateWithOptionalYear(i+2, strconv.Itoa(int(month)), m[1], 2)
+		}
+		return time.Time{}, 0
+	}
+
+	// "nov 3", "November 3rd 2027"
+	if month, ok := months[w]; ok {
+		if m := dayPattern.FindStringSubmatch(p.word(i + 1)); m != nil {
+			return p.dateWithOptionalYear(i+2, strconv.Itoa(int(month)), m[1], 2)
+		}
+	}
+
+	return time.Time{}, 0
+}
+
+// dateWithOptionalYear builds a date from month and day, using a year at i if there is one
+func (p *parser) dateWithOptionalYear(i int, month, day string, consumed int) (time.Time, int) {
+	if year := p.word(i); yearPattern.MatchString(year) {
+		if date, ok := p.makeDate(year, month, day); ok {
+			return date, consumed + 1
+		}
+		return time.Time{}, 0
+	}
+	return p.nextDate(month, day, consumed)
+}
+
+// nextDate returns the next occurrence of a month and day (today included)
+func (p *parser) nextDate(month, day string, consumed int) (time.Time, int) {
+	today := p.today()
+	date, ok := p.makeDate(strconv.Itoa(today.Year()), month, day)
+	if !ok {
+		return time.Time{}, 0
+	}
+	if date.Before(today) {
+		date, ok = p.makeDate(strconv.Itoa(today.Year()+1), month, day)
+		if !ok {
+			return time.Time{}, 0
+		}
+	}
+	return date, consumed
+}
+
+// makeDate creates a date, rejecting days that do not exist (e.g. 31.2.)
+func (p *parser) makeDate(year, month, day string) (time.Time, bool) {
+	y, _ := strconv.Atoi(year)
+	m, _ := strconv.Atoi(month)
+	d, _ := strconv.Atoi(day)
+	if m < 1 || m > 12 || d < 1 {
+		return time.Time{}, false
+	}
+
+	date := time.Date(y, time.Month(m), d, 0, 0, 0, 0, p.opts.Now.Location())
+	if date.Day() != d {
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+// nextWeekday returns the next day with the weekday (today only if includeToday is set)
+func nextWeekday(today time.Time, wd time.Weekday, includeToday bool) time.Time {
+	days := (int(wd) - int(today.Weekday()) + 7) % 7
+	if days == 0 && !includeToday {
+		days = 7
+	}
+	return today.AddDate(0, 0, days)
+}
+
+// build assembles the result from the recognized tokens and the remaining title words
+func (p *parser) build() (*Result, error) {
+	result := p.result
+
+	result.Type = p.opts.Type
+	if result.Type == "" {
+		result.Type = TypeTask
+		if p.end != nil {
+			result.Type = TypeEvent
+		}
+	}
+
+	// Events have no tags, their hashtags stay in the title
+	if result.Type == TypeEvent && len(result.Tags) > 0 {
+		result.Tags = nil
+		var tokens []tokenSpan
+		for _, token := range p.tokens {
+			if token.kind == KindTag {
+				p.used[token.from] = false
+				continue
+			}
+			tokens = append(tokens, token)
+		}
+		p.tokens = tokens
+	}
+
+	var title []string
+	for i, word := range p.words {
+		if !p.used[i] {
+			title = append(title, word)
+		}
+	}
+	result.Title = strings.TrimSpace(strings.Join(title, " "))
+	if result.Title == "" {
+		return nil, errors.New("title is required")
+	}
+
+	result.Tokens = make([]Token, len(p.tokens))
+	for i, token := range p.tokens {
+		result.Tokens[i] = Token{Text: strings.Join(p.words[token.from:token.to], " "), Kind: token.kind}
+	}
+
+	// Default date: the first day of a weekday recurrence, otherwise today if anything needs a date
+	// (tomorrow for a time that has already passed today)
+	date := p.date
+	if date == nil {
+		var fallback time.Time
+		switch {
+		case p.ruleDay != nil:
+			fallback = nextWeekday(p.today(), *p.ruleDay, true)
+		case p.start != nil || p.rule != nil || result.Type == TypeEvent:
+			fallback = p.today()
+			if p.start != nil && atClock(fallback, *p.start).Before(p.opts.Now) {
+				fallback = fallback.AddDate(0, 0, 1)
+			}
+		}
+		if !fallback.IsZero() {
+			date = &fallback
+		}
+	}
+
+	if p.rule != nil {
+		result.RecurrenceRule = p.rule.String()
+	}
+
+	if date == nil {
+		return result, nil
+	}
+
+	start := *date
+	result.AllDay = p.start == nil
+	if p.start != nil {
+		start = atClock(*date, *p.start)
+	}
+
+	if result.Type == TypeTask {
+		result.Deadline = &start
+		return result, nil
+	}
+
+	result.Start = &start
+	if p.start != nil {
+		end := start.Add(defaultEventDuration)
+		if p.end != nil {
+			end = atClock(*date, *p.end)
+			// Ranges past midnight end on the next day ("22-1")
+			if !end.After(start) {
+				end = end.AddDate(0, 0, 1)
+			}
+		}
+		result.End = &end
+	}
+
+	return result, nil
+}
+
+// atClock returns the time of day on a date
+func atClock(date time.Time, c clock) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), c.hour, c.minute, 0, 0, date.Location())
+}
@@ -0,0 +1,176 @@
+package quickadd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("load Europe/Berlin: %v", err)
+	}
+	const layout = "2006-01-02 15:04"
+
+	// Saturday afternoon
+	now := time.Date(2026, 10, 17, 14, 30, 0, 0, berlin)
+	domains := map[string]string{"university": "University", "health": "Health", "finanzen": "Finanzen"}
+	domain := func(name string) (string, bool) {
+		d, ok := domains[strings.ToLower(name)]
+		return d, ok
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		typ      string
+		title    string
+		priority string
+		domain   string
+		tags     []string
+		deadline string // Tasks
+		start    string // Events
+		end      string
+		rule     string
+	}{
+		{
+			name:     "task with weekday, time, priority and domain",
+			input:    "Submit thesis draft fri 17:00 !high #University",
+			typ:      TypeTask,
+			title:    "Submit thesis draft",
+			priority: "High",
+			domain:   "University",
+			deadline: "2026-10-23 17:00",
+		},
+		{
+			name:   "event with next weekday, range and recurrence",
+			input:  "Dentist next tuesday 9-10 @Health every 6 months",
+			typ:    TypeEvent,
+			title:  "Dentist",
+			domain: "Health",
+			start:  "2026-10-20 09:00",
+			end:    "2026-10-20 10:00",
+			rule:   "FREQ=MONTHLY;INTERVAL=6",
+		},
+		{
+			name:   "german event with next weekday, range and recurrence",
+			input:  "Zahnarzt nächsten dienstag 9-10 @Health alle 6 monate",
+			typ:    TypeEvent,
+			title:  "Zahnarzt",
+			domain: "Health",
+			start:  "2026-10-20 09:00",
+			end:    "2026-10-20 10:00",
+			rule:   "FREQ=MONTHLY;INTERVAL=6",
+		},
+		{
+			name:     "german task with tomorrow, uhr, priority, domain and tag",
+			input:    "Steuererklärung abgeben morgen 17 Uhr !hoch #Finanzen #Papierkram",
+			typ:      TypeTask,
+			title:    "Steuererklärung abgeben",
+			priority: "High",
+			domain:   "Finanzen",
+			tags:     []string{"papierkram"},
+			deadline: "2026-10-18 17:00",
+		},
+		{
+			name:  "german event with von bis and weekday recurrence",
+			input: "Teammeeting montags von 9 bis 10",
+			typ:   TypeEvent,
+			title: "Teammeeting",
+			start: "2026-10-19 09:00",
+			end:   "2026-10-19 10:00",
+			rule:  "FREQ=WEEKLY;BYDAY=MO",
+		},
+		{
+			name:  "range that has passed today is tomorrow",
+			input: "Standup 9am-9:30am",
+			typ:   TypeEvent,
+			title: "Standup",
+			start: "2026-10-18 09:00",
+			end:   "2026-10-18 09:30",
+		},
+		{
+			name:  "range later today stays today",
+			input: "Call 4pm-5pm",
+			typ:   TypeEvent,
+			title: "Call",
+			start: "2026-10-17 16:00",
+			end:   "2026-10-17 17:00",
+		},
+		{
+			name:     "german time that has passed today is tomorrow",
+			input:    "Müll rausbringen um 8",
+			typ:      TypeTask,
+			title:    "Müll rausbringen",
+			deadline: "2026-10-18 08:00",
+		},
+		{
+			name:     "explicit today keeps a passed time",
+			input:    "Report heute 9:00",
+			typ:      TypeTask,
+			title:    "Report",
+			deadline: "2026-10-17 09:00",
+		},
+		{
+			name:     "german calendar date",
+			input:    "Abgabe 3.11. 12:00",
+			typ:      TypeTask,
+			title:    "Abgabe",
+			deadline: "2026-11-03 12:00",
+		},
+		{
+			name:  "range past midnight ends the next day",
+			input: "Party sat 22-1",
+			typ:   TypeEvent,
+			title: "Party",
+			start: "2026-10-17 22:00",
+			end:   "2026-10-18 01:00",
+		},
+	}
+
+	format := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.In(berlin).Format(layout)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Parse(tt.input, Options{Now: now, Domain: domain})
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.input, err)
+			}
+
+			if result.Type != tt.typ {
+				t.Errorf("type = %q, want %q", result.Type, tt.typ)
+			}
+			if result.Title != tt.title {
+				t.Errorf("title = %q, want %q", result.Title, tt.title)
+			}
+			if result.Priority != tt.priority {
+				t.Errorf("priority = %q, want %q", result.Priority, tt.priority)
+			}
+			if result.Domain != tt.domain {
+				t.Errorf("domain = %q, want %q", result.Domain, tt.domain)
+			}
+			if !reflect.DeepEqual(result.Tags, tt.tags) {
+				t.Errorf("tags = %q, want %q", result.Tags, tt.tags)
+			}
+			if got := format(result.Deadline); got != tt.deadline {
+				t.Errorf("deadline = %q, want %q", got, tt.deadline)
+			}
+			if got := format(result.Start); got != tt.start {
+				t.Errorf("start = %q, want %q", got, tt.start)
+			}
+			if got := format(result.End); got != tt.end {
+				t.Errorf("end = %q, want %q", got, tt.end)
+			}
+			if result.RecurrenceRule != tt.rule {
+				t.Errorf("rule = %q, want %q", result.RecurrenceRule, tt.rule)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+// Package rank generates lexicographic ranks for manually ordered lists.
+// A rank is a string of base-36 digits (0-9, a-z) that never ends in "0", so there is always
+// room for another rank between two neighbours and moving an item only rewrites its own rank.
+package rank
+
+import (
+	"errors"
+	"strings"
+)
+
+const digits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+const base = len(digits)
+
+// MaxLength is the rank length after which a list should be rebalanced with Spread
+const MaxLength = 32
+
+// Between returns a rank sorting after prev and before next ("" = start or end of the list)
+func Between(prev, next string) (string, error) {
+	if !valid(prev) || !valid(next) {
+		return "", errors.New("invalid rank")
+	}
+	if next != "" && prev >= next {
+		return "", errors.New("ranks are out of order")
+	}
+	return midpoint(prev, next), nil
+}
+
+// Spread returns n evenly spaced ranks in ascending order (for ranking or rebalancing a whole list)
+func Spread(n int) []string {
+	ranks := make([]string, n)
+	if n == 0 {
+		return ranks
+	}
+
+	// Enough digits to leave gaps between all ranks
+	width, capacity := 1, base
+	for capacity <= 2*(n+1) {
+		width++
+		capacity *= base
+	}
+
+	step := capacity / (n + 1)
+	for i := range ranks {
+		ranks[i] = strings.TrimRight(encode((i+1)*step, width), "0")
+	}
+	return ranks
+}
+
+// midpoint returns a string between a and b (b == "" = above every rank), assuming a < b
+func midpoint(a, b string) string {
+	// Skip the common prefix (a is padded with the lowest, b with the highest digit)
+	n := 0
+	for {
+		da, db := digit(a, n, 0), digit(b, n, base)
+		if da != db {
+			break
+		}
+		n++
+	}
+	prefix := pad(a, n)
+
+	da, db := digit(a, n, 0), digit(b, n, base)
+	if db-da > 1 {
+		return prefix + string(digits[(da+db)/2])
+	}
+
+	// Neighbouring digits: keep the digit of a and go above the rest of a
+	rest := ""
+	if n+1 < len(a) {
+		rest = a[n+1:]
+	}
+	return prefix + string(digits[da]) + midpoint(rest, "")
+}
+
+// digit returns the value of the i-th digit of s, or fallback past its end
+func digit(s string, i, fallback int) int {
+	if i >= len(s) {
+		return fallback
+	}
+	return strings.IndexByte(digits, s[i])
+}
+
+// pad returns the first n digits of s, filled up with the lowest digit
+func pad(s string, n int) string {
+	if len(s) >= n {
+		return s[:n]
+	}
+	return s + strings.Repeat(string(digits[0]), n-len(s))
+}
+
+// encode writes a value as a fixed-width base-36 number
+func encode(value, width int) string {
+	b := make([]byte, width)
+	for i := width - 1; i >= 0; i-- {
+		b[i] = digits[value%base]
+		value /= base
+	}
+	return string(b)
+}
+
+// valid checks if s only uses rank digits and does not end in the lowest digit
+func valid(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte(digits, s[i]) < 0 {
+			return false
+		}
+	}
+	return !strings.HasSuffix(s, string(digits[0]))
+}
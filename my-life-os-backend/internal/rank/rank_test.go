@@ -0,0 +1,123 @@
+package rank
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBetween(t *testing.T) {
+	long := "a" + strings.Repeat("0", 30) + "1"
+
+	tests := []struct {
+		name       string
+		prev, next string
+		want       string // Empty = only check the order
+	}{
+		{"empty list", "", "", "i"},
+		{"top of the list", "", "a", "5"},
+		{"top above the lowest rank", "", "1", "0i"},
+		{"end of the list", "a", "", "n"},
+		{"end below the highest rank", "z", "", "zi"},
+		{"gap between digits", "a", "c", "b"},
+		{"adjacent digits", "a", "b", "ai"},
+		{"next extends prev", "a", "a1", "a0i"},
+		{"next extends prev by a low digit", "a", "a01", "a00i"},
+		{"prev extends next's prefix", "a1", "b", "ai"},
+		{"long neighbours", long, "a" + strings.Repeat("0", 30) + "2", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Between(tt.prev, tt.next)
+			if err != nil {
+				t.Fatalf("Between(%q, %q): %v", tt.prev, tt.next, err)
+			}
+			if tt.want != "" && got != tt.want {
+				t.Errorf("Between(%q, %q) = %q, want %q", tt.prev, tt.next, got, tt.want)
+			}
+			if got <= tt.prev || (tt.next != "" && got >= tt.next) {
+				t.Errorf("Between(%q, %q) = %q is out of order", tt.prev, tt.next, got)
+			}
+			if !valid(got) {
+				t.Errorf("Between(%q, %q) = %q is no valid rank", tt.prev, tt.next, got)
+			}
+		})
+	}
+}
+
+func TestBetweenErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		prev, next string
+	}{
+		{"equal ranks", "a", "a"},
+		{"out of order", "b", "a"},
+		{"trailing lowest digit", "a0", ""},
+		{"invalid digit", "A", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := Between(tt.prev, tt.next); err == nil {
+				t.Errorf("Between(%q, %q) = %q, want an error", tt.prev, tt.next, got)
+			}
+		})
+	}
+}
+
+func TestBetweenRepeatedInserts(t *testing.T) {
+	// Moving every item to the top (or bottom) keeps the order and only grows the ranks slowly
+	first, last := "i", "i"
+	for i := 0; i < 100; i++ {
+		top, err := Between("", first)
+		if err != nil {
+			t.Fatalf("insert %d at the top: %v", i, err)
+		}
+		if top >= first || !valid(top) {
+			t.Fatalf("insert %d at the top: %q before %q", i, top, first)
+		}
+		first = top
+
+		bottom, err := Between(last, "")
+		if err != nil {
+			t.Fatalf("insert %d at the end: %v", i, err)
+		}
+		if bottom <= last || !valid(bottom) {
+			t.Fatalf("insert %d at the end: %q after %q", i, bottom, last)
+		}
+		last = bottom
+	}
+	if len(first) > MaxLength || len(last) > MaxLength {
+		t.Errorf("ranks grew to %d and %d digits", len(first), len(last))
+	}
+}
+
+func TestSpread(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 17, 35, 36, 100, 1000, 5000} {
+		ranks := Spread(n)
+		if len(ranks) != n {
+			t.Fatalf("Spread(%d) returned %d ranks", n, len(ranks))
+		}
+		for i, r := range ranks {
+			if r == "" || !valid(r) {
+				t.Errorf("Spread(%d)[%d] = %q is no valid rank", n, i, r)
+			}
+			if strings.HasSuffix(r, "0") {
+				t.Errorf("Spread(%d)[%d] = %q ends in 0", n, i, r)
+			}
+			if i > 0 && ranks[i-1] >= r {
+				t.Errorf("Spread(%d)[%d] = %q is not after %q", n, i, r, ranks[i-1])
+			}
+		}
+
+		// There is room at both ends
+		if n > 0 {
+			if _, err := Between("", ranks[0]); err != nil {
+				t.Errorf("Spread(%d): no room before the first rank: %v", n, err)
+			}
+			if _, err := Between(ranks[n-1], ""); err != nil {
+				t.Errorf("Spread(%d): no room after the last rank: %v", n, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type calendarFeedTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewCalendarFeedTokenRepository creates a new calendar feed token repository
+func NewCalendarFeedTokenRepository(db *gorm.DB) interfaces.CalendarFeedTokenRepository {
+	return &calendarFeedTokenRepository{db: db}
+}
+
+// CreateFeedToken creates a new feed token
+func (r *calendarFeedTokenRepository) CreateFeedToken(token *entities.CalendarFeedToken) error {
+	return r.db.Create(token).Error
+}
+
+// FindFeedTokenByID retrieves a feed token by ID
+func (r *calendarFeedTokenRepository) FindFeedTokenByID(id uuid.UUID) (*entities.CalendarFeedToken, error) {
+	var token entities.CalendarFeedToken
+	err := r.db.Where("id = ?", id).First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// FindFeedTokenByHash retrieves a feed token by the hash of its secret
+func (r *calendarFeedTokenRepository) FindFeedTokenByHash(tokenHash string) (*entities.CalendarFeedToken, error) {
+	var token entities.CalendarFeedToken
+	err := r.db.Where("token_hash = ?", tokenHash).First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// FindFeedTokensByUserID retrieves all feed tokens for a user
+func (r *calendarFeedTokenRepository) FindFeedTokensByUserID(userID uuid.UUID) ([]*entities.CalendarFeedToken, error) {
+	var tokens []*entities.CalendarFeedToken
+	err := r.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&tokens).Error
+	if err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// TouchFeedToken updates the last used timestamp of a feed token
+func (r *calendarFeedTokenRepository) TouchFeedToken(id uuid.UUID) error {
+	return r.db.Model(&entities.CalendarFeedToken{}).Where("id = ?", id).Update("last_used_at", time.Now()).Error
+}
+
+// DeleteFeedToken deletes a feed token
+func (r *calendarFeedTokenRepository) DeleteFeedToken(id uuid.UUID) error {
+	return r.db.Delete(&entities.CalendarFeedToken{}, id).Error
+}
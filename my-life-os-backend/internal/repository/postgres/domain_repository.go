@@ -0,0 +1,141 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type domainRepository struct {
+	db *gorm.DB
+}
+
+// NewDomainRepository creates a new domain repository
+func NewDomainRepository(db *gorm.DB) interfaces.DomainRepository {
+	return &domainRepository{db: db}
+}
+
+// CreateDomain creates a new domain
+func (r *domainRepository) CreateDomain(domain *entities.Domain) error {
+	return r.db.Create(domain).Error
+}
+
+// CreateDomainsIfNotExist creates domains, names the user already has are skipped
+func (r *domainRepository) CreateDomainsIfNotExist(domains []*entities.Domain) error {
+	if len(domains) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&domains).Error
+}
+
+// FindDomainByID retrieves a domain by ID
+func (r *domainRepository) FindDomainByID(id uuid.UUID) (*entities.Domain, error) {
+	var domain entities.Domain
+	err := r.db.Where("id = ?", id).First(&domain).Error
+	if err != nil {
+		return nil, err
+	}
+	return &domain, nil
+}
+
+// FindDomainsByUserID retrieves all domains for a user
+func (r *domainRepository) FindDomainsByUserID(userID uuid.UUID, includeArchived bool) ([]*entities.Domain, error) {
+	var domains []*entities.Domain
+	query := r.db.Where("user_id = ?", userID)
+
+	if !includeArchived {
+		query = query.Where("archived = false")
+	}
+
+	err := query.Order("name ASC").Find(&domains).Error
+	if err != nil {
+		return nil, err
+	}
+	return domains, nil
+}
+
+// UpdateDomain updates a domain and rewrites the references if it was renamed
+func (r *domainRepository) UpdateDomain(domain *entities.Domain, oldName string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(domain).Error; err != nil {
+			return err
+		}
+		if oldName == domain.Name {
+			return nil
+		}
+		return rewriteDomainReferences(tx, domain.UserID, oldName, domain.Name)
+	})
+}
+
+// MergeDomains rewrites the references of the source domain to the target and deletes the source
+func (r *domainRepository) MergeDomains(source, target *entities.Domain) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := rewriteDomainReferences(tx, source.UserID, source.Name, target.Name); err != nil {
+			return err
+		}
+		if err := tx.Model(&entities.Domain{}).Where("id = ?", target.ID).Update("updated_at", time.Now()).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&entities.Domain{}, source.ID).Error
+	})
+}
+
+// CountDomainReferences counts the tasks, events and event exceptions using a domain name
+func (r *domainRepository) CountDomainReferences(userID uuid.UUID, name string) (int64, error) {
+	var total int64
+
+	for _, ref := range domainReferences {
+		var count int64
+		err := r.db.Table(ref.table).Where("user_id = ? AND "+ref.column+" = ?", userID, name).Count(&count).Error
+		if err != nil {
+			return 0, err
+		}
+		total += count
+	}
+
+	return total, nil
+}
+
+// DeleteDomain deletes a domain
+func (r *domainRepository) DeleteDomain(id uuid.UUID) error {
+	return r.db.Delete(&entities.Domain{}, id).Error
+}
+
+// domainReferences lists the columns that store domain names
+var domainReferences = []struct {
+	table  string
+	column string
+}{
+	{"tasks", "domain"},
+	{"events", "domain"},
+	{"event_exceptions", "modified_domain"},
+}
+
+// rewriteDomainReferences renames a domain in all referencing rows of a user
+// (plain table updates, so UpdatedAt and the entity hooks are left alone)
+func rewriteDomainReferences(tx *gorm.DB, userID uuid.UUID, oldName, newName string) error {
+	for _, ref := range domainReferences {
+		err := tx.Table(ref.table).
+			Where("user_id = ? AND "+ref.column+" = ?", userID, oldName).
+			Update(ref.column, newName).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	// Manual task order: drop stale ranks in the new list, then move the old list over
+	err := tx.Exec(`DELETE FROM task_ranks WHERE user_id = ? AND list = ? AND list_key = ?
+		AND task_id IN (SELECT task_id FROM task_ranks WHERE user_id = ? AND list = ? AND list_key = ?)`,
+		userID, entities.TaskListDomain, newName, userID, entities.TaskListDomain, oldName).Error
+	if err != nil {
+		return err
+	}
+	return tx.Model(&entities.TaskRank{}).
+		Where("user_id = ? AND list = ? AND list_key = ?", userID, entities.TaskListDomain, oldName).
+		Update("list_key", newName).Error
+}
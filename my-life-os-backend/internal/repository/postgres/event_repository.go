@@ -24,6 +24,21 @@ func (r *eventRepository) CreateEvent(event *entities.Event) error {
 	return r.db.Create(event).Error
 }
 
+// CreateEventWithExceptions creates an event and its exceptions in one transaction
+func (r *eventRepository) CreateEventWithExceptions(event *entities.Event, exceptions []*entities.EventException) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(event).Error; err != nil {
+			return err
+		}
+		for _, exception := range exceptions {
+			if err := tx.Create(exception).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 // FindEventByID retrieves an event by ID
 func (r *eventRepository) FindEventByID(id uuid.UUID) (*entities.Event, error) {
 	var event entities.Event
@@ -34,6 +49,26 @@ func (r *eventRepository) FindEventByID(id uuid.UUID) (*entities.Event, error) {
 	return &event, nil
 }
 
+// FindEventsByUserID retrieves all events for a user
+func (r *eventRepository) FindEventsByUserID(userID uuid.UUID) ([]*entities.Event, error) {
+	var events []*entities.Event
+	err := r.db.Where("user_id = ?", userID).Order("start_date ASC").Find(&events).Error
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// FindEventByICalUID retrieves a user's event by its iCalendar UID
+func (r *eventRepository) FindEventByICalUID(userID uuid.UUID, icalUID string) (*entities.Event, error) {
+	var event entities.Event
+	err := r.db.Where("user_id = ? AND ical_uid = ?", userID, icalUID).First(&event).Error
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
 // FindEventsByUserIDAndDateRange retrieves all events for a user within a date range
 func (r *eventRepository) FindEventsByUserIDAndDateRange(userID uuid.UUID, start, end time.Time) ([]*entities.Event, error) {
 	var events []*entities.Event
@@ -41,8 +76,8 @@ func (r *eventRepository) FindEventsByUserIDAndDateRange(userID uuid.UUID, start
 	// Query for non-recurring events in range OR recurring events that could appear in range
 	err := r.db.Where("user_id = ?", userID).
 		Where(
-			r.db.Where("is_recurring = false AND start_date BETWEEN ? AND ?", start, end).
-				Or("is_recurring = true AND start_date <= ? AND (recurrence_end IS NULL OR recurrence_end >= ?)", end, start),
+			r.db.Where("is_recurring = false AND start_date BETWEEN ? AND ?", start.UTC(), end.UTC()).
+				Or("is_recurring = true AND start_date <= ? AND (recurrence_end IS NULL OR recurrence_end >= ?)", end.UTC(), start.UTC()),
 		).
 		Order("start_date ASC").
 		Find(&events).Error
@@ -58,16 +93,116 @@ func (r *eventRepository) UpdateEvent(event *entities.Event) error {
 	return r.db.Save(event).Error
 }
 
-// DeleteEvent deletes an event
+// DeleteEvent moves an event to the trash (exceptions are kept for restoring)
 func (r *eventRepository) DeleteEvent(id uuid.UUID) error {
 	return r.db.Delete(&entities.Event{}, id).Error
 }
 
+// FindDeletedEventsByUserID retrieves the trashed events of a user (most recently deleted first)
+func (r *eventRepository) FindDeletedEventsByUserID(userID uuid.UUID) ([]*entities.Event, error) {
+	var events []*entities.Event
+	err := r.db.Unscoped().Where("user_id = ? AND deleted_at IS NOT NULL", userID).
+		Order("deleted_at DESC").
+		Find(&events).Error
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// FindDeletedEventByID retrieves an event in the trash by ID
+func (r *eventRepository) FindDeletedEventByID(id uuid.UUID) (*entities.Event, error) {
+	var event entities.Event
+	err := r.db.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(&event).Error
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// RestoreEvent takes an event out of the trash
+func (r *eventRepository) RestoreEvent(id uuid.UUID) error {
+	return r.db.Unscoped().Model(&entities.Event{}).Where("id = ?", id).Update("deleted_at", nil).Error
+}
+
+// PurgeEvent permanently deletes an event with its exceptions
+func (r *eventRepository) PurgeEvent(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		_, err := purgeEvents(tx, `SELECT ?::uuid`, id)
+		return err
+	})
+}
+
+// PurgeDeletedEvents permanently deletes the events that were moved to the trash before a time
+func (r *eventRepository) PurgeDeletedEvents(before time.Time) (int64, error) {
+	var purged int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		purged, err = purgeEvents(tx, `SELECT id FROM events WHERE deleted_at < ?`, before)
+		return err
+	})
+	return purged, err
+}
+
+// purgeEvents permanently deletes the events selected by a subquery and their exceptions.
+// Series split off a purged event stay, they are no longer linked to it.
+func purgeEvents(tx *gorm.DB, ids string, args ...any) (int64, error) {
+	if err := tx.Exec(`DELETE FROM event_exceptions WHERE event_id IN (`+ids+`)`, args...).Error; err != nil {
+		return 0, err
+	}
+	if err := tx.Exec(`UPDATE events SET parent_event_id = NULL WHERE parent_event_id IN (`+ids+`)`, args...).Error; err != nil {
+		return 0, err
+	}
+
+	result := tx.Exec(`DELETE FROM events WHERE id IN (`+ids+`)`, args...)
+	return result.RowsAffected, result.Error
+}
+
+// MergeEventSeries saves the extended parent series and the exceptions moved to it from the split,
+// then deletes the split (splits of the split are linked to the parent)
+func (r *eventRepository) MergeEventSeries(parent, split *entities.Event, moved []*entities.EventException) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(parent).Error; err != nil {
+			return err
+		}
+
+		for _, exception := range moved {
+			exception.EventID = parent.ID
+			if err := tx.Save(exception).Error; err != nil {
+				return err
+			}
+		}
+
+		err := tx.Model(&entities.Event{}).
+			Where("parent_event_id = ?", split.ID).
+			Update("parent_event_id", parent.ID).Error
+		if err != nil {
+			return err
+		}
+
+		// Remaining exceptions belong to occurrences the parent series does not have
+		if err := tx.Where("event_id = ?", split.ID).Delete(&entities.EventException{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(&entities.Event{}, split.ID).Error
+	})
+}
+
 // CreateEventException creates an event exception
 func (r *eventRepository) CreateEventException(exception *entities.EventException) error {
 	return r.db.Create(exception).Error
 }
 
+// FindEventExceptionByID retrieves an exception by its ID
+func (r *eventRepository) FindEventExceptionByID(id uuid.UUID) (*entities.EventException, error) {
+	var exception entities.EventException
+	err := r.db.Where("id = ?", id).First(&exception).Error
+	if err != nil {
+		return nil, err
+	}
+	return &exception, nil
+}
+
 // FindEventExceptionsByEventID retrieves all exceptions for an event
 func (r *eventRepository) FindEventExceptionsByEventID(eventID uuid.UUID) ([]*entities.EventException, error) {
 	var exceptions []*entities.EventException
@@ -84,7 +219,7 @@ func (r *eventRepository) FindEventExceptionsByEventID(eventID uuid.UUID) ([]*en
 // FindEventExceptionsByDateRange retrieves exceptions within a date range
 func (r *eventRepository) FindEventExceptionsByDateRange(eventID uuid.UUID, start, end time.Time) ([]*entities.EventException, error) {
 	var exceptions []*entities.EventException
-	err := r.db.Where("event_id = ? AND original_date BETWEEN ? AND ?", eventID, start, end).
+	err := r.db.Where("event_id = ? AND original_date BETWEEN ? AND ?", eventID, start.UTC(), end.UTC()).
 		Order("original_date ASC").
 		Find(&exceptions).Error
 
@@ -1,12 +1,22 @@
 package postgres
 
 import (
+	"time"
+
 	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
 	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+// liveProjectTasks limits project assignments to tasks that are not in the trash
+func liveProjectTasks(db *gorm.DB) *gorm.DB {
+	return db.Where("task_id IN (" + liveTasks + ")")
+}
+
+// liveProjects selects the IDs of projects that are not in the trash (for subqueries GORM does not scope)
+const liveProjects = `SELECT id FROM projects WHERE deleted_at IS NULL`
+
 type projectRepository struct {
 	db *gorm.DB
 }
@@ -24,7 +34,7 @@ func (r *projectRepository) CreateProject(project *entities.Project) error {
 // FindProjectByID retrieves a project by ID
 func (r *projectRepository) FindProjectByID(id uuid.UUID) (*entities.Project, error) {
 	var project entities.Project
-	err := r.db.Preload("TechStack.Category").Preload("Tasks.Task").Where("id = ?", id).First(&project).Error
+	err := preloadSubtasks(r.db, "Tasks.Task.").Preload("TechStack.Category").Preload("WIPLimits").Preload("Tasks", liveProjectTasks).Preload("Tasks.Task").Where("id = ?", id).First(&project).Error
 	if err != nil {
 		return nil, err
 	}
@@ -34,7 +44,7 @@ func (r *projectRepository) FindProjectByID(id uuid.UUID) (*entities.Project, er
 // FindProjectsByUserID retrieves all projects for a user
 func (r *projectRepository) FindProjectsByUserID(userID uuid.UUID) ([]*entities.Project, error) {
 	var projects []*entities.Project
-	err := r.db.Preload("TechStack.Category").Preload("Tasks.Task").Where("user_id = ?", userID).Find(&projects).Error
+	err := preloadSubtasks(r.db, "Tasks.Task.").Preload("TechStack.Category").Preload("WIPLimits").Preload("Tasks", liveProjectTasks).Preload("Tasks.Task").Where("user_id = ?", userID).Find(&projects).Error
 	if err != nil {
 		return nil, err
 	}
@@ -43,7 +53,7 @@ func (r *projectRepository) FindProjectsByUserID(userID uuid.UUID) ([]*entities.
 
 // FindProjectsByUserIDAndFilters retrieves projects with filters
 func (r *projectRepository) FindProjectsByUserIDAndFilters(userID uuid.UUID, status string, techStackIDs []uuid.UUID) ([]*entities.Project, error) {
-	query := r.db.Preload("TechStack.Category").Preload("Tasks.Task").Where("user_id = ?", userID)
+	query := preloadSubtasks(r.db, "Tasks.Task.").Preload("TechStack.Category").Preload("WIPLimits").Preload("Tasks", liveProjectTasks).Preload("Tasks.Task").Where("user_id = ?", userID)
 
 	// Apply status filter
 	if status != "" {
@@ -86,11 +96,92 @@ func (r *projectRepository) UpdateProject(project *entities.Project) error {
 	})
 }
 
-// DeleteProject deletes a project
+// ReplaceWIPLimits replaces the per-status WIP limits of a project
+func (r *projectRepository) ReplaceWIPLimits(projectID uuid.UUID, limits []*entities.ProjectWIPLimit) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("project_id = ?", projectID).Delete(&entities.ProjectWIPLimit{}).Error; err != nil {
+			return err
+		}
+		if len(limits) == 0 {
+			return nil
+		}
+		return tx.Create(&limits).Error
+	})
+}
+
+// DeleteProject moves a project to the trash (task assignments and tech stack are kept for restoring)
 func (r *projectRepository) DeleteProject(id uuid.UUID) error {
 	return r.db.Delete(&entities.Project{}, id).Error
 }
 
+// FindDeletedProjectsByUserID retrieves the trashed projects of a user (most recently deleted first)
+func (r *projectRepository) FindDeletedProjectsByUserID(userID uuid.UUID) ([]*entities.Project, error) {
+	var projects []*entities.Project
+	err := r.db.Unscoped().Preload("TechStack.Category").Preload("WIPLimits").Preload("Tasks", liveProjectTasks).Preload("Tasks.Task").
+		Where("user_id = ? AND deleted_at IS NOT NULL", userID).
+		Order("deleted_at DESC").
+		Find(&projects).Error
+	if err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+// FindDeletedProjectByID retrieves a project in the trash by ID
+func (r *projectRepository) FindDeletedProjectByID(id uuid.UUID) (*entities.Project, error) {
+	var project entities.Project
+	err := r.db.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(&project).Error
+	if err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+// RestoreProject takes a project out of the trash
+func (r *projectRepository) RestoreProject(id uuid.UUID) error {
+	return r.db.Unscoped().Model(&entities.Project{}).Where("id = ?", id).Update("deleted_at", nil).Error
+}
+
+// PurgeProject permanently deletes a project with its task assignments and tech stack links
+func (r *projectRepository) PurgeProject(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		_, err := purgeProjects(tx, `SELECT ?::uuid`, id)
+		return err
+	})
+}
+
+// PurgeDeletedProjects permanently deletes the projects that were moved to the trash before a time
+func (r *projectRepository) PurgeDeletedProjects(before time.Time) (int64, error) {
+	var purged int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		purged, err = purgeProjects(tx, `SELECT id FROM projects WHERE deleted_at < ?`, before)
+		return err
+	})
+	return purged, err
+}
+
+// purgeProjects permanently deletes the projects selected by a subquery with their links and board settings
+func purgeProjects(tx *gorm.DB, ids string, args ...any) (int64, error) {
+	if err := tx.Exec(`DELETE FROM project_tasks WHERE project_id IN (`+ids+`)`, args...).Error; err != nil {
+		return 0, err
+	}
+	if err := tx.Exec(`DELETE FROM project_tech_stack WHERE project_id IN (`+ids+`)`, args...).Error; err != nil {
+		return 0, err
+	}
+	if err := tx.Exec(`DELETE FROM project_wip_limits WHERE project_id IN (`+ids+`)`, args...).Error; err != nil {
+		return 0, err
+	}
+	err := tx.Exec(`DELETE FROM task_ranks WHERE list = ? AND list_key IN (SELECT id::text FROM (`+ids+`) AS purged(id))`,
+		append([]any{entities.TaskListProject}, args...)...).Error
+	if err != nil {
+		return 0, err
+	}
+
+	result := tx.Exec(`DELETE FROM projects WHERE id IN (`+ids+`)`, args...)
+	return result.RowsAffected, result.Error
+}
+
 // AssignTask assigns a task to a project
 func (r *projectRepository) AssignTask(projectTask *entities.ProjectTask) error {
 	return r.db.Create(projectTask).Error
@@ -104,7 +195,22 @@ func (r *projectRepository) UnassignTask(projectID, taskID uuid.UUID) error {
 // FindProjectTasks retrieves all tasks assigned to a project
 func (r *projectRepository) FindProjectTasks(projectID uuid.UUID) ([]*entities.ProjectTask, error) {
 	var projectTasks []*entities.ProjectTask
-	err := r.db.Preload("Task").Where("project_id = ?", projectID).Order("assigned_at DESC").Find(&projectTasks).Error
+	err := preloadSubtasks(r.db, "Task.").Preload("Task").Preload("Task.Tags").Where("project_id = ?", projectID).Scopes(liveProjectTasks).Order("assigned_at DESC").Find(&projectTasks).Error
+	if err != nil {
+		return nil, err
+	}
+	return projectTasks, nil
+}
+
+// FindProjectTasksByTaskIDs retrieves the project assignments of tasks (to projects that are not in the trash)
+func (r *projectRepository) FindProjectTasksByTaskIDs(taskIDs []uuid.UUID) ([]*entities.ProjectTask, error) {
+	var projectTasks []*entities.ProjectTask
+	if len(taskIDs) == 0 {
+		return projectTasks, nil
+	}
+
+	err := r.db.Preload("Project.WIPLimits").Where("task_id IN ?", taskIDs).Where("project_id IN (" + liveProjects + ")").
+		Order("assigned_at ASC").Find(&projectTasks).Error
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,47 @@
+package postgres
+
+import (
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type pushSubscriptionRepository struct {
+	db *gorm.DB
+}
+
+// NewPushSubscriptionRepository creates a new push subscription repository
+func NewPushSubscriptionRepository(db *gorm.DB) interfaces.PushSubscriptionRepository {
+	return &pushSubscriptionRepository{db: db}
+}
+
+// SaveSubscription creates a subscription or refreshes the keys of an existing endpoint
+func (r *pushSubscriptionRepository) SaveSubscription(subscription *entities.PushSubscription) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "endpoint"}},
+		DoUpdates: clause.AssignmentColumns([]string{"user_id", "p256dh", "auth"}),
+	}).Create(subscription).Error
+}
+
+// FindSubscriptionsByUserID retrieves all subscriptions of a user
+func (r *pushSubscriptionRepository) FindSubscriptionsByUserID(userID uuid.UUID) ([]*entities.PushSubscription, error) {
+	var subscriptions []*entities.PushSubscription
+	err := r.db.Where("user_id = ?", userID).Order("created_at ASC").Find(&subscriptions).Error
+	if err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+// DeleteSubscriptionByEndpoint deletes a subscription of a user by its endpoint
+func (r *pushSubscriptionRepository) DeleteSubscriptionByEndpoint(userID uuid.UUID, endpoint string) error {
+	return r.db.Where("user_id = ? AND endpoint = ?", userID, endpoint).Delete(&entities.PushSubscription{}).Error
+}
+
+// DeleteSubscription deletes a subscription
+func (r *pushSubscriptionRepository) DeleteSubscription(id uuid.UUID) error {
+	return r.db.Delete(&entities.PushSubscription{}, id).Error
+}
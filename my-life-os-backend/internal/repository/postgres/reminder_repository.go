@@ -0,0 +1,154 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type reminderRepository struct {
+	db *gorm.DB
+}
+
+// NewReminderRepository creates a new reminder repository
+func NewReminderRepository(db *gorm.DB) interfaces.ReminderRepository {
+	return &reminderRepository{db: db}
+}
+
+// CreateRule creates a new reminder rule
+func (r *reminderRepository) CreateRule(rule *entities.ReminderRule) error {
+	return r.db.Create(rule).Error
+}
+
+// FindRuleByID retrieves a reminder rule by ID
+func (r *reminderRepository) FindRuleByID(id uuid.UUID) (*entities.ReminderRule, error) {
+	var rule entities.ReminderRule
+	err := r.db.Where("id = ?", id).First(&rule).Error
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// FindRulesByUserID retrieves the reminder rules of a user, optionally for a single entity
+func (r *reminderRepository) FindRulesByUserID(userID uuid.UUID, entityType *string, entityID *uuid.UUID) ([]*entities.ReminderRule, error) {
+	var rules []*entities.ReminderRule
+	query := r.db.Where("user_id = ?", userID)
+
+	if entityType != nil {
+		query = query.Where("entity_type = ?", *entityType)
+	}
+	if entityID != nil {
+		query = query.Where("entity_id = ?", *entityID)
+	}
+
+	err := query.Order("created_at ASC").Find(&rules).Error
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// FindAllRules retrieves the reminder rules of all users
+func (r *reminderRepository) FindAllRules() ([]*entities.ReminderRule, error) {
+	var rules []*entities.ReminderRule
+	err := r.db.Order("created_at ASC").Find(&rules).Error
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// DeleteRule deletes a reminder rule and its pending deliveries (sent ones are kept as history)
+func (r *reminderRepository) DeleteRule(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("rule_id = ? AND status = ?", id, entities.DeliveryStatusPending).
+			Delete(&entities.ReminderDelivery{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&entities.ReminderRule{}, id).Error
+	})
+}
+
+// CreateDelivery creates a delivery, a delivery for the same rule and due time is kept as is
+func (r *reminderRepository) CreateDelivery(delivery *entities.ReminderDelivery) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(delivery).Error
+}
+
+// ClaimDueDeliveries locks due deliveries, counts the attempt and postpones them by the lease
+func (r *reminderRepository) ClaimDueDeliveries(now time.Time, lease time.Duration, limit int) ([]*entities.ReminderDelivery, error) {
+	var deliveries []*entities.ReminderDelivery
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// SKIP LOCKED lets several instances share the queue without sending twice
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", entities.DeliveryStatusPending, now).
+			Order("next_attempt_at ASC").
+			Limit(limit).
+			Find(&deliveries).Error
+		if err != nil || len(deliveries) == 0 {
+			return err
+		}
+
+		ids := make([]uuid.UUID, len(deliveries))
+		for i, delivery := range deliveries {
+			ids[i] = delivery.ID
+			delivery.Attempts++
+			delivery.NextAttemptAt = now.Add(lease)
+		}
+
+		return tx.Model(&entities.ReminderDelivery{}).
+			Where("id IN ?", ids).
+			Updates(map[string]any{
+				"attempts":        gorm.Expr("attempts + 1"),
+				"next_attempt_at": now.Add(lease),
+			}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// MarkDeliverySent marks a delivery as sent
+func (r *reminderRepository) MarkDeliverySent(id uuid.UUID, sentAt time.Time) error {
+	return r.db.Model(&entities.ReminderDelivery{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"status":     entities.DeliveryStatusSent,
+			"sent_at":    sentAt,
+			"last_error": nil,
+		}).Error
+}
+
+// MarkDeliveryAttemptFailed records a failed attempt, without a next attempt the delivery is given up
+func (r *reminderRepository) MarkDeliveryAttemptFailed(id uuid.UUID, lastError string, nextAttemptAt *time.Time) error {
+	updates := map[string]any{
+		"last_error": lastError,
+	}
+	if nextAttemptAt != nil {
+		updates["next_attempt_at"] = *nextAttemptAt
+	} else {
+		updates["status"] = entities.DeliveryStatusFailed
+	}
+
+	return r.db.Model(&entities.ReminderDelivery{}).Where("id = ?", id).Updates(updates).Error
+}
+
+// FindDeliveriesByUserID retrieves the most recent deliveries of a user
+func (r *reminderRepository) FindDeliveriesByUserID(userID uuid.UUID, limit int) ([]*entities.ReminderDelivery, error) {
+	var deliveries []*entities.ReminderDelivery
+	err := r.db.Where("user_id = ?", userID).
+		Order("remind_at DESC").
+		Limit(limit).
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
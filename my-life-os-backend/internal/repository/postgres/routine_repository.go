@@ -59,11 +59,67 @@ func (r *routineRepository) UpdateRoutine(routine *entities.Routine) error {
 	return r.db.Save(routine).Error
 }
 
-// DeleteRoutine deletes a routine
+// DeleteRoutine moves a routine to the trash (completions are kept for restoring)
 func (r *routineRepository) DeleteRoutine(id uuid.UUID) error {
 	return r.db.Delete(&entities.Routine{}, id).Error
 }
 
+// GetDeletedRoutinesByUserID retrieves the trashed routines of a user (most recently deleted first)
+func (r *routineRepository) GetDeletedRoutinesByUserID(userID uuid.UUID) ([]*entities.Routine, error) {
+	var routines []*entities.Routine
+	err := r.db.Unscoped().Where("user_id = ? AND deleted_at IS NOT NULL", userID).
+		Order("deleted_at DESC").
+		Find(&routines).Error
+	if err != nil {
+		return nil, err
+	}
+	return routines, nil
+}
+
+// GetDeletedRoutineByID retrieves a routine in the trash by ID
+func (r *routineRepository) GetDeletedRoutineByID(id uuid.UUID) (*entities.Routine, error) {
+	var routine entities.Routine
+	err := r.db.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(&routine).Error
+	if err != nil {
+		return nil, err
+	}
+	return &routine, nil
+}
+
+// RestoreRoutine takes a routine out of the trash
+func (r *routineRepository) RestoreRoutine(id uuid.UUID) error {
+	return r.db.Unscoped().Model(&entities.Routine{}).Where("id = ?", id).Update("deleted_at", nil).Error
+}
+
+// PurgeRoutine permanently deletes a routine with its completions
+func (r *routineRepository) PurgeRoutine(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		_, err := purgeRoutines(tx, `SELECT ?::uuid`, id)
+		return err
+	})
+}
+
+// PurgeDeletedRoutines permanently deletes the routines that were moved to the trash before a time
+func (r *routineRepository) PurgeDeletedRoutines(before time.Time) (int64, error) {
+	var purged int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		purged, err = purgeRoutines(tx, `SELECT id FROM routines WHERE deleted_at < ?`, before)
+		return err
+	})
+	return purged, err
+}
+
+// purgeRoutines permanently deletes the routines selected by a subquery and their completions
+func purgeRoutines(tx *gorm.DB, ids string, args ...any) (int64, error) {
+	if err := tx.Exec(`DELETE FROM routine_completions WHERE routine_id IN (`+ids+`)`, args...).Error; err != nil {
+		return 0, err
+	}
+
+	result := tx.Exec(`DELETE FROM routines WHERE id IN (`+ids+`)`, args...)
+	return result.RowsAffected, result.Error
+}
+
 // UpdateStreak updates the streak counters for a routine
 func (r *routineRepository) UpdateStreak(id uuid.UUID, currentStreak, longestStreak int) error {
 	return r.db.Model(&entities.Routine{}).
@@ -107,3 +163,65 @@ func (r *routineRepository) GetCompletionHistory(routineID uuid.UUID, limit int)
 
 	return completions, nil
 }
+
+// GetCompletionByID retrieves a completion/skip event by ID
+func (r *routineRepository) GetCompletionByID(id uuid.UUID) (*entities.RoutineCompletion, error) {
+	var completion entities.RoutineCompletion
+	err := r.db.Where("id = ?", id).First(&completion).Error
+	if err != nil {
+		return nil, err
+	}
+	return &completion, nil
+}
+
+// GetCompletionsInRange retrieves the completions of a routine between two dates (inclusive, oldest first)
+func (r *routineRepository) GetCompletionsInRange(routineID uuid.UUID, from, to time.Time) ([]*entities.RoutineCompletion, error) {
+	var completions []*entities.RoutineCompletion
+
+	err := r.db.Where("routine_id = ? AND completed_at BETWEEN ? AND ?", routineID, from, to).
+		Order("completed_at ASC").
+		Find(&completions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return completions, nil
+}
+
+// GetCompletionsByUserIDInRange retrieves the completions of all routines of a user between two dates (inclusive, oldest first)
+func (r *routineRepository) GetCompletionsByUserIDInRange(userID uuid.UUID, from, to time.Time) ([]*entities.RoutineCompletion, error) {
+	var completions []*entities.RoutineCompletion
+
+	err := r.db.Where("user_id = ? AND completed_at BETWEEN ? AND ?", userID, from, to).
+		Order("completed_at ASC").
+		Find(&completions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return completions, nil
+}
+
+// GetAllCompletions retrieves all completions of a routine (oldest first)
+func (r *routineRepository) GetAllCompletions(routineID uuid.UUID) ([]*entities.RoutineCompletion, error) {
+	var completions []*entities.RoutineCompletion
+
+	err := r.db.Where("routine_id = ?", routineID).
+		Order("completed_at ASC").
+		Find(&completions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return completions, nil
+}
+
+// UpdateCompletion updates the date or status of a completion/skip event
+func (r *routineRepository) UpdateCompletion(completion *entities.RoutineCompletion) error {
+	return r.db.Save(completion).Error
+}
+
+// DeleteCompletion deletes a completion/skip event
+func (r *routineRepository) DeleteCompletion(id uuid.UUID) error {
+	return r.db.Delete(&entities.RoutineCompletion{}, id).Error
+}
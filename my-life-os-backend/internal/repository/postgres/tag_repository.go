@@ -0,0 +1,162 @@
+package postgres
+
+import (
+	"strings"
+	"time"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type tagRepository struct {
+	db *gorm.DB
+}
+
+// NewTagRepository creates a new tag repository
+func NewTagRepository(db *gorm.DB) interfaces.TagRepository {
+	return &tagRepository{db: db}
+}
+
+// CreateTagsIfNotExist creates tags, names the user already has are skipped
+func (r *tagRepository) CreateTagsIfNotExist(tags []*entities.Tag) error {
+	if len(tags) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&tags).Error
+}
+
+// FindTagByID retrieves a tag by ID
+func (r *tagRepository) FindTagByID(id uuid.UUID) (*entities.Tag, error) {
+	var tag entities.Tag
+	err := r.db.Where("id = ?", id).First(&tag).Error
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// FindTagByName retrieves a tag of a user by name
+func (r *tagRepository) FindTagByName(userID uuid.UUID, name string) (*entities.Tag, error) {
+	var tag entities.Tag
+	err := r.db.Where("user_id = ? AND name = ?", userID, name).First(&tag).Error
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// FindTagsByNames retrieves the tags of a user with the given names
+func (r *tagRepository) FindTagsByNames(userID uuid.UUID, names []string) ([]*entities.Tag, error) {
+	var tags []*entities.Tag
+	if len(names) == 0 {
+		return tags, nil
+	}
+	err := r.db.Where("user_id = ? AND name IN ?", userID, names).Order("name ASC").Find(&tags).Error
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// FindTagUsage retrieves the tags of a user starting with prefix, ordered by usage (for autocomplete)
+func (r *tagRepository) FindTagUsage(userID uuid.UUID, prefix string, limit int) ([]*interfaces.TagUsage, error) {
+	var usage []*interfaces.TagUsage
+
+	// Escape LIKE wildcards in the typed prefix
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(prefix)
+
+	query := r.db.Table("tags").
+		Select("tags.*, COUNT(task_tags.task_id) AS usage_count").
+		Joins("LEFT JOIN task_tags ON task_tags.tag_id = tags.id AND task_tags.task_id IN ("+liveTasks+")").
+		Where("tags.user_id = ? AND tags.name LIKE ?", userID, escaped+"%").
+		Group("tags.id").
+		Order("usage_count DESC").
+		Order("tags.name ASC")
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	err := query.Scan(&usage).Error
+	if err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
+
+// SetTaskTags replaces the tags of a task
+func (r *tagRepository) SetTaskTags(taskID uuid.UUID, tagIDs []uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("task_id = ?", taskID).Delete(&entities.TaskTag{}).Error; err != nil {
+			return err
+		}
+		if len(tagIDs) == 0 {
+			return nil
+		}
+
+		links := make([]*entities.TaskTag, len(tagIDs))
+		for i, tagID := range tagIDs {
+			links[i] = &entities.TaskTag{TaskID: taskID, TagID: tagID}
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&links).Error
+	})
+}
+
+// RenameTag saves a renamed tag and touches the tasks using it
+func (r *tagRepository) RenameTag(tag *entities.Tag) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(tag).Error; err != nil {
+			return err
+		}
+		return touchTaggedTasks(tx, tag.ID)
+	})
+}
+
+// MergeTags moves the tasks of the source tag to the target and deletes the source
+func (r *tagRepository) MergeTags(source, target *entities.Tag) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := touchTaggedTasks(tx, source.ID); err != nil {
+			return err
+		}
+
+		// Tasks having both tags keep a single link
+		err := tx.Exec(`INSERT INTO task_tags (task_id, tag_id)
+			SELECT task_id, ? FROM task_tags WHERE tag_id = ?
+			ON CONFLICT DO NOTHING`, target.ID, source.ID).Error
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Where("tag_id = ?", source.ID).Delete(&entities.TaskTag{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&entities.Tag{}).Where("id = ?", target.ID).Update("updated_at", time.Now()).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&entities.Tag{}, source.ID).Error
+	})
+}
+
+// DeleteTag removes a tag from all tasks and deletes it
+func (r *tagRepository) DeleteTag(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := touchTaggedTasks(tx, id); err != nil {
+			return err
+		}
+		if err := tx.Where("tag_id = ?", id).Delete(&entities.TaskTag{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&entities.Tag{}, id).Error
+	})
+}
+
+// touchTaggedTasks sets UpdatedAt of all tasks using a tag (their tags changed)
+func touchTaggedTasks(tx *gorm.DB, tagID uuid.UUID) error {
+	return tx.Model(&entities.Task{}).
+		Where("id IN (SELECT task_id FROM task_tags WHERE tag_id = ?)", tagID).
+		Update("updated_at", time.Now()).Error
+}
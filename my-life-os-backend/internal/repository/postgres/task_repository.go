@@ -1,11 +1,15 @@
 package postgres
 
 import (
+	"strings"
+	"time"
+
 	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
 	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type taskRepository struct {
@@ -25,18 +29,41 @@ func (r *taskRepository) CreateTask(task *entities.Task) error {
 // FindByID retrieves a task by ID
 func (r *taskRepository) FindTaskByID(id uuid.UUID) (*entities.Task, error) {
 	var task entities.Task
-	err := r.db.Where("id = ?", id).First(&task).Error
+	err := r.db.Preload("Tags").Where("id = ?", id).First(&task).Error
+	if err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// FindTaskWithSubtasks retrieves a task with its nested subtasks (ordered by position)
+func (r *taskRepository) FindTaskWithSubtasks(id uuid.UUID) (*entities.Task, error) {
+	var task entities.Task
+	err := preloadSubtasks(r.db.Preload("Tags"), "").Where("id = ?", id).First(&task).Error
 	if err != nil {
 		return nil, err
 	}
 	return &task, nil
 }
 
+// FindSubtasks retrieves the direct subtasks of a task (ordered by position)
+func (r *taskRepository) FindSubtasks(parentTaskID uuid.UUID) ([]*entities.Task, error) {
+	var tasks []*entities.Task
+	err := r.db.Where("parent_task_id = ?", parentTaskID).
+		Order("position ASC").
+		Order("created_at ASC").
+		Find(&tasks).Error
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
 // FindByUserID retrieves all tasks for a user
 func (r *taskRepository) FindTasksByUserID(userID uuid.UUID) ([]*entities.Task, error) {
 	var tasks []*entities.Task
 	// Order by deadline ascending (closest first), NULL deadlines last
-	err := r.db.Where("user_id = ?", userID).
+	err := r.db.Preload("Tags").Where("user_id = ?", userID).
 		Order("CASE WHEN deadline IS NULL THEN 1 ELSE 0 END"). // NULLs last
 		Order("deadline ASC").                                 // Closest deadline first
 		Order("created_at DESC").                              // Then by creation date
@@ -47,34 +74,554 @@ func (r *taskRepository) FindTasksByUserID(userID uuid.UUID) ([]*entities.Task,
 	return tasks, nil
 }
 
-// FindByUserIDAndFilters retrieves tasks with filters
-func (r *taskRepository) FindTasksByUserIDAndFilters(userID uuid.UUID, filters map[string]interface{}) ([]*entities.Task, error) {
+// FindTasksByUserIDAndFilters retrieves the filtered tasks of a user page by page (keyset pagination)
+func (r *taskRepository) FindTasksByUserIDAndFilters(userID uuid.UUID, filter interfaces.TaskFilter, sort []interfaces.TaskSort, cursor *interfaces.TaskCursor, limit int) ([]*entities.Task, error) {
 	var tasks []*entities.Task
-	query := r.db.Where("user_id = ?", userID)
+	query := applyTaskFilter(r.db.Preload("Tags").Where("user_id = ?", userID), filter)
+
+	// The ID makes the order total, so pages neither skip nor repeat tasks
+	keys := make([]taskSortKey, 0, len(sort)+1)
+	for _, s := range sort {
+		keys = append(keys, newTaskSortKey(s))
+	}
+	keys = append(keys, taskSortKey{expr: "id", value: func(c *interfaces.TaskCursor) any { return c.ID }})
 
-	// Apply filters
-	for key, value := range filters {
-		query = query.Where(key+" = ?", value)
+	// Continue after the cursor: (k1 > v1) OR (k1 = v1 AND k2 > v2) OR ...
+	if cursor != nil {
+		var conditions []string
+		var args []any
+		for i, key := range keys {
+			var parts []string
+			for _, previous := range keys[:i] {
+				parts = append(parts, previous.expr+" = ?")
+				args = append(args, previous.value(cursor))
+			}
+			op := " > ?"
+			if key.desc {
+				op = " < ?"
+			}
+			parts = append(parts, key.expr+op)
+			args = append(args, key.value(cursor))
+			conditions = append(conditions, "("+strings.Join(parts, " AND ")+")")
+		}
+		query = query.Where("("+strings.Join(conditions, " OR ")+")", args...)
 	}
 
-	err := query.Order("created_at DESC").Find(&tasks).Error
+	for _, key := range keys {
+		if key.desc {
+			query = query.Order(key.expr + " DESC")
+		} else {
+			query = query.Order(key.expr + " ASC")
+		}
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	err := query.Find(&tasks).Error
 	if err != nil {
 		return nil, err
 	}
 	return tasks, nil
 }
 
-// Update updates a task
+// CountTasksByUserIDAndFilters counts the filtered tasks of a user in total and per domain and status
+func (r *taskRepository) CountTasksByUserIDAndFilters(userID uuid.UUID, filter interfaces.TaskFilter) (*interfaces.TaskCounts, error) {
+	counts := &interfaces.TaskCounts{
+		Domains:  make(map[string]int64),
+		Statuses: make(map[string]int64),
+	}
+
+	err := applyTaskFilter(r.db.Model(&entities.Task{}).Where("user_id = ?", userID), filter).Count(&counts.Total).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// Each facet ignores its own filter, so the counts show what selecting it would return
+	byDomain := filter
+	byDomain.Domain = ""
+	if err := r.countTasksBy(userID, byDomain, "domain", counts.Domains); err != nil {
+		return nil, err
+	}
+
+	byStatus := filter
+	byStatus.Status = ""
+	if err := r.countTasksBy(userID, byStatus, "status", counts.Statuses); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+// countTasksBy counts the filtered tasks of a user grouped by a column
+func (r *taskRepository) countTasksBy(userID uuid.UUID, filter interfaces.TaskFilter, column string, counts map[string]int64) error {
+	var rows []struct {
+		Value string
+		Count int64
+	}
+
+	err := applyTaskFilter(r.db.Model(&entities.Task{}).Where("user_id = ?", userID), filter).
+		Select(column + " AS value, COUNT(*) AS count").
+		Group(column).
+		Scan(&rows).Error
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		counts[row.Value] = row.Count
+	}
+	return nil
+}
+
+// Update updates a task (loaded subtasks are not saved)
 func (r *taskRepository) UpdateTask(task *entities.Task) error {
-	return r.db.Save(task).Error
+	return r.db.Omit(clause.Associations).Save(task).Error
 }
 
-// UpdateStatus updates only the task status
-func (r *taskRepository) UpdateStatus(id uuid.UUID, status string) error {
-	return r.db.Model(&entities.Task{}).Where("id = ?", id).Update("status", status).Error
+// UpdateTaskStatuses stores the status and workflow timestamps of tasks together with their status changes
+func (r *taskRepository) UpdateTaskStatuses(tasks []*entities.Task, changes []*entities.TaskStatusChange) error {
+	if len(tasks) == 0 && len(changes) == 0 {
+		return nil
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, task := range tasks {
+			err := tx.Model(&entities.Task{}).Where("id = ?", task.ID).Updates(map[string]any{
+				"status":       task.Status,
+				"started_at":   task.StartedAt,
+				"completed_at": task.CompletedAt,
+				"updated_at":   task.UpdatedAt,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+		if len(changes) == 0 {
+			return nil
+		}
+		return tx.Create(&changes).Error
+	})
 }
 
-// Delete deletes a task
+// FindTaskStatusChanges retrieves the status history of a task (oldest first)
+func (r *taskRepository) FindTaskStatusChanges(taskID uuid.UUID) ([]*entities.TaskStatusChange, error) {
+	var changes []*entities.TaskStatusChange
+	err := r.db.Where("task_id = ?", taskID).Order("changed_at ASC").Find(&changes).Error
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// UpdatePositions stores the order of subtasks (position = index in ids)
+func (r *taskRepository) UpdatePositions(ids []uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for i, id := range ids {
+			if err := tx.Model(&entities.Task{}).Where("id = ?", id).Update("position", i).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// FindTaskRanks retrieves the ranks of a user's tasks in all lists of a context
+func (r *taskRepository) FindTaskRanks(userID uuid.UUID, list string) ([]*entities.TaskRank, error) {
+	var ranks []*entities.TaskRank
+	err := r.db.Where("user_id = ? AND list = ?", userID, list).Find(&ranks).Error
+	if err != nil {
+		return nil, err
+	}
+	return ranks, nil
+}
+
+// SaveTaskRanks creates or replaces the ranks of tasks in lists (one statement for all ranks)
+func (r *taskRepository) SaveTaskRanks(ranks []*entities.TaskRank) error {
+	if len(ranks) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "task_id"}, {Name: "list"}, {Name: "list_key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"rank"}),
+	}).Create(&ranks).Error
+}
+
+// taskTree selects the IDs of a task and all of its subtasks (in the trash or not)
+const taskTree = `WITH RECURSIVE tree AS (
+		SELECT id FROM tasks WHERE id = ?
+		UNION ALL
+		SELECT t.id FROM tasks t JOIN tree ON t.parent_task_id = tree.id
+	)
+	SELECT id FROM tree`
+
+// liveTasks selects the IDs of tasks that are not in the trash (for subqueries GORM does not scope)
+const liveTasks = `SELECT id FROM tasks WHERE deleted_at IS NULL`
+
+// DeleteTask moves a task together with its subtasks to the trash (links are kept for restoring)
+// and stops timers running on them
 func (r *taskRepository) DeleteTask(id uuid.UUID) error {
-	return r.db.Delete(&entities.Task{}, id).Error
+	now := time.Now()
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(`UPDATE time_entries SET ended_at = ?, updated_at = ? WHERE ended_at IS NULL AND task_id IN (`+taskTree+`)`,
+			now, now, id).Error
+		if err != nil {
+			return err
+		}
+		return tx.Exec(`UPDATE tasks SET deleted_at = ? WHERE deleted_at IS NULL AND id IN (`+taskTree+`)`, now, id).Error
+	})
+}
+
+// FindDeletedTasksByUserID retrieves the trashed tasks of a user that were deleted themselves,
+// not together with their parent (most recently deleted first)
+func (r *taskRepository) FindDeletedTasksByUserID(userID uuid.UUID) ([]*entities.Task, error) {
+	var tasks []*entities.Task
+	err := r.db.Unscoped().Preload("Tags").
+		Where("user_id = ? AND deleted_at IS NOT NULL", userID).
+		Where(`NOT EXISTS (SELECT 1 FROM tasks parents
+			WHERE parents.id = tasks.parent_task_id AND parents.deleted_at = tasks.deleted_at)`).
+		Order("deleted_at DESC").
+		Find(&tasks).Error
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// FindDeletedTaskByID retrieves a task in the trash by ID
+func (r *taskRepository) FindDeletedTaskByID(id uuid.UUID) (*entities.Task, error) {
+	var task entities.Task
+	err := r.db.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(&task).Error
+	if err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// RestoreTask takes a task out of the trash together with the subtasks deleted with it
+func (r *taskRepository) RestoreTask(task *entities.Task) error {
+	return r.db.Exec(`UPDATE tasks SET deleted_at = NULL WHERE deleted_at = ? AND id IN (`+taskTree+`)`,
+		task.DeletedAt.Time, task.ID).Error
+}
+
+// PurgeTask permanently deletes a task together with all of its subtasks and their links
+func (r *taskRepository) PurgeTask(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		_, err := purgeTasks(tx, taskTree, id)
+		return err
+	})
+}
+
+// PurgeDeletedTasks permanently deletes the tasks that were moved to the trash before a time
+// (their subtasks were trashed with them or earlier)
+func (r *taskRepository) PurgeDeletedTasks(before time.Time) (int64, error) {
+	var purged int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		purged, err = purgeTasks(tx, `SELECT id FROM tasks WHERE deleted_at < ?`, before)
+		return err
+	})
+	return purged, err
+}
+
+// purgeTasks permanently deletes the tasks selected by a subquery and everything linked to them
+func purgeTasks(tx *gorm.DB, ids string, args ...any) (int64, error) {
+	links := []string{
+		`DELETE FROM task_tags WHERE task_id IN (` + ids + `)`,
+		`DELETE FROM time_entries WHERE task_id IN (` + ids + `)`,
+		`DELETE FROM task_status_changes WHERE task_id IN (` + ids + `)`,
+		`DELETE FROM project_tasks WHERE task_id IN (` + ids + `)`,
+		`DELETE FROM task_ranks WHERE task_id IN (` + ids + `)`,
+	}
+	for _, link := range links {
+		if err := tx.Exec(link, args...).Error; err != nil {
+			return 0, err
+		}
+	}
+	err := tx.Exec(`DELETE FROM task_dependencies WHERE task_id IN (`+ids+`) OR blocked_by_id IN (`+ids+`)`,
+		append(args, args...)...).Error
+	if err != nil {
+		return 0, err
+	}
+
+	result := tx.Exec(`DELETE FROM tasks WHERE id IN (`+ids+`)`, args...)
+	return result.RowsAffected, result.Error
+}
+
+// CreateTaskSeries creates a new recurring task series
+func (r *taskRepository) CreateTaskSeries(series *entities.TaskSeries) error {
+	return r.db.Create(series).Error
+}
+
+// FindTaskSeriesByID retrieves a series by ID
+func (r *taskRepository) FindTaskSeriesByID(id uuid.UUID) (*entities.TaskSeries, error) {
+	var series entities.TaskSeries
+	err := r.db.Where("id = ?", id).First(&series).Error
+	if err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
+
+// FindTaskSeriesWithTasks retrieves a series with all of its instances (ordered by deadline)
+func (r *taskRepository) FindTaskSeriesWithTasks(id uuid.UUID) (*entities.TaskSeries, error) {
+	var series entities.TaskSeries
+	err := r.db.Preload("Tasks", func(db *gorm.DB) *gorm.DB {
+		return db.Order("deadline ASC").Order("created_at ASC")
+	}).Where("id = ?", id).First(&series).Error
+	if err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
+
+// FindOpenSeriesTasks retrieves the instances of a series that are not done or cancelled yet
+func (r *taskRepository) FindOpenSeriesTasks(seriesID uuid.UUID) ([]*entities.Task, error) {
+	var tasks []*entities.Task
+	err := r.db.Preload("Tags").Where("series_id = ? AND status NOT IN ?", seriesID, entities.TerminalTaskStatuses).
+		Order("deadline ASC").
+		Find(&tasks).Error
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// UpdateTaskSeries updates a series (loaded instances are not saved)
+func (r *taskRepository) UpdateTaskSeries(series *entities.TaskSeries) error {
+	return r.db.Omit(clause.Associations).Save(series).Error
+}
+
+// FindTasksByIDs retrieves several tasks by ID
+func (r *taskRepository) FindTasksByIDs(ids []uuid.UUID) ([]*entities.Task, error) {
+	var tasks []*entities.Task
+	if len(ids) == 0 {
+		return tasks, nil
+	}
+
+	err := r.db.Preload("Tags").Where("id IN ?", ids).Order("created_at ASC").Find(&tasks).Error
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// CreateTaskDependency creates a dependency if check accepts the dependencies of the user
+// (does nothing if it already exists)
+func (r *taskRepository) CreateTaskDependency(userID uuid.UUID, dependency *entities.TaskDependency, check func(dependencies []*entities.TaskDependency) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Locking the user row serializes concurrent additions, so two dependencies cannot close a cycle together
+		var user entities.User
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").
+			First(&user, "id = ?", userID).Error
+		if err != nil {
+			return err
+		}
+
+		dependencies, err := findTaskDependenciesByUserID(tx, userID)
+		if err != nil {
+			return err
+		}
+		if err := check(dependencies); err != nil {
+			return err
+		}
+
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(dependency).Error
+	})
+}
+
+// DeleteTaskDependency deletes a dependency
+func (r *taskRepository) DeleteTaskDependency(taskID, blockedByID uuid.UUID) error {
+	return r.db.Where("task_id = ? AND blocked_by_id = ?", taskID, blockedByID).Delete(&entities.TaskDependency{}).Error
+}
+
+// FindTaskDependenciesByUserID retrieves all dependencies between the tasks of a user
+// (trashed tasks included, so restoring them cannot create a cycle)
+func (r *taskRepository) FindTaskDependenciesByUserID(userID uuid.UUID) ([]*entities.TaskDependency, error) {
+	return findTaskDependenciesByUserID(r.db, userID)
+}
+
+// findTaskDependenciesByUserID retrieves the dependencies of a user (within a transaction or not)
+func findTaskDependenciesByUserID(db *gorm.DB, userID uuid.UUID) ([]*entities.TaskDependency, error) {
+	var dependencies []*entities.TaskDependency
+	err := db.Joins("JOIN tasks ON tasks.id = task_dependencies.task_id").
+		Where("tasks.user_id = ?", userID).
+		Find(&dependencies).Error
+	if err != nil {
+		return nil, err
+	}
+	return dependencies, nil
+}
+
+// FindTaskDependencies retrieves the dependencies touching any of the tasks (without trashed tasks)
+func (r *taskRepository) FindTaskDependencies(taskIDs []uuid.UUID) ([]*entities.TaskDependency, error) {
+	var dependencies []*entities.TaskDependency
+	if len(taskIDs) == 0 {
+		return dependencies, nil
+	}
+
+	err := r.db.Where("task_id IN ? OR blocked_by_id IN ?", taskIDs, taskIDs).
+		Where("task_id IN (" + liveTasks + ") AND blocked_by_id IN (" + liveTasks + ")").
+		Order("created_at ASC").
+		Find(&dependencies).Error
+	if err != nil {
+		return nil, err
+	}
+	return dependencies, nil
+}
+
+// FindBlockers retrieves the tasks a task is blocked by
+func (r *taskRepository) FindBlockers(taskID uuid.UUID) ([]*entities.Task, error) {
+	var tasks []*entities.Task
+	err := r.db.Preload("Tags").
+		Where("id IN (SELECT blocked_by_id FROM task_dependencies WHERE task_id = ?)", taskID).
+		Order("created_at ASC").
+		Find(&tasks).Error
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// FindBlockedTasks retrieves the tasks blocked by a task
+func (r *taskRepository) FindBlockedTasks(taskID uuid.UUID) ([]*entities.Task, error) {
+	var tasks []*entities.Task
+	err := r.db.Preload("Tags").
+		Where("id IN (SELECT task_id FROM task_dependencies WHERE blocked_by_id = ?)", taskID).
+		Order("created_at ASC").
+		Find(&tasks).Error
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// applyTaskFilter adds the conditions of a task filter to a query
+func applyTaskFilter(query *gorm.DB, filter interfaces.TaskFilter) *gorm.DB {
+	if filter.Domain != "" {
+		query = query.Where("domain = ?", filter.Domain)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.TopLevel {
+		query = query.Where("parent_task_id IS NULL")
+	}
+	if filter.NoDeadline {
+		query = query.Where("deadline IS NULL")
+	}
+	if filter.DeadlineFrom != nil {
+		query = query.Where("deadline >= ?", *filter.DeadlineFrom)
+	}
+	if filter.DeadlineBefore != nil {
+		query = query.Where("deadline < ?", *filter.DeadlineBefore)
+	}
+	if filter.ExcludeDone {
+		query = query.Where("status NOT IN ?", entities.TerminalTaskStatuses)
+	}
+
+	// Tag filter: OR of groups, each an AND of (NOT) EXISTS conditions
+	if filter.Tags != nil && len(filter.Tags.Groups) > 0 {
+		var groups []string
+		var args []any
+		for _, group := range filter.Tags.Groups {
+			var conditions []string
+			for _, condition := range group {
+				exists := `EXISTS (SELECT 1 FROM task_tags JOIN tags ON tags.id = task_tags.tag_id
+					WHERE task_tags.task_id = tasks.id AND tags.name IN ?)`
+				if condition.Negate {
+					exists = "NOT " + exists
+				}
+				conditions = append(conditions, exists)
+				args = append(args, condition.Tags)
+			}
+			groups = append(groups, "("+strings.Join(conditions, " AND ")+")")
+		}
+		query = query.Where("("+strings.Join(groups, " OR ")+")", args...)
+	}
+
+	// Dependency filter: open tasks with or without blockers that are not done or cancelled yet
+	if filter.Blocked != nil {
+		openBlocker := `EXISTS (SELECT 1 FROM task_dependencies JOIN tasks blockers ON blockers.id = task_dependencies.blocked_by_id
+			WHERE task_dependencies.task_id = tasks.id AND blockers.status NOT IN ? AND blockers.deleted_at IS NULL)`
+		if !*filter.Blocked {
+			openBlocker = "NOT " + openBlocker
+		}
+		query = query.Where("status NOT IN ?", entities.TerminalTaskStatuses).Where(openBlocker, entities.TerminalTaskStatuses)
+	}
+
+	return query
+}
+
+// Missing deadlines are replaced by these bounds so they sort last in either direction
+var (
+	noDeadlineAsc  = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+	noDeadlineDesc = time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// taskSortKey is the SQL expression of a sort key with the matching value of a cursor
+type taskSortKey struct {
+	expr  string
+	desc  bool
+	value func(cursor *interfaces.TaskCursor) any
+}
+
+// newTaskSortKey returns the SQL expression for a sort key (unknown keys sort by creation time)
+func newTaskSortKey(sort interfaces.TaskSort) taskSortKey {
+	switch sort.Key {
+	case interfaces.TaskSortDeadline:
+		bound := noDeadlineAsc
+		if sort.Desc {
+			bound = noDeadlineDesc
+		}
+		return taskSortKey{
+			expr: "COALESCE(deadline, '" + bound.Format("2006-01-02 15:04:05-07") + "'::timestamptz)",
+			desc: sort.Desc,
+			value: func(c *interfaces.TaskCursor) any {
+				if c.Deadline == nil {
+					return bound
+				}
+				return *c.Deadline
+			},
+		}
+
+	case interfaces.TaskSortPriority:
+		return taskSortKey{
+			expr: "CASE priority WHEN 'High' THEN 3 WHEN 'Medium' THEN 2 ELSE 1 END",
+			desc: sort.Desc,
+			value: func(c *interfaces.TaskCursor) any {
+				switch c.Priority {
+				case entities.PriorityHigh:
+					return 3
+				case entities.PriorityMedium:
+					return 2
+				default:
+					return 1
+				}
+			},
+		}
+
+	default:
+		return taskSortKey{
+			expr:  "created_at",
+			desc:  sort.Desc,
+			value: func(c *interfaces.TaskCursor) any { return c.CreatedAt },
+		}
+	}
+}
+
+// preloadSubtasks preloads the subtask tree below prefix (e.g. "Tasks.Task.") ordered by position
+func preloadSubtasks(db *gorm.DB, prefix string) *gorm.DB {
+	byPosition := func(db *gorm.DB) *gorm.DB {
+		return db.Order("position ASC").Order("created_at ASC")
+	}
+
+	path := prefix + "Subtasks"
+	for depth := 1; depth <= entities.MaxSubtaskDepth; depth++ {
+		db = db.Preload(path, byPosition)
+		path += ".Subtasks"
+	}
+	return db
 }
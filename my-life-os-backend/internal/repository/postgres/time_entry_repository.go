@@ -0,0 +1,112 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type timeEntryRepository struct {
+	db *gorm.DB
+}
+
+// NewTimeEntryRepository creates a new time entry repository
+func NewTimeEntryRepository(db *gorm.DB) interfaces.TimeEntryRepository {
+	return &timeEntryRepository{db: db}
+}
+
+// CreateTimeEntry creates a new time entry
+func (r *timeEntryRepository) CreateTimeEntry(entry *entities.TimeEntry) error {
+	return r.db.Omit(clause.Associations).Create(entry).Error
+}
+
+// StartTimer stops the running timer of the user and creates the new one in one transaction
+func (r *timeEntryRepository) StartTimer(entry *entities.TimeEntry) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&entities.TimeEntry{}).
+			Where("user_id = ? AND ended_at IS NULL", entry.UserID).
+			Updates(map[string]any{"ended_at": entry.StartedAt, "updated_at": entry.StartedAt}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Omit(clause.Associations).Create(entry).Error
+	})
+}
+
+// StopRunningTimer stops the running timer of a user (only one request can stop it)
+func (r *timeEntryRepository) StopRunningTimer(userID uuid.UUID, endedAt time.Time) (*entities.TimeEntry, error) {
+	var entries []*entities.TimeEntry
+	err := r.db.Model(&entries).Clauses(clause.Returning{}).
+		Where("user_id = ? AND ended_at IS NULL", userID).
+		Updates(map[string]any{"ended_at": endedAt, "updated_at": endedAt}).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	return entries[0], nil
+}
+
+// FindTimeEntryByID retrieves a time entry by ID
+func (r *timeEntryRepository) FindTimeEntryByID(id uuid.UUID) (*entities.TimeEntry, error) {
+	var entry entities.TimeEntry
+	err := r.db.Preload("Task").Where("id = ?", id).First(&entry).Error
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// FindRunningTimeEntry retrieves the running timer of a user
+func (r *timeEntryRepository) FindRunningTimeEntry(userID uuid.UUID) (*entities.TimeEntry, error) {
+	var entry entities.TimeEntry
+	err := r.db.Preload("Task").Where("user_id = ? AND ended_at IS NULL", userID).First(&entry).Error
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// FindTimeEntriesByUserID retrieves the entries of a user overlapping a time range (without trashed tasks)
+func (r *timeEntryRepository) FindTimeEntriesByUserID(userID uuid.UUID, from, to time.Time, taskID *uuid.UUID) ([]*entities.TimeEntry, error) {
+	var entries []*entities.TimeEntry
+	query := r.db.Preload("Task").
+		Where("user_id = ? AND started_at < ? AND (ended_at IS NULL OR ended_at > ?)", userID, to, from).
+		Where("task_id IN (" + liveTasks + ")")
+	if taskID != nil {
+		query = query.Where("task_id = ?", *taskID)
+	}
+	err := query.Order("started_at ASC").Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// FindOverlappingTimeEntries retrieves the entries of a user overlapping a time range, except one entry
+func (r *timeEntryRepository) FindOverlappingTimeEntries(userID uuid.UUID, from, to time.Time, excludeID uuid.UUID) ([]*entities.TimeEntry, error) {
+	var entries []*entities.TimeEntry
+	err := r.db.Where("user_id = ? AND id <> ? AND started_at < ? AND (ended_at IS NULL OR ended_at > ?)", userID, excludeID, to, from).
+		Order("started_at ASC").
+		Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// UpdateTimeEntry updates a time entry (the loaded task is not saved)
+func (r *timeEntryRepository) UpdateTimeEntry(entry *entities.TimeEntry) error {
+	return r.db.Omit(clause.Associations).Save(entry).Error
+}
+
+// DeleteTimeEntry deletes a time entry
+func (r *timeEntryRepository) DeleteTimeEntry(id uuid.UUID) error {
+	return r.db.Delete(&entities.TimeEntry{}, "id = ?", id).Error
+}
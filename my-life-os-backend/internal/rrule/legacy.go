@@ -0,0 +1,55 @@
+package rrule
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+// legacyDays maps the day names of the old recurrenceDays format to weekdays
+var legacyDays = map[string]time.Weekday{
+	"sunday": time.Sunday, "monday": time.Monday, "tuesday": time.Tuesday,
+	"wednesday": time.Wednesday, "thursday": time.Thursday,
+	"friday": time.Friday, "saturday": time.Saturday,
+}
+
+// FromLegacy converts the old recurrence format (recurrenceType, recurrenceDays, recurrenceEnd) into a rule.
+// recurrenceType is one of daily, weekly, monthly, yearly; recurrenceDays is a JSON array
+// of day names (["monday","wednesday"]) and is required for weekly recurrences.
+func FromLegacy(recurrenceType string, recurrenceDays *string, recurrenceEnd *time.Time) (*Rule, error) {
+	rule := &Rule{Interval: 1, Wkst: time.Monday, Until: recurrenceEnd}
+
+	switch recurrenceType {
+	case "daily":
+		rule.Freq = Daily
+	case "weekly":
+		rule.Freq = Weekly
+		if recurrenceDays == nil || *recurrenceDays == "" {
+			return nil, errors.New("recurrence days are required for weekly recurring events")
+		}
+
+		var days []string
+		if err := json.Unmarshal([]byte(*recurrenceDays), &days); err != nil {
+			return nil, errors.New("invalid recurrence days format")
+		}
+		for _, day := range days {
+			wd, ok := legacyDays[strings.ToLower(day)]
+			if !ok {
+				return nil, errors.New("invalid day name in recurrence days")
+			}
+			rule.ByDay = append(rule.ByDay, WeekdayNum{Weekday: wd})
+		}
+		if len(rule.ByDay) == 0 {
+			return nil, errors.New("recurrence days are required for weekly recurring events")
+		}
+	case "monthly":
+		rule.Freq = Monthly
+	case "yearly":
+		rule.Freq = Yearly
+	default:
+		return nil, errors.New("invalid recurrence type")
+	}
+
+	return rule, nil
+}
@@ -0,0 +1,597 @@
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency values (RFC 5545 FREQ)
+const (
+	Daily   = "DAILY"
+	Weekly  = "WEEKLY"
+	Monthly = "MONTHLY"
+	Yearly  = "YEARLY"
+)
+
+// untilLayout is the UTC DATE-TIME format used for UNTIL
+const untilLayout = "20060102T150405Z"
+
+// maxIterations guards against rules that never produce an occurrence (e.g. BYMONTHDAY=31;BYMONTH=2)
+const maxIterations = 100000
+
+// weekdayCodes maps RFC 5545 weekday codes to time.Weekday
+var weekdayCodes = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// WeekdayNum is a BYDAY entry: a weekday with an optional ordinal (e.g. 2MO, -1FR)
+type WeekdayNum struct {
+	Weekday time.Weekday
+	N       int // 0 = every such weekday in the period
+}
+
+// Rule is a recurrence rule (the subset of RFC 5545 RRULE used for events)
+type Rule struct {
+	Freq       string
+	Interval   int
+	Count      int        // 0 = unlimited
+	Until      *time.Time // nil = never ends (inclusive)
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	ByMonth    []int
+	BySetPos   []int
+	Wkst       time.Weekday
+}
+
+// Parse parses an RRULE value such as "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH"
+func Parse(value string) (*Rule, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	if value == "" {
+		return nil, errors.New("recurrence rule is empty")
+	}
+
+	rule := &Rule{Interval: 1, Wkst: time.Monday}
+	seen := make(map[string]bool)
+
+	for _, part := range strings.Split(value, ";") {
+		if part == "" {
+			continue
+		}
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid recurrence rule part %q", part)
+		}
+		key := strings.ToUpper(strings.TrimSpace(kv[0]))
+		val := strings.ToUpper(strings.TrimSpace(kv[1]))
+		if seen[key] {
+			return nil, fmt.Errorf("duplicate %s in recurrence rule", key)
+		}
+		seen[key] = true
+
+		var err error
+		switch key {
+		case "FREQ":
+			rule.Freq = val
+		case "INTERVAL":
+			rule.Interval, err = strconv.Atoi(val)
+		case "COUNT":
+			rule.Count, err = strconv.Atoi(val)
+		case "UNTIL":
+			var until time.Time
+			until, err = parseUntil(val)
+			rule.Until = &until
+		case "BYDAY":
+			rule.ByDay, err = parseByDay(val)
+		case "BYMONTHDAY":
+			rule.ByMonthDay, err = parseIntList(val)
+		case "BYMONTH":
+			rule.ByMonth, err = parseIntList(val)
+		case "BYSETPOS":
+			rule.BySetPos, err = parseIntList(val)
+		case "WKST":
+			wd, ok := weekdayCodes[val]
+			if !ok {
+				err = errors.New("invalid weekday")
+			}
+			rule.Wkst = wd
+		default:
+			return nil, fmt.Errorf("unsupported recurrence rule part %s", key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s in recurrence rule", key)
+		}
+	}
+
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// Validate checks that the rule is consistent
+func (r *Rule) Validate() error {
+	switch r.Freq {
+	case Daily, Weekly, Monthly, Yearly:
+	case "":
+		return errors.New("recurrence rule requires FREQ")
+	default:
+		return errors.New("unsupported recurrence frequency " + r.Freq)
+	}
+
+	if r.Interval < 1 {
+		return errors.New("recurrence interval must be at least 1")
+	}
+	if r.Count < 0 {
+		return errors.New("recurrence count must be positive")
+	}
+	if r.Count > 0 && r.Until != nil {
+		return errors.New("recurrence rule cannot have both COUNT and UNTIL")
+	}
+
+	for _, d := range r.ByDay {
+		if d.N != 0 && r.Freq != Monthly && r.Freq != Yearly {
+			return errors.New("BYDAY ordinals are only allowed for monthly and yearly rules")
+		}
+		if d.N < -53 || d.N > 53 {
+			return errors.New("BYDAY ordinal out of range")
+		}
+	}
+	for _, d := range r.ByMonthDay {
+		if d == 0 || d < -31 || d > 31 {
+			return errors.New("BYMONTHDAY must be between 1 and 31 (or -31 and -1)")
+		}
+	}
+	if len(r.ByMonthDay) > 0 && r.Freq == Weekly {
+		return errors.New("BYMONTHDAY is not allowed for weekly rules")
+	}
+	for _, m := range r.ByMonth {
+		if m < 1 || m > 12 {
+			return errors.New("BYMONTH must be between 1 and 12")
+		}
+	}
+	for _, p := range r.BySetPos {
+		if p == 0 || p < -366 || p > 366 {
+			return errors.New("BYSETPOS must be between 1 and 366 (or -366 and -1)")
+		}
+	}
+	if len(r.BySetPos) > 0 && len(r.ByDay) == 0 && len(r.ByMonthDay) == 0 && len(r.ByMonth) == 0 {
+		return errors.New("BYSETPOS requires another BYxxx rule part")
+	}
+
+	return nil
+}
+
+// String formats the rule as an RRULE value
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + r.Freq}
+
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format(untilLayout))
+	}
+	if len(r.ByDay) > 0 {
+		var days []string
+		for _, d := range r.ByDay {
+			days = append(days, d.String())
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		parts = append(parts, "BYMONTHDAY="+joinInts(r.ByMonthDay))
+	}
+	if len(r.ByMonth) > 0 {
+		parts = append(parts, "BYMONTH="+joinInts(r.ByMonth))
+	}
+	if len(r.BySetPos) > 0 {
+		parts = append(parts, "BYSETPOS="+joinInts(r.BySetPos))
+	}
+	if r.Wkst != time.Monday {
+		parts = append(parts, "WKST="+weekdayCode(r.Wkst))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+// String formats a BYDAY entry (e.g. "2MO", "-1FR", "TU")
+func (d WeekdayNum) String() string {
+	if d.N == 0 {
+		return weekdayCode(d.Weekday)
+	}
+	return strconv.Itoa(d.N) + weekdayCode(d.Weekday)
+}
+
+// Between returns all occurrences with start <= occurrence <= end.
+// dtstart is the first instance of the series; its time of day and location are kept for every occurrence.
+func (r *Rule) Between(dtstart, start, end time.Time) []time.Time {
+	var occurrences []time.Time
+	r.iterate(dtstart, func(t time.Time) bool {
+		if t.After(end) {
+			return false
+		}
+		if !t.Before(start) {
+			occurrences = append(occurrences, t)
+		}
+		return true
+	})
+	return occurrences
+}
+
+// First returns the first occurrence of the rule, or nil if it has none.
+// dtstart itself is only an occurrence if it matches the rule.
+func (r *Rule) First(dtstart time.Time) *time.Time {
+	var first *time.Time
+	r.iterate(dtstart, func(t time.Time) bool {
+		occurrence := t
+		first = &occurrence
+		return false
+	})
+	return first
+}
+
+// After returns the first occurrence strictly after t, or nil if the rule ends before
+func (r *Rule) After(dtstart, t time.Time) *time.Time {
+	var next *time.Time
+	r.iterate(dtstart, func(occurrence time.Time) bool {
+		if !occurrence.After(t) {
+			return true
+		}
+		next = &occurrence
+		return false
+	})
+	return next
+}
+
+// Last returns the final occurrence of a finite rule (COUNT or UNTIL), or nil if the rule never ends
+func (r *Rule) Last(dtstart time.Time) *time.Time {
+	if r.Count == 0 && r.Until == nil {
+		return nil
+	}
+
+	var last *time.Time
+	r.iterate(dtstart, func(t time.Time) bool {
+		occurrence := t
+		last = &occurrence
+		return true
+	})
+	return last
+}
+
+// iterate calls fn for every occurrence in order until fn returns false or the rule ends
+func (r *Rule) iterate(dtstart time.Time, fn func(time.Time) bool) {
+	emitted := 0
+	period := r.periodStart(dtstart)
+
+	for i := 0; i < maxIterations; i++ {
+		candidates := r.expandPeriod(period, dtstart)
+
+		for _, t := range candidates {
+			if t.Before(dtstart) {
+				continue
+			}
+			if r.Until != nil && t.After(*r.Until) {
+				return
+			}
+			if !fn(t) {
+				return
+			}
+			emitted++
+			if r.Count > 0 && emitted >= r.Count {
+				return
+			}
+		}
+
+		period = r.nextPeriod(period)
+		if r.Until != nil && period.After(*r.Until) {
+			return
+		}
+	}
+}
+
+// periodStart returns the first day of the period (day, week, month, year) containing t
+func (r *Rule) periodStart(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+
+	switch r.Freq {
+	case Weekly:
+		offset := (int(day.Weekday()) - int(r.Wkst) + 7) % 7
+		return day.AddDate(0, 0, -offset)
+	case Monthly:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	case Yearly:
+		return time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, t.Location())
+	default:
+		return day
+	}
+}
+
+// nextPeriod advances a period start by INTERVAL periods
+func (r *Rule) nextPeriod(period time.Time) time.Time {
+	switch r.Freq {
+	case Weekly:
+		return period.AddDate(0, 0, 7*r.Interval)
+	case Monthly:
+		return period.AddDate(0, r.Interval, 0)
+	case Yearly:
+		return period.AddDate(r.Interval, 0, 0)
+	default:
+		return period.AddDate(0, 0, r.Interval)
+	}
+}
+
+// expandPeriod returns the sorted occurrences within one period (before BYSETPOS is applied it is the candidate set)
+func (r *Rule) expandPeriod(period, dtstart time.Time) []time.Time {
+	var days []time.Time
+
+	switch r.Freq {
+	case Daily:
+		if r.matchesDay(period) {
+			days = append(days, period)
+		}
+
+	case Weekly:
+		byDay := r.ByDay
+		if len(byDay) == 0 {
+			byDay = []WeekdayNum{{Weekday: dtstart.Weekday()}}
+		}
+		for i := 0; i < 7; i++ {
+			day := period.AddDate(0, 0, i)
+			if containsWeekday(byDay, day.Weekday()) && r.matchesMonth(day) {
+				days = append(days, day)
+			}
+		}
+
+	case Monthly:
+		if r.matchesMonth(period) {
+			days = r.expandMonth(period.Year(), period.Month(), period.Location(), dtstart)
+		}
+
+	case Yearly:
+		days = r.expandYear(period.Year(), period.Location(), dtstart)
+	}
+
+	// Apply the time of day of dtstart (time.Date normalizes DST gaps)
+	occurrences := make([]time.Time, 0, len(days))
+	for _, day := range days {
+		occurrences = append(occurrences, time.Date(day.Year(), day.Month(), day.Day(),
+			dtstart.Hour(), dtstart.Minute(), dtstart.Second(), 0, day.Location()))
+	}
+
+	return r.applySetPos(occurrences)
+}
+
+// expandMonth returns the matching days of a single month
+func (r *Rule) expandMonth(year int, month time.Month, loc *time.Location, dtstart time.Time) []time.Time {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, loc)
+	daysInMonth := first.AddDate(0, 1, -1).Day()
+
+	// Without BYDAY/BYMONTHDAY, the day of dtstart is used (months without that day are skipped)
+	if len(r.ByDay) == 0 && len(r.ByMonthDay) == 0 {
+		if dtstart.Day() > daysInMonth {
+			return nil
+		}
+		return []time.Time{time.Date(year, month, dtstart.Day(), 0, 0, 0, 0, loc)}
+	}
+
+	var days []time.Time
+	for d := 1; d <= daysInMonth; d++ {
+		day := time.Date(year, month, d, 0, 0, 0, 0, loc)
+
+		if len(r.ByMonthDay) > 0 && !matchesMonthDay(r.ByMonthDay, d, daysInMonth) {
+			continue
+		}
+		if len(r.ByDay) > 0 && !matchesByDay(r.ByDay, day.Weekday(), d, daysInMonth) {
+			continue
+		}
+		days = append(days, day)
+	}
+	return days
+}
+
+// expandYear returns the matching days of a single year
+func (r *Rule) expandYear(year int, loc *time.Location, dtstart time.Time) []time.Time {
+	// BYDAY without BYMONTH/BYMONTHDAY: ordinals are relative to the whole year
+	if len(r.ByMonth) == 0 && len(r.ByMonthDay) == 0 && len(r.ByDay) > 0 {
+		daysInYear := time.Date(year, time.December, 31, 0, 0, 0, 0, loc).YearDay()
+		var days []time.Time
+		for d := 1; d <= daysInYear; d++ {
+			day := time.Date(year, time.January, d, 0, 0, 0, 0, loc)
+			if matchesByDay(r.ByDay, day.Weekday(), d, daysInYear) {
+				days = append(days, day)
+			}
+		}
+		return days
+	}
+
+	// BYMONTHDAY without BYMONTH applies to every month, only a plain yearly rule repeats DTSTART's month
+	months := r.ByMonth
+	if len(months) == 0 && len(r.ByMonthDay) > 0 {
+		months = []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
+	} else if len(months) == 0 {
+		months = []int{int(dtstart.Month())}
+	}
+	sorted := append([]int(nil), months...)
+	sort.Ints(sorted)
+
+	var days []time.Time
+	for _, m := range sorted {
+		days = append(days, r.expandMonth(year, time.Month(m), loc, dtstart)...)
+	}
+	return days
+}
+
+// matchesDay checks the BY* filters of a daily rule
+func (r *Rule) matchesDay(day time.Time) bool {
+	if !r.matchesMonth(day) {
+		return false
+	}
+	if len(r.ByMonthDay) > 0 {
+		daysInMonth := time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, day.Location()).Day()
+		if !matchesMonthDay(r.ByMonthDay, day.Day(), daysInMonth) {
+			return false
+		}
+	}
+	if len(r.ByDay) > 0 && !containsWeekday(r.ByDay, day.Weekday()) {
+		return false
+	}
+	return true
+}
+
+// matchesMonth checks the BYMONTH filter
+func (r *Rule) matchesMonth(day time.Time) bool {
+	if len(r.ByMonth) == 0 {
+		return true
+	}
+	for _, m := range r.ByMonth {
+		if int(day.Month()) == m {
+			return true
+		}
+	}
+	return false
+}
+
+// applySetPos keeps only the BYSETPOS positions of a period's occurrences
+func (r *Rule) applySetPos(occurrences []time.Time) []time.Time {
+	if len(r.BySetPos) == 0 || len(occurrences) == 0 {
+		return occurrences
+	}
+
+	n := len(occurrences)
+	selected := make(map[int]bool)
+	for _, pos := range r.BySetPos {
+		idx := pos - 1
+		if pos < 0 {
+			idx = n + pos
+		}
+		if idx >= 0 && idx < n {
+			selected[idx] = true
+		}
+	}
+
+	var result []time.Time
+	for i, t := range occurrences {
+		if selected[i] {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// matchesMonthDay checks a day against BYMONTHDAY values (negative values count from the end)
+func matchesMonthDay(byMonthDay []int, day, daysInMonth int) bool {
+	for _, d := range byMonthDay {
+		if d > 0 && d == day {
+			return true
+		}
+		if d < 0 && daysInMonth+d+1 == day {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesByDay checks a day against BYDAY entries whose ordinals are relative to a range (month or year).
+// index is the 1-based position of the day within the range.
+func matchesByDay(byDay []WeekdayNum, wd time.Weekday, index, rangeDays int) bool {
+	for _, d := range byDay {
+		if d.Weekday != wd {
+			continue
+		}
+		if d.N == 0 {
+			return true
+		}
+		nth := (index-1)/7 + 1                   // e.g. 2nd Monday
+		nthFromEnd := -((rangeDays-index)/7 + 1) // e.g. -1 = last Monday
+		if d.N == nth || d.N == nthFromEnd {
+			return true
+		}
+	}
+	return false
+}
+
+// containsWeekday checks if a weekday is in a BYDAY list (ignoring ordinals)
+func containsWeekday(byDay []WeekdayNum, wd time.Weekday) bool {
+	for _, d := range byDay {
+		if d.Weekday == wd {
+			return true
+		}
+	}
+	return false
+}
+
+// parseUntil parses an UNTIL value (DATE or DATE-TIME). A DATE includes the whole day.
+func parseUntil(value string) (time.Time, error) {
+	if len(value) == 8 {
+		t, err := time.ParseInLocation("20060102", value, time.UTC)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return t.Add(24*time.Hour - time.Second), nil
+	}
+	if strings.HasSuffix(value, "Z") {
+		return time.ParseInLocation(untilLayout, value, time.UTC)
+	}
+	return time.ParseInLocation("20060102T150405", value, time.UTC)
+}
+
+// parseByDay parses a BYDAY list (e.g. "MO,WE" or "2MO,-1FR")
+func parseByDay(value string) ([]WeekdayNum, error) {
+	var days []WeekdayNum
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if len(item) < 2 {
+			return nil, errors.New("invalid BYDAY")
+		}
+		code := item[len(item)-2:]
+		wd, ok := weekdayCodes[code]
+		if !ok {
+			return nil, errors.New("invalid BYDAY")
+		}
+		n := 0
+		if prefix := item[:len(item)-2]; prefix != "" {
+			var err error
+			n, err = strconv.Atoi(prefix)
+			if err != nil || n == 0 {
+				return nil, errors.New("invalid BYDAY")
+			}
+		}
+		days = append(days, WeekdayNum{Weekday: wd, N: n})
+	}
+	return days, nil
+}
+
+// parseIntList parses a comma-separated list of integers
+func parseIntList(value string) ([]int, error) {
+	var values []int
+	for _, item := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(item))
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, n)
+	}
+	return values, nil
+}
+
+// joinInts formats a list of integers as a comma-separated string
+func joinInts(values []int) string {
+	strs := make([]string, len(values))
+	for i, v := range values {
+		strs[i] = strconv.Itoa(v)
+	}
+	return strings.Join(strs, ",")
+}
+
+// weekdayCode returns the RFC 5545 code of a weekday
+func weekdayCode(wd time.Weekday) string {
+	return strings.ToUpper(wd.String()[:2])
+}
@@ -0,0 +1,228 @@
+package rrule
+
+import (
+	"testing"
+	"time"
+)
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("load %s: %v", name, err)
+	}
+	return loc
+}
+
+func TestBetweenAcrossDST(t *testing.T) {
+	const layout = "2006-01-02 15:04 -0700"
+
+	tests := []struct {
+		name    string
+		rule    string
+		zone    string
+		dtstart string // Local wall-clock time in zone
+		from    string
+		to      string
+		want    []string
+	}{
+		{
+			name:    "daily keeps local time over spring-forward",
+			rule:    "FREQ=DAILY",
+			zone:    "Europe/Berlin",
+			dtstart: "2026-03-27 09:00",
+			from:    "2026-03-27 00:00",
+			to:      "2026-03-31 00:00",
+			want: []string{
+				"2026-03-27 09:00 +0100",
+				"2026-03-28 09:00 +0100",
+				"2026-03-29 09:00 +0200",
+				"2026-03-30 09:00 +0200",
+			},
+		},
+		{
+			name:    "weekly keeps local time over fall-back",
+			rule:    "FREQ=WEEKLY;BYDAY=SU",
+			zone:    "Europe/Berlin",
+			dtstart: "2026-10-18 09:00",
+			from:    "2026-10-18 00:00",
+			to:      "2026-11-02 00:00",
+			want: []string{
+				"2026-10-18 09:00 +0200",
+				"2026-10-25 09:00 +0100",
+				"2026-11-01 09:00 +0100",
+			},
+		},
+		{
+			name:    "time in the spring-forward gap moves past the gap",
+			rule:    "FREQ=DAILY",
+			zone:    "Europe/Berlin",
+			dtstart: "2026-03-28 02:30",
+			from:    "2026-03-28 00:00",
+			to:      "2026-03-31 00:00",
+			want: []string{
+				"2026-03-28 02:30 +0100",
+				"2026-03-29 03:30 +0200",
+				"2026-03-30 02:30 +0200",
+			},
+		},
+		{
+			name:    "until is compared as an instant after the change",
+			rule:    "FREQ=DAILY;UNTIL=20260330T070000Z",
+			zone:    "Europe/Berlin",
+			dtstart: "2026-03-28 09:00",
+			from:    "2026-03-28 00:00",
+			to:      "2026-04-05 00:00",
+			want: []string{
+				"2026-03-28 09:00 +0100",
+				"2026-03-29 09:00 +0200",
+				"2026-03-30 09:00 +0200",
+			},
+		},
+		{
+			name:    "monthly last sunday over both changes",
+			rule:    "FREQ=MONTHLY;BYDAY=-1SU;BYMONTH=3,10",
+			zone:    "Europe/Berlin",
+			dtstart: "2026-03-29 12:00",
+			from:    "2026-01-01 00:00",
+			to:      "2026-12-31 00:00",
+			want: []string{
+				"2026-03-29 12:00 +0200",
+				"2026-10-25 12:00 +0100",
+			},
+		},
+		{
+			name:    "US zone changes on its own dates",
+			rule:    "FREQ=WEEKLY;BYDAY=SU",
+			zone:    "America/New_York",
+			dtstart: "2026-10-25 08:00",
+			from:    "2026-10-25 00:00",
+			to:      "2026-11-09 00:00",
+			want: []string{
+				"2026-10-25 08:00 -0400",
+				"2026-11-01 08:00 -0500",
+				"2026-11-08 08:00 -0500",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc := mustLoad(t, tt.zone)
+			rule, err := Parse(tt.rule)
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			parse := func(value string) time.Time {
+				parsed, err := time.ParseInLocation("2006-01-02 15:04", value, loc)
+				if err != nil {
+					t.Fatalf("parse %q: %v", value, err)
+				}
+				return parsed
+			}
+
+			got := rule.Between(parse(tt.dtstart), parse(tt.from), parse(tt.to))
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d occurrences %v, want %d", len(got), got, len(tt.want))
+			}
+			for i, occurrence := range got {
+				if formatted := occurrence.In(loc).Format(layout); formatted != tt.want[i] {
+					t.Errorf("occurrence %d = %s, want %s", i, formatted, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestBetweenYearly(t *testing.T) {
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2026, month, d, 10, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name    string
+		rule    string
+		dtstart time.Time
+		want    int
+		first   []string
+	}{
+		{
+			name:    "plain yearly repeats the month and day of dtstart",
+			rule:    "FREQ=YEARLY",
+			dtstart: day(time.March, 15),
+			want:    1,
+			first:   []string{"2026-03-15"},
+		},
+		{
+			name:    "month days without months apply to every month",
+			rule:    "FREQ=YEARLY;BYMONTHDAY=1,15",
+			dtstart: day(time.January, 1),
+			want:    24,
+			first:   []string{"2026-01-01", "2026-01-15", "2026-02-01", "2026-02-15"},
+		},
+		{
+			name:    "last day of every month",
+			rule:    "FREQ=YEARLY;BYMONTHDAY=-1",
+			dtstart: day(time.January, 31),
+			want:    12,
+			first:   []string{"2026-01-31", "2026-02-28", "2026-03-31"},
+		},
+		{
+			name:    "weekday and month day without months",
+			rule:    "FREQ=YEARLY;BYDAY=FR;BYMONTHDAY=13",
+			dtstart: day(time.February, 13),
+			want:    3, // Friday the 13th: February, March and November 2026
+			first:   []string{"2026-02-13", "2026-03-13", "2026-11-13"},
+		},
+		{
+			name:    "month days with months stay in those months",
+			rule:    "FREQ=YEARLY;BYMONTH=6;BYMONTHDAY=1,15",
+			dtstart: day(time.June, 1),
+			want:    2,
+			first:   []string{"2026-06-01", "2026-06-15"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse(tt.rule)
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+
+			got := rule.Between(tt.dtstart, tt.dtstart, time.Date(2026, 12, 31, 23, 59, 0, 0, time.UTC))
+			if len(got) != tt.want {
+				t.Fatalf("got %d occurrences in 2026 %v, want %d", len(got), got, tt.want)
+			}
+			for i, want := range tt.first {
+				if formatted := got[i].Format("2006-01-02"); formatted != want {
+					t.Errorf("occurrence %d = %s, want %s", i, formatted, want)
+				}
+				if got[i].Hour() != 10 {
+					t.Errorf("occurrence %d = %s, want the time of dtstart", i, got[i])
+				}
+			}
+		})
+	}
+}
+
+func TestBetweenFallBackRepeatedHour(t *testing.T) {
+	loc := mustLoad(t, "Europe/Berlin")
+	rule, err := Parse("FREQ=DAILY")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	// 02:30 exists twice on the fall-back night, the occurrence must still be there exactly once
+	dtstart := time.Date(2026, 10, 24, 2, 30, 0, 0, loc)
+	got := rule.Between(dtstart, dtstart, time.Date(2026, 10, 26, 23, 0, 0, 0, loc))
+	if len(got) != 3 {
+		t.Fatalf("got %d occurrences %v, want 3", len(got), got)
+	}
+	for i, occurrence := range got {
+		local := occurrence.In(loc)
+		if local.Hour() != 2 || local.Minute() != 30 || local.Day() != 24+i {
+			t.Errorf("occurrence %d = %s, want 02:30 on day %d", i, local, 24+i)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package service
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
+
+	"github.com/google/uuid"
+)
+
+type agendaService struct {
+	eventService   interfaces.EventService
+	taskRepo       interfaces.TaskRepository
+	routineService interfaces.RoutineService
+	userRepo       interfaces.UserRepository
+}
+
+// NewAgendaService creates a new agenda service
+func NewAgendaService(
+	eventService interfaces.EventService,
+	taskRepo interfaces.TaskRepository,
+	routineService interfaces.RoutineService,
+	userRepo interfaces.UserRepository,
+) interfaces.AgendaService {
+	return &agendaService{
+		eventService:   eventService,
+		taskRepo:       taskRepo,
+		routineService: routineService,
+		userRepo:       userRepo,
+	}
+}
+
+// GetAgenda returns the agenda of the day (or Monday-based week) of date (only its calendar date is used, nil = today).
+// Days are computed in the given timezone (empty = user's timezone).
+func (s *agendaService) GetAgenda(userID uuid.UUID, date *time.Time, view, timezone string) (*interfaces.Agenda, error) {
+	timezone, err := resolveUserTimezone(s.userRepo, userID, timezone)
+	if err != nil {
+		return nil, err
+	}
+	loc, _ := time.LoadLocation(timezone)
+
+	now := time.Now().In(loc)
+	todayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+
+	first := todayStart
+	if date != nil {
+		first = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
+	}
+	numDays := 1
+	switch view {
+	case interfaces.AgendaViewDay:
+	case interfaces.AgendaViewWeek:
+		// Weeks start on Monday
+		first = first.AddDate(0, 0, -((int(first.Weekday()) + 6) % 7))
+		numDays = 7
+	default:
+		return nil, errors.New("invalid view")
+	}
+
+	agenda := &interfaces.Agenda{
+		View:     view,
+		Timezone: timezone,
+	}
+	for i := 0; i < numDays; i++ {
+		// time.Date keeps days aligned to midnight across DST changes
+		start := time.Date(first.Year(), first.Month(), first.Day()+i, 0, 0, 0, 0, loc)
+		agenda.Days = append(agenda.Days, &interfaces.AgendaDay{
+			Date:  start.Format("2006-01-02"),
+			Start: start,
+			End:   time.Date(start.Year(), start.Month(), start.Day()+1, 0, 0, 0, 0, loc),
+			Items: []*interfaces.AgendaItem{},
+		})
+	}
+	rangeStart := agenda.Days[0].Start
+	rangeEnd := agenda.Days[numDays-1].End
+
+	// Events (expanded occurrences, including those starting before the range but lasting into it)
+	events, err := s.eventService.GetUserEventsInRange(userID, rangeStart.Add(-busyLookback), rangeEnd, timezone)
+	if err != nil {
+		return nil, err
+	}
+	busy := make(map[*interfaces.AgendaDay][]*interfaces.BusyInterval)
+	for _, event := range events {
+		if event.HideFromAgenda {
+			continue
+		}
+		for _, day := range agenda.Days {
+			if !eventOnDay(event, day, loc) {
+				continue
+			}
+			start := event.StartDate
+			day.Items = append(day.Items, &interfaces.AgendaItem{
+				Type:        interfaces.AgendaItemEvent,
+				ID:          event.ID,
+				Title:       event.Title,
+				Domain:      event.Domain,
+				Start:       &start,
+				End:         event.EndDate,
+				AllDay:      event.AllDay,
+				IsRecurring: event.IsRecurring,
+			})
+			day.Load.EventCount++
+
+			if from, to, ok := busyRange(event, loc); ok && !event.AllDay {
+				busy[day] = append(busy[day], &interfaces.BusyInterval{Start: from, End: to})
+			}
+		}
+	}
+
+	// Routines due on each day with their completion state. Routine days end at the user's rollover hour,
+	// so a routine at 01:30 of the routine day before is listed after midnight.
+	byDate := make(map[string]*interfaces.AgendaDay, numDays)
+	for _, day := range agenda.Days {
+		byDate[day.Date] = day
+	}
+	occurrences, err := s.routineService.GetRoutineOccurrences(userID, first.AddDate(0, 0, -1), first.AddDate(0, 0, numDays-1))
+	if err != nil {
+		return nil, err
+	}
+	for _, occurrence := range occurrences {
+		routine := occurrence.Routine
+		date := occurrence.Day.Format("2006-01-02")
+		var start *time.Time
+		if occurrence.Start != nil {
+			local := occurrence.Start.In(loc)
+			start = &local
+			date = local.Format("2006-01-02")
+		}
+
+		// Routines do not appear before they were created
+		day, ok := byDate[date]
+		if !ok || !routine.CreatedAt.Before(day.End) {
+			continue
+		}
+
+		day.Items = append(day.Items, &interfaces.AgendaItem{
+			Type:     interfaces.AgendaItemRoutine,
+			ID:       routine.ID,
+			Title:    routine.Title,
+			AllDay:   routine.TimeType == "AllDay",
+			TimeType: routine.TimeType,
+			Start:    start,
+			Status:   occurrence.Status,
+		})
+		day.Load.RoutineCount++
+		if occurrence.Status != "pending" {
+			day.Load.RoutinesDone++
+		}
+	}
+
+	// Tasks due on each day, open overdue tasks are listed on today
+	tasks, err := s.taskRepo.FindTasksByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, task := range tasks {
+		if task.Deadline == nil || task.Status == entities.StatusCancelled {
+			continue
+		}
+		deadline := task.Deadline.In(loc)
+		overdue := task.IsOpen() && deadline.Before(todayStart)
+
+		for _, day := range agenda.Days {
+			dueThatDay := !deadline.Before(day.Start) && deadline.Before(day.End)
+			overdueToday := overdue && day.Start.Equal(todayStart)
+			if !dueThatDay && !overdueToday {
+				continue
+			}
+
+			day.Items = append(day.Items, &interfaces.AgendaItem{
+				Type:     interfaces.AgendaItemTask,
+				ID:       task.ID,
+				Title:    task.Title,
+				Domain:   task.Domain,
+				Start:    &deadline,
+				AllDay:   overdueToday || deadline.Equal(day.Start), // Deadlines at midnight are date-only
+				Priority: task.Priority,
+				Overdue:  overdue,
+				Status:   task.Status,
+			})
+			day.Load.TaskCount++
+			if task.IsOpen() {
+				day.Load.OpenTaskCount++
+				if task.Priority == entities.PriorityHigh {
+					day.Load.HighPriorityDue++
+				}
+			}
+			if overdueToday {
+				day.Load.OverdueCount++
+			}
+		}
+	}
+
+	for _, day := range agenda.Days {
+		sortAgendaItems(day)
+
+		intervals := busy[day]
+		sort.SliceStable(intervals, func(i, j int) bool {
+			return intervals[i].Start.Before(intervals[j].Start)
+		})
+		day.Load.BusyMinutes = busyMinutes(intervals, day.Start, day.End)
+	}
+
+	return agenda, nil
+}
+
+// eventOnDay checks if an event occurrence takes place on a day (multi-day events appear on every day)
+func eventOnDay(event *entities.Event, day *interfaces.AgendaDay, loc *time.Location) bool {
+	start, end, ok := busyRange(event, loc)
+	if !ok {
+		// Events without an end appear on the day they start
+		return !event.StartDate.Before(day.Start) && event.StartDate.Before(day.End)
+	}
+	return start.Before(day.End) && end.After(day.Start)
+}
+
+// sortAgendaItems orders all-day items first, then by time.
+// AM and PM routines without a specific time are placed at the start of the morning and at noon.
+func sortAgendaItems(day *interfaces.AgendaDay) {
+	sortTime := func(item *interfaces.AgendaItem) time.Time {
+		if item.Start != nil {
+			return *item.Start
+		}
+		if item.TimeType == "PM" {
+			return day.Start.Add(12 * time.Hour)
+		}
+		return day.Start
+	}
+
+	sort.SliceStable(day.Items, func(i, j int) bool {
+		a, b := day.Items[i], day.Items[j]
+		if a.AllDay != b.AllDay {
+			return a.AllDay
+		}
+		if a.AllDay {
+			return false
+		}
+		return sortTime(a).Before(sortTime(b))
+	})
+}
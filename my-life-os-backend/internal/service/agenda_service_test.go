@@ -0,0 +1,207 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
+
+	"github.com/google/uuid"
+)
+
+type fakeEventService struct {
+	interfaces.EventService
+	events []*entities.Event
+}
+
+func (s *fakeEventService) GetUserEventsInRange(userID uuid.UUID, start, end time.Time, timezone string) ([]*entities.Event, error) {
+	var events []*entities.Event
+	for _, event := range s.events {
+		if !event.StartDate.Before(start) && !event.StartDate.After(end) {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+type fakeTaskRepo struct {
+	interfaces.TaskRepository
+	tasks    []*entities.Task
+	series   *entities.TaskSeries
+	blockers []*entities.Task
+	ranks    []*entities.TaskRank
+	saved    []*entities.TaskRank
+}
+
+func (r *fakeTaskRepo) FindTasksByUserID(userID uuid.UUID) ([]*entities.Task, error) {
+	return r.tasks, nil
+}
+
+// agendaRoutines returns the routine items of each day of an agenda week ("2006-01-02" -> "title status")
+func agendaRoutines(t *testing.T, routines []*entities.Routine, completions []*entities.RoutineCompletion, user *entities.User, week time.Time) map[string][]string {
+	t.Helper()
+	userRepo := &fakeUserRepo{user: user}
+	s := &agendaService{
+		eventService:   &fakeEventService{},
+		taskRepo:       &fakeTaskRepo{},
+		routineService: &routineService{routineRepo: &fakeRoutineRepo{routines: routines, completions: completions}, userRepo: userRepo},
+		userRepo:       userRepo,
+	}
+
+	agenda, err := s.GetAgenda(user.ID, &week, interfaces.AgendaViewWeek, "")
+	if err != nil {
+		t.Fatalf("GetAgenda: %v", err)
+	}
+
+	days := make(map[string][]string)
+	for _, day := range agenda.Days {
+		done := 0
+		for _, item := range day.Items {
+			if item.Type != interfaces.AgendaItemRoutine {
+				continue
+			}
+			entry := item.Title + " " + item.Status
+			if item.Start != nil {
+				entry += " " + item.Start.Format("15:04")
+			}
+			days[day.Date] = append(days[day.Date], entry)
+			if item.Status != "pending" {
+				done++
+			}
+		}
+		if day.Load.RoutineCount != len(days[day.Date]) || day.Load.RoutinesDone != done {
+			t.Errorf("%s: load %d/%d, want %d/%d", day.Date, day.Load.RoutinesDone, day.Load.RoutineCount, done, len(days[day.Date]))
+		}
+	}
+	return days
+}
+
+func TestGetAgendaRoutineRollover(t *testing.T) {
+	user := &entities.User{ID: uuid.New(), Timezone: "Europe/Berlin", DayRolloverHour: 4}
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	saturday := int(time.Saturday)
+	late, morning := "01:30", "07:00"
+	routines := []*entities.Routine{
+		{ID: uuid.New(), Title: "Wind down", Frequency: "Weekly", Weekday: &saturday, TimeType: "Specific", SpecificTime: &late, CreatedAt: created},
+		{ID: uuid.New(), Title: "Run", Frequency: "Weekly", Weekday: &saturday, TimeType: "Specific", SpecificTime: &morning, CreatedAt: created},
+		{ID: uuid.New(), Title: "Stretch", Frequency: "Weekly", Weekday: &saturday, TimeType: "AM", CreatedAt: created},
+	}
+	completions := []*entities.RoutineCompletion{
+		// Done in the night after Saturday, stored for Saturday's routine day
+		{RoutineID: routines[0].ID, CompletedAt: time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC), Status: entities.RoutineCompleted},
+	}
+
+	days := agendaRoutines(t, routines, completions, user, time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC))
+
+	assertAgendaRoutines(t, days, map[string][]string{
+		"2026-10-17": {"Stretch pending", "Run pending 07:00"},
+		"2026-10-18": {"Wind down completed 01:30"}, // Saturday's routine, after midnight
+	})
+}
+
+func TestGetAgendaQuotaRoutines(t *testing.T) {
+	user := &entities.User{ID: uuid.New(), Timezone: "Europe/Berlin"}
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	twice, week := 2, entities.RoutinePeriodWeek
+	routines := []*entities.Routine{
+		{ID: uuid.New(), Title: "Gym", Frequency: "Quota", RoutineSchedule: entities.RoutineSchedule{QuotaCount: &twice, QuotaPeriod: &week}, TimeType: "AllDay", CreatedAt: created},
+		{ID: uuid.New(), Title: "Swim", Frequency: "Quota", RoutineSchedule: entities.RoutineSchedule{QuotaCount: &twice, QuotaPeriod: &week}, TimeType: "AllDay", CreatedAt: created},
+	}
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC) }
+	completions := []*entities.RoutineCompletion{
+		// Gym is done for the week on Wednesday (still pending the day before), Swim only once
+		{RoutineID: routines[0].ID, CompletedAt: day(12), Status: entities.RoutineCompleted},
+		{RoutineID: routines[0].ID, CompletedAt: day(14), Status: entities.RoutineSkipped},
+		{RoutineID: routines[1].ID, CompletedAt: day(13), Status: entities.RoutineCompleted},
+	}
+
+	days := agendaRoutines(t, routines, completions, user, day(15))
+
+	assertAgendaRoutines(t, days, map[string][]string{
+		"2026-10-12": {"Gym completed", "Swim pending"},
+		"2026-10-13": {"Gym pending", "Swim completed"},
+		"2026-10-14": {"Gym skipped", "Swim pending"},
+		"2026-10-15": {"Swim pending"},
+		"2026-10-16": {"Swim pending"},
+		"2026-10-17": {"Swim pending"},
+		"2026-10-18": {"Swim pending"},
+	})
+}
+
+func TestGetAgendaCompletionWindowRoutines(t *testing.T) {
+	user := &entities.User{ID: uuid.New(), Timezone: "Europe/Berlin"}
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	wednesday, tenth := int(time.Wednesday), 10
+	week, month := entities.RoutinePeriodWeek, entities.RoutinePeriodMonth
+	routines := []*entities.Routine{
+		{ID: uuid.New(), Title: "Laundry", Frequency: "Weekly", Weekday: &wednesday, RoutineSchedule: entities.RoutineSchedule{CompletionWindow: &week}, TimeType: "AllDay", CreatedAt: created},
+		{ID: uuid.New(), Title: "Budget", Frequency: "Monthly", DayOfMonth: &tenth, RoutineSchedule: entities.RoutineSchedule{CompletionWindow: &month}, TimeType: "AllDay", CreatedAt: created},
+		{ID: uuid.New(), Title: "Plants", Frequency: "Weekly", Weekday: &wednesday, TimeType: "AllDay", CreatedAt: created},
+	}
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC) }
+	completions := []*entities.RoutineCompletion{
+		// Budget was due on the 10th and done on Thursday
+		{RoutineID: routines[1].ID, CompletedAt: day(15), Status: entities.RoutineCompleted},
+	}
+
+	tests := []struct {
+		name string
+		week time.Time
+		want map[string][]string
+	}{
+		{
+			name: "window opens on its first day",
+			week: day(5),
+			want: map[string][]string{
+				"2026-10-05": {"Laundry pending", "Budget pending"},
+				"2026-10-06": {"Laundry pending", "Budget pending"},
+				"2026-10-07": {"Laundry pending", "Budget pending", "Plants pending"},
+				"2026-10-08": {"Laundry pending", "Budget pending"},
+				"2026-10-09": {"Laundry pending", "Budget pending"},
+				"2026-10-10": {"Laundry pending", "Budget pending"},
+				"2026-10-11": {"Laundry pending", "Budget pending"},
+			},
+		},
+		{
+			name: "window closes when done",
+			week: day(12),
+			want: map[string][]string{
+				"2026-10-12": {"Laundry pending", "Budget pending"},
+				"2026-10-13": {"Laundry pending", "Budget pending"},
+				"2026-10-14": {"Laundry pending", "Budget pending", "Plants pending"},
+				"2026-10-15": {"Laundry pending", "Budget completed"},
+				"2026-10-16": {"Laundry pending"},
+				"2026-10-17": {"Laundry pending"},
+				"2026-10-18": {"Laundry pending"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertAgendaRoutines(t, agendaRoutines(t, routines, completions, user, tt.week), tt.want)
+		})
+	}
+}
+
+func assertAgendaRoutines(t *testing.T, days, want map[string][]string) {
+	t.Helper()
+	if len(days) != len(want) {
+		t.Errorf("routines on %d days %v, want %d", len(days), days, len(want))
+	}
+	for date, items := range want {
+		if len(days[date]) != len(items) {
+			t.Errorf("%s: %v, want %v", date, days[date], items)
+			continue
+		}
+		for i := range items {
+			if days[date][i] != items[i] {
+				t.Errorf("%s item %d = %q, want %q", date, i, days[date][i], items[i])
+			}
+		}
+	}
+}
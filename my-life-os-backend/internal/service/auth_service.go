@@ -149,6 +149,32 @@ func (s *authService) Logout(userID uuid.UUID) error {
 	return s.tokenRepo.DeleteRefreshTokensByUserID(userID)
 }
 
+// UpdateSettings changes the timezone and routine day rollover hour of a user
+func (s *authService) UpdateSettings(userID uuid.UUID, timezone *string, dayRolloverHour *int) (*entities.User, error) {
+	user, err := s.userRepo.FindUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if timezone != nil {
+		if _, err := time.LoadLocation(*timezone); err != nil || *timezone == "" {
+			return nil, errors.New("invalid timezone")
+		}
+		user.Timezone = *timezone
+	}
+	if dayRolloverHour != nil {
+		if *dayRolloverHour < 0 || *dayRolloverHour > 12 {
+			return nil, errors.New("dayRolloverHour must be between 0 and 12")
+		}
+		user.DayRolloverHour = *dayRolloverHour
+	}
+
+	if err := s.userRepo.UpdateUser(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
 func (s *authService) ValidateAccessToken(tokenString string) (uuid.UUID, error) {
 	claims, err := utils.ValidateAccessToken(tokenString, s.jwtSecret)
 	if err != nil {
@@ -0,0 +1,397 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/rank"
+
+	"github.com/google/uuid"
+)
+
+type boardService struct {
+	taskRepo       interfaces.TaskRepository
+	projectRepo    interfaces.ProjectRepository
+	taskService    interfaces.TaskService
+	projectService interfaces.ProjectService
+}
+
+// NewBoardService creates a new board service
+func NewBoardService(
+	taskRepo interfaces.TaskRepository,
+	projectRepo interfaces.ProjectRepository,
+	taskService interfaces.TaskService,
+	projectService interfaces.ProjectService,
+) interfaces.BoardService {
+	return &boardService{
+		taskRepo:       taskRepo,
+		projectRepo:    projectRepo,
+		taskService:    taskService,
+		projectService: projectService,
+	}
+}
+
+// GetOrderedTasks retrieves the tasks of a list in manual order.
+// Domain and status lists hold top-level tasks, project lists the tasks assigned to the project.
+func (s *boardService) GetOrderedTasks(userID uuid.UUID, list, key string) ([]*entities.Task, error) {
+	tasks, _, err := s.orderedTasks(userID, list, key)
+	return tasks, err
+}
+
+// MoveTask moves a task between two neighbours of a list (ensures user owns it).
+// Only the moved task gets a new rank, plus the never ranked tasks above its new position,
+// the whole list is ranked again only when ranks get too long.
+// Moving a task into another status column changes its status (following the workflow).
+func (s *boardService) MoveTask(userID, taskID uuid.UUID, move interfaces.TaskMove, force bool) (*interfaces.TaskMoveResult, error) {
+	if err := validateTaskList(move.List, move.Key); err != nil {
+		return nil, err
+	}
+
+	// Get task and verify ownership
+	task, err := s.taskService.GetTask(taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &interfaces.TaskMoveResult{Warnings: []string{}}
+	if move.List == entities.TaskListStatus && task.Status != move.Key {
+		if task.ParentTaskID != nil {
+			return nil, errors.New("invalid position: task is not in this list")
+		}
+		changed, err := s.taskService.SetTaskStatus(taskID, userID, move.Key, force)
+		if err != nil {
+			return nil, err
+		}
+		task, result.NextTask = changed.Task, changed.NextTask
+		result.Warnings = append(result.Warnings, changed.Warnings...)
+	}
+
+	tasks, ranks, err := s.orderedTasks(userID, move.List, move.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	// Take the task out of the list
+	others := make([]*entities.Task, 0, len(tasks))
+	for _, t := range tasks {
+		if t.ID != task.ID {
+			others = append(others, t)
+		}
+	}
+	if len(others) == len(tasks) {
+		return nil, errors.New("invalid position: task is not in this list")
+	}
+
+	position, err := movePosition(others, move)
+	if err != nil {
+		return nil, err
+	}
+
+	// Rank the never moved tasks above the new position (they keep their order below the ranked ones)
+	var changed []*entities.TaskRank
+	prev := ""
+	for _, t := range others[:position] {
+		r, ok := ranks[t.ID]
+		if !ok {
+			r, err = rank.Between(prev, "")
+			if err != nil {
+				return nil, err
+			}
+			changed = append(changed, newTaskRank(t, move, r))
+		}
+		prev = r
+	}
+	next := ""
+	if position < len(others) {
+		next = ranks[others[position].ID] // "" for unranked tasks, which sort after every rank
+	}
+
+	// Out of room between the neighbours: spread the whole list again
+	newRank, err := rank.Between(prev, next)
+	if err != nil || len(newRank) > rank.MaxLength {
+		ordered := append(append(append([]*entities.Task{}, others[:position]...), task), others[position:]...)
+		spread := rank.Spread(len(ordered))
+		changed = changed[:0]
+		for i, t := range ordered {
+			changed = append(changed, newTaskRank(t, move, spread[i]))
+		}
+		newRank = spread[position]
+	} else {
+		changed = append(changed, newTaskRank(task, move, newRank))
+	}
+
+	if err := s.taskRepo.SaveTaskRanks(changed); err != nil {
+		return nil, err
+	}
+
+	result.Task = task
+	result.Rank = newRank
+
+	// Warn about the project columns the task overfills
+	projectTasks, err := s.projectRepo.FindProjectTasksByTaskIDs([]uuid.UUID{task.ID})
+	if err != nil {
+		return nil, err
+	}
+	for _, pt := range projectTasks {
+		limit := wipLimit(&pt.Project, task.Status)
+		if limit == 0 {
+			continue
+		}
+		assigned, err := s.projectRepo.FindProjectTasks(pt.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		count := 0
+		for _, a := range assigned {
+			if a.Task.Status == task.Status {
+				count++
+			}
+		}
+		if count > limit {
+			result.Warnings = append(result.Warnings, wipWarning(pt.Project.Title, task.Status, count, limit))
+		}
+	}
+
+	return result, nil
+}
+
+// GetProjectBoard retrieves the Kanban board of a project (ensures user owns it).
+// Columns are ordered like the status lists, so moving a task on the board moves it in its status list.
+func (s *boardService) GetProjectBoard(userID, projectID uuid.UUID) (*interfaces.ProjectBoard, error) {
+	// Verify project ownership
+	project, err := s.projectService.GetProject(projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	projectTasks, err := s.projectRepo.FindProjectTasks(projectID)
+	if err != nil {
+		return nil, err
+	}
+	ranks, err := s.taskRepo.FindTaskRanks(userID, entities.TaskListStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	board := &interfaces.ProjectBoard{
+		ProjectID: project.ID,
+		Columns:   make([]*interfaces.BoardColumn, 0, len(entities.TaskStatuses)),
+		Warnings:  []string{},
+	}
+	for _, status := range entities.TaskStatuses {
+		column := &interfaces.BoardColumn{
+			Status:   status,
+			Tasks:    []*entities.Task{},
+			WIPLimit: wipLimit(project, status),
+		}
+		for _, pt := range projectTasks {
+			if pt.Task.Status == status {
+				task := pt.Task
+				column.Tasks = append(column.Tasks, &task)
+			}
+		}
+		sortByRank(column.Tasks, rankIndex(ranks, status))
+
+		column.Count = len(column.Tasks)
+		column.OverLimit = column.WIPLimit > 0 && column.Count > column.WIPLimit
+		if column.OverLimit {
+			board.Warnings = append(board.Warnings, wipWarning(project.Title, status, column.Count, column.WIPLimit))
+		}
+		board.Columns = append(board.Columns, column)
+	}
+
+	return board, nil
+}
+
+// SetProjectWIPLimits replaces the WIP limits of a project (ensures user owns it).
+// Only open statuses can be limited, a limit of 0 removes the limit of a status.
+func (s *boardService) SetProjectWIPLimits(userID, projectID uuid.UUID, limits map[string]int) (*interfaces.ProjectBoard, error) {
+	// Verify project ownership
+	if _, err := s.projectService.GetProject(projectID, userID); err != nil {
+		return nil, err
+	}
+
+	for status, limit := range limits {
+		if !entities.IsValidTaskStatus(status) {
+			return nil, errors.New("invalid status: " + status)
+		}
+		if limit < 0 {
+			return nil, errors.New("invalid WIP limit for " + status + ": must not be negative")
+		}
+		if limit > 0 && entities.IsTerminalTaskStatus(status) {
+			return nil, errors.New("invalid WIP limit: " + status + " is a closed status")
+		}
+	}
+
+	var wipLimits []*entities.ProjectWIPLimit
+	for _, status := range entities.TaskStatuses {
+		if limit := limits[status]; limit > 0 {
+			wipLimits = append(wipLimits, &entities.ProjectWIPLimit{
+				ProjectID: projectID,
+				Status:    status,
+				Limit:     limit,
+			})
+		}
+	}
+
+	if err := s.projectRepo.ReplaceWIPLimits(projectID, wipLimits); err != nil {
+		return nil, err
+	}
+
+	return s.GetProjectBoard(userID, projectID)
+}
+
+// orderedTasks retrieves the tasks of a list in manual order with their ranks (unranked tasks are missing)
+func (s *boardService) orderedTasks(userID uuid.UUID, list, key string) ([]*entities.Task, map[uuid.UUID]string, error) {
+	if err := validateTaskList(list, key); err != nil {
+		return nil, nil, err
+	}
+
+	var tasks []*entities.Task
+	var err error
+	switch list {
+	case entities.TaskListDomain, entities.TaskListStatus:
+		filter := interfaces.TaskFilter{TopLevel: true}
+		if list == entities.TaskListDomain {
+			filter.Domain = key
+		} else {
+			filter.Status = key
+		}
+		tasks, err = s.taskRepo.FindTasksByUserIDAndFilters(userID, filter, []interfaces.TaskSort{{Key: interfaces.TaskSortCreatedAt}}, nil, 0)
+		if err != nil {
+			return nil, nil, err
+		}
+	case entities.TaskListProject:
+		// Verify project ownership
+		projectID, _ := uuid.Parse(key)
+		if _, err := s.projectService.GetProject(projectID, userID); err != nil {
+			return nil, nil, err
+		}
+		projectTasks, err := s.projectRepo.FindProjectTasks(projectID)
+		if err != nil {
+			return nil, nil, err
+		}
+		tasks = make([]*entities.Task, len(projectTasks))
+		for i, pt := range projectTasks {
+			task := pt.Task
+			tasks[i] = &task
+		}
+	}
+
+	allRanks, err := s.taskRepo.FindTaskRanks(userID, list)
+	if err != nil {
+		return nil, nil, err
+	}
+	ranks := rankIndex(allRanks, key)
+	sortByRank(tasks, ranks)
+
+	return tasks, ranks, nil
+}
+
+// validateTaskList checks the context and key of a manually ordered list
+func validateTaskList(list, key string) error {
+	switch list {
+	case entities.TaskListDomain:
+		if key == "" {
+			return errors.New("invalid list key: domain is required")
+		}
+	case entities.TaskListProject:
+		if _, err := uuid.Parse(key); err != nil {
+			return errors.New("invalid list key: project ID is required")
+		}
+	case entities.TaskListStatus:
+		if !entities.IsValidTaskStatus(key) {
+			return errors.New("invalid status: " + key)
+		}
+	default:
+		return errors.New("invalid list: " + list)
+	}
+	return nil
+}
+
+// movePosition returns the index in the list (without the moved task) where the moved task goes
+func movePosition(others []*entities.Task, move interfaces.TaskMove) (int, error) {
+	indexOf := func(id uuid.UUID) int {
+		for i, t := range others {
+			if t.ID == id {
+				return i
+			}
+		}
+		return -1
+	}
+
+	position := 0
+	if move.AfterID != nil {
+		after := indexOf(*move.AfterID)
+		if after < 0 {
+			return 0, errors.New("invalid position: task to move after is not in this list")
+		}
+		position = after + 1
+	}
+	if move.BeforeID != nil {
+		before := indexOf(*move.BeforeID)
+		if before < 0 {
+			return 0, errors.New("invalid position: task to move before is not in this list")
+		}
+		if move.AfterID != nil && before != position {
+			return 0, errors.New("invalid position: tasks to move between are not next to each other")
+		}
+		position = before
+	}
+	return position, nil
+}
+
+// newTaskRank creates the rank of a task in the list of a move
+func newTaskRank(task *entities.Task, move interfaces.TaskMove, r string) *entities.TaskRank {
+	return &entities.TaskRank{
+		TaskID:  task.ID,
+		List:    move.List,
+		ListKey: move.Key,
+		UserID:  task.UserID,
+		Rank:    r,
+	}
+}
+
+// rankIndex maps task IDs to their ranks in one list
+func rankIndex(ranks []*entities.TaskRank, key string) map[uuid.UUID]string {
+	index := make(map[uuid.UUID]string)
+	for _, r := range ranks {
+		if r.ListKey == key {
+			index[r.TaskID] = r.Rank
+		}
+	}
+	return index
+}
+
+// sortByRank orders tasks by rank, tasks without a rank come last (oldest first)
+func sortByRank(tasks []*entities.Task, ranks map[uuid.UUID]string) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		ri, iRanked := ranks[tasks[i].ID]
+		rj, jRanked := ranks[tasks[j].ID]
+		if iRanked != jRanked {
+			return iRanked
+		}
+		if iRanked {
+			return ri < rj
+		}
+		return tasks[i].CreatedAt.Before(tasks[j].CreatedAt)
+	})
+}
+
+// wipLimit returns the WIP limit of a project for a status (0 = no limit)
+func wipLimit(project *entities.Project, status string) int {
+	for _, limit := range project.WIPLimits {
+		if limit.Status == status {
+			return limit.Limit
+		}
+	}
+	return 0
+}
+
+// wipWarning describes an exceeded WIP limit
+func wipWarning(project, status string, count, limit int) string {
+	return fmt.Sprintf("%s has %d tasks in %s (WIP limit %d)", project, count, status, limit)
+}
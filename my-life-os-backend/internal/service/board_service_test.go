@@ -0,0 +1,95 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/rank"
+
+	"github.com/google/uuid"
+)
+
+func (r *fakeTaskRepo) FindTaskByID(id uuid.UUID) (*entities.Task, error) {
+	return r.FindTaskWithSubtasks(id)
+}
+
+func (r *fakeTaskRepo) FindTasksByUserIDAndFilters(userID uuid.UUID, filter interfaces.TaskFilter, sort []interfaces.TaskSort, cursor *interfaces.TaskCursor, limit int) ([]*entities.Task, error) {
+	return append([]*entities.Task{}, r.tasks...), nil
+}
+
+func (r *fakeTaskRepo) FindTaskRanks(userID uuid.UUID, list string) ([]*entities.TaskRank, error) {
+	return r.ranks, nil
+}
+
+func (r *fakeTaskRepo) SaveTaskRanks(ranks []*entities.TaskRank) error {
+	r.saved = ranks
+	return nil
+}
+
+type fakeProjectRepo struct {
+	interfaces.ProjectRepository
+}
+
+func (r *fakeProjectRepo) FindProjectTasksByTaskIDs(taskIDs []uuid.UUID) ([]*entities.ProjectTask, error) {
+	return nil, nil
+}
+
+func TestMoveTaskRebalance(t *testing.T) {
+	userID := uuid.New()
+	created := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	deep := "a" + strings.Repeat("0", rank.MaxLength-2)
+
+	tests := []struct {
+		name      string
+		ranks     []string // Ranks of the tasks A, B and C
+		wantSaved int      // Ranks written (1 = only the moved task)
+	}{
+		{"room between the neighbours", []string{"a", "c", "e"}, 1},
+		{"ranks too long between the neighbours", []string{deep + "1", deep + "2", "e"}, 3},
+		{"equal neighbour ranks", []string{"a", "a", "e"}, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tasks []*entities.Task
+			var ranks []*entities.TaskRank
+			for i, title := range []string{"A", "B", "C"} {
+				task := &entities.Task{ID: uuid.New(), UserID: userID, Title: title, Domain: "Work", Status: entities.StatusTodo, CreatedAt: created.Add(time.Duration(i) * time.Hour)}
+				tasks = append(tasks, task)
+				ranks = append(ranks, &entities.TaskRank{TaskID: task.ID, List: entities.TaskListDomain, ListKey: "Work", UserID: userID, Rank: tt.ranks[i]})
+			}
+			repo := &fakeTaskRepo{tasks: tasks, ranks: ranks}
+			s := &boardService{taskRepo: repo, projectRepo: &fakeProjectRepo{}, taskService: &taskService{taskRepo: repo}}
+
+			// Move C between A and B
+			result, err := s.MoveTask(userID, tasks[2].ID, interfaces.TaskMove{List: entities.TaskListDomain, Key: "Work", AfterID: &tasks[0].ID, BeforeID: &tasks[1].ID}, false)
+			if err != nil {
+				t.Fatalf("MoveTask: %v", err)
+			}
+			if len(repo.saved) != tt.wantSaved {
+				t.Fatalf("saved %d ranks, want %d", len(repo.saved), tt.wantSaved)
+			}
+			if len(result.Rank) > rank.MaxLength {
+				t.Errorf("rank %q is longer than %d", result.Rank, rank.MaxLength)
+			}
+
+			// The saved ranks (and the untouched ones) order the list A, C, B
+			final := make(map[uuid.UUID]string)
+			for _, r := range ranks {
+				final[r.TaskID] = r.Rank
+			}
+			for _, r := range repo.saved {
+				final[r.TaskID] = r.Rank
+			}
+			if final[tasks[2].ID] != result.Rank {
+				t.Errorf("saved rank of C = %q, result rank %q", final[tasks[2].ID], result.Rank)
+			}
+			if !(final[tasks[0].ID] < final[tasks[2].ID] && final[tasks[2].ID] < final[tasks[1].ID]) {
+				t.Errorf("ranks A %q, C %q, B %q are not in order", final[tasks[0].ID], final[tasks[2].ID], final[tasks[1].ID])
+			}
+		})
+	}
+}
@@ -0,0 +1,329 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"time"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/ical"
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/rrule"
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/utils"
+
+	"github.com/google/uuid"
+)
+
+// icalUIDDomain is appended to event IDs to build globally unique iCalendar UIDs
+const icalUIDDomain = "@mylifeos"
+
+// icalTimezoneYears is how far ahead of the latest time VTIMEZONE transitions are listed (recurrences may not end)
+const icalTimezoneYears = 10
+
+type calendarFeedService struct {
+	tokenRepo interfaces.CalendarFeedTokenRepository
+	eventRepo interfaces.EventRepository
+}
+
+// NewCalendarFeedService creates a new calendar feed service
+func NewCalendarFeedService(tokenRepo interfaces.CalendarFeedTokenRepository, eventRepo interfaces.EventRepository) interfaces.CalendarFeedService {
+	return &calendarFeedService{
+		tokenRepo: tokenRepo,
+		eventRepo: eventRepo,
+	}
+}
+
+// CreateFeedToken creates a new feed token and returns the plain secret (only the hash is stored)
+func (s *calendarFeedService) CreateFeedToken(userID uuid.UUID, name string) (*entities.CalendarFeedToken, string, error) {
+	if name == "" {
+		return nil, "", errors.New("name is required")
+	}
+
+	secret, err := utils.GenerateOpaqueToken()
+	if err != nil {
+		return nil, "", errors.New("failed to generate feed token")
+	}
+
+	token := &entities.CalendarFeedToken{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Name:      name,
+		TokenHash: utils.HashOpaqueToken(secret),
+		CreatedAt: time.Now(),
+	}
+
+	if err := s.tokenRepo.CreateFeedToken(token); err != nil {
+		return nil, "", err
+	}
+
+	return token, secret, nil
+}
+
+// GetUserFeedTokens retrieves all feed tokens for a user
+func (s *calendarFeedService) GetUserFeedTokens(userID uuid.UUID) ([]*entities.CalendarFeedToken, error) {
+	return s.tokenRepo.FindFeedTokensByUserID(userID)
+}
+
+// RevokeFeedToken deletes a feed token (ensures user owns it)
+func (s *calendarFeedService) RevokeFeedToken(tokenID, userID uuid.UUID) error {
+	token, err := s.tokenRepo.FindFeedTokenByID(tokenID)
+	if err != nil {
+		return err
+	}
+
+	// Verify ownership
+	if token.UserID != userID {
+		return errors.New("unauthorized: feed token does not belong to user")
+	}
+
+	return s.tokenRepo.DeleteFeedToken(tokenID)
+}
+
+// GenerateFeed renders all events of the token's owner as an iCalendar document
+func (s *calendarFeedService) GenerateFeed(secret string) ([]byte, error) {
+	if secret == "" {
+		return nil, errors.New("invalid feed token")
+	}
+
+	token, err := s.tokenRepo.FindFeedTokenByHash(utils.HashOpaqueToken(secret))
+	if err != nil {
+		return nil, errors.New("invalid feed token")
+	}
+
+	events, err := s.eventRepo.FindEventsByUserID(token.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	cal := ical.NewComponent("VCALENDAR")
+	cal.Add("VERSION", "2.0")
+	cal.Add("PRODID", "-//MyLifeOS//Calendar Feed//EN")
+	cal.Add("CALSCALE", "GREGORIAN")
+	cal.Add("METHOD", "PUBLISH")
+	cal.Add("X-WR-CALNAME", "MyLifeOS")
+
+	now := time.Now()
+
+	for _, event := range events {
+		cal.AddComponent(s.eventToComponent(event, now))
+
+		if !event.IsRecurring {
+			continue
+		}
+
+		exceptions, err := s.eventRepo.FindEventExceptionsByEventID(event.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		vevent := cal.Components[len(cal.Components)-1]
+		for _, exception := range effectiveExceptions(exceptions) {
+			switch exception.Type {
+			case "deleted":
+				// Deleted occurrences become EXDATEs on the master event
+				addICalTime(vevent, "EXDATE", exception.OriginalDate, event.AllDay, eventLocation(event))
+
+			case "modified":
+				// Modified occurrences become overrides with a RECURRENCE-ID
+				cal.AddComponent(s.overrideToComponent(event, exception, now))
+			}
+		}
+	}
+
+	// Every TZID used needs a VTIMEZONE (RFC 5545 3.2.19)
+	addTimezones(cal, now)
+
+	// Remember when the feed was last fetched (non-critical)
+	_ = s.tokenRepo.TouchFeedToken(token.ID)
+
+	var buf bytes.Buffer
+	if err := ical.Encode(&buf, cal); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// eventToComponent converts an event into a VEVENT (including its RRULE)
+func (s *calendarFeedService) eventToComponent(event *entities.Event, now time.Time) *ical.Component {
+	start := event.StartDate
+	if event.IsRecurring {
+		start = firstRecurrenceStart(event)
+	}
+
+	vevent := ical.NewComponent("VEVENT")
+	vevent.Add("UID", eventUID(event))
+	vevent.Add("DTSTAMP", ical.FormatDateTime(now))
+	s.addEventFields(vevent, event.Title, event.Domain, start, event.EndDate, event.StartDate, event.AllDay, eventLocation(event))
+	vevent.Add("CREATED", ical.FormatDateTime(event.CreatedAt))
+	vevent.Add("LAST-MODIFIED", ical.FormatDateTime(event.UpdatedAt))
+
+	if event.IsRecurring {
+		if rrule := buildRRule(event); rrule != "" {
+			vevent.Add("RRULE", rrule)
+		}
+	}
+
+	return vevent
+}
+
+// overrideToComponent converts a modified occurrence into a VEVENT with RECURRENCE-ID
+func (s *calendarFeedService) overrideToComponent(event *entities.Event, exception *entities.EventException, now time.Time) *ical.Component {
+	occurrence := createOccurrenceAt(event, exception.OriginalDate)
+	applyModification(occurrence, exception)
+
+	vevent := ical.NewComponent("VEVENT")
+	vevent.Add("UID", eventUID(event))
+	vevent.Add("DTSTAMP", ical.FormatDateTime(now))
+	addICalTime(vevent, "RECURRENCE-ID", exception.OriginalDate, event.AllDay, eventLocation(event))
+	s.addEventFields(vevent, occurrence.Title, occurrence.Domain, occurrence.StartDate, occurrence.EndDate, occurrence.StartDate, occurrence.AllDay, eventLocation(event))
+	vevent.Add("LAST-MODIFIED", ical.FormatDateTime(exception.CreatedAt))
+
+	return vevent
+}
+
+// addEventFields adds SUMMARY, DTSTART, DTEND and CATEGORIES to a VEVENT
+func (s *calendarFeedService) addEventFields(vevent *ical.Component, title, domain string, start time.Time, endDate *time.Time, originalStart time.Time, allDay bool, loc *time.Location) {
+	vevent.Add("SUMMARY", ical.EscapeText(title))
+	addICalTime(vevent, "DTSTART", start, allDay, loc)
+
+	if allDay {
+		// DTEND is exclusive for all-day events
+		end := start.In(loc).AddDate(0, 0, 1)
+		if endDate != nil && endDate.After(originalStart) {
+			days := int(endDate.Sub(originalStart).Hours()/24) + 1
+			end = start.In(loc).AddDate(0, 0, days)
+		}
+		addICalTime(vevent, "DTEND", end, true, loc)
+	} else if endDate != nil {
+		addICalTime(vevent, "DTEND", start.Add(endDate.Sub(originalStart)), false, loc)
+	}
+
+	if domain != "" {
+		vevent.Add("CATEGORIES", ical.EscapeText(domain))
+	}
+}
+
+// eventUID returns the iCalendar UID of an event (keeps the original UID of imported events)
+func eventUID(event *entities.Event) string {
+	if event.ICalUID != nil && *event.ICalUID != "" {
+		return *event.ICalUID
+	}
+	return event.ID.String() + icalUIDDomain
+}
+
+// buildRRule returns the RRULE value of a recurring event (UNTIL must be a DATE for all-day events)
+func buildRRule(event *entities.Event) string {
+	rule := parseEventRule(event)
+	if rule == nil {
+		return ""
+	}
+
+	if event.AllDay && rule.Until != nil {
+		until := *rule.Until
+		rule.Until = nil
+		return rule.String() + ";UNTIL=" + ical.FormatDate(until)
+	}
+
+	return rule.String()
+}
+
+// parseEventRule parses the stored recurrence rule of an event (nil if missing or invalid)
+func parseEventRule(event *entities.Event) *rrule.Rule {
+	if event.RecurrenceRule == nil {
+		return nil
+	}
+
+	rule, err := rrule.Parse(*event.RecurrenceRule)
+	if err != nil {
+		return nil
+	}
+	return rule
+}
+
+// firstRecurrenceStart returns the first actual occurrence of a recurring event.
+// Events may start on a day that does not match their rule (e.g. a weekly event on Mondays created on a Sunday),
+// but RFC 5545 requires DTSTART to be the first instance.
+func firstRecurrenceStart(event *entities.Event) time.Time {
+	rule := parseEventRule(event)
+	if rule == nil {
+		return event.StartDate
+	}
+
+	if first := rule.First(event.StartDate.In(eventLocation(event))); first != nil {
+		return *first
+	}
+	return event.StartDate
+}
+
+// createOccurrenceAt creates a copy of an event moved to the given start date
+func createOccurrenceAt(event *entities.Event, start time.Time) *entities.Event {
+	occurrence := *event
+	occurrence.StartDate = start
+	if event.EndDate != nil {
+		end := start.Add(event.EndDate.Sub(event.StartDate))
+		occurrence.EndDate = &end
+	}
+	return &occurrence
+}
+
+// addICalTime adds a DATE or DATE-TIME property in the event's timezone.
+// Times in zones other than UTC get a TZID, so clients expand recurrences in local time across DST changes.
+func addICalTime(vevent *ical.Component, name string, t time.Time, allDay bool, loc *time.Location) {
+	local := t.In(loc)
+
+	switch {
+	case allDay:
+		vevent.Add(name, ical.FormatDate(local), ical.Param{Name: "VALUE", Value: "DATE"})
+	case loc == time.UTC:
+		vevent.Add(name, ical.FormatDateTime(t))
+	default:
+		vevent.Add(name, local.Format(ical.DateTimeLayout), ical.Param{Name: "TZID", Value: loc.String()})
+	}
+}
+
+// addTimezones adds a VTIMEZONE for every TZID used by the events of a calendar (before the events)
+func addTimezones(cal *ical.Component, now time.Time) {
+	type zoneRange struct {
+		loc         *time.Location
+		first, last time.Time
+	}
+	var zones []*zoneRange
+	byName := make(map[string]*zoneRange)
+
+	for _, vevent := range cal.Components {
+		for _, prop := range vevent.Properties {
+			tzid := prop.Param("TZID")
+			if tzid == "" {
+				continue
+			}
+			loc, err := time.LoadLocation(tzid)
+			if err != nil {
+				continue
+			}
+			t, err := time.ParseInLocation(ical.DateTimeLayout, prop.Value, loc)
+			if err != nil {
+				continue
+			}
+
+			zone, ok := byName[tzid]
+			if !ok {
+				zone = &zoneRange{loc: loc, first: t, last: now}
+				byName[tzid] = zone
+				zones = append(zones, zone)
+			}
+			if t.Before(zone.first) {
+				zone.first = t
+			}
+			if t.After(zone.last) {
+				zone.last = t
+			}
+		}
+	}
+
+	timezones := make([]*ical.Component, 0, len(zones)+len(cal.Components))
+	for _, zone := range zones {
+		timezones = append(timezones, ical.Timezone(zone.loc, zone.first, zone.last.AddDate(icalTimezoneYears, 0, 0)))
+	}
+	cal.Components = append(timezones, cal.Components...)
+}
@@ -0,0 +1,597 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/ical"
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/rrule"
+
+	"github.com/google/uuid"
+)
+
+type calendarImportService struct {
+	eventService interfaces.EventService
+	eventRepo    interfaces.EventRepository
+	domainRepo   interfaces.DomainRepository
+}
+
+// NewCalendarImportService creates a new calendar import service
+func NewCalendarImportService(eventService interfaces.EventService, eventRepo interfaces.EventRepository, domainRepo interfaces.DomainRepository) interfaces.CalendarImportService {
+	return &calendarImportService{
+		eventService: eventService,
+		eventRepo:    eventRepo,
+		domainRepo:   domainRepo,
+	}
+}
+
+// importedEvent holds a parsed VEVENT before it is validated and stored
+type importedEvent struct {
+	uid        string
+	event      *entities.Event
+	exceptions []*entities.EventException
+	result     *interfaces.CalendarImportResult
+}
+
+// importRun tracks the events accepted so far in one import.
+// A dry run stores nothing, so later events are checked against this instead of the database in both modes.
+type importRun struct {
+	uids     map[string]bool
+	ids      map[uuid.UUID]bool
+	accepted []*importedEvent
+}
+
+// importOverlap is an occurrence an imported event overlaps
+type importOverlap struct {
+	title string
+	start time.Time
+}
+
+// importInterval is the time a timed occurrence blocks
+type importInterval struct {
+	start, end time.Time
+}
+
+// ImportICS parses an .ics file and creates its events for a user
+func (s *calendarImportService) ImportICS(userID uuid.UUID, r io.Reader, defaultDomain string, dryRun bool) (*interfaces.CalendarImportReport, error) {
+	// CATEGORIES are matched against the user's active domains
+	domains, err := userDomains(s.domainRepo, userID, false)
+	if err != nil {
+		return nil, err
+	}
+	defaultDomain, err = resolveDomain(s.domainRepo, userID, defaultDomain)
+	if err != nil {
+		return nil, errors.New("invalid default domain")
+	}
+
+	cal, err := ical.Decode(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid calendar file: %w", err)
+	}
+	if cal.Name != "VCALENDAR" {
+		return nil, errors.New("invalid calendar file: missing VCALENDAR")
+	}
+
+	report := &interfaces.CalendarImportReport{DryRun: dryRun}
+	zones := ical.NewTimezones(cal)
+	run := &importRun{uids: make(map[string]bool), ids: make(map[uuid.UUID]bool)}
+
+	// Group VEVENTs by UID: the master (no RECURRENCE-ID) and its overrides
+	var order []string
+	masters := make(map[string]*ical.Component)
+	overrides := make(map[string][]*ical.Component)
+
+	for _, comp := range cal.Components {
+		if comp.Name != "VEVENT" {
+			continue
+		}
+
+		uid := ""
+		if p := comp.Get("UID"); p != nil {
+			uid = strings.TrimSpace(p.Value)
+		}
+		if uid == "" {
+			// UID is required by RFC 5545, but be lenient with sloppy exporters
+			uid = uuid.New().String() + icalUIDDomain
+		}
+
+		if comp.Get("RECURRENCE-ID") != nil {
+			overrides[uid] = append(overrides[uid], comp)
+			if _, seen := masters[uid]; !seen {
+				if !containsString(order, uid) {
+					order = append(order, uid)
+				}
+			}
+			continue
+		}
+
+		if _, exists := masters[uid]; exists {
+			addImportResult(report, &interfaces.CalendarImportResult{
+				UID:    uid,
+				Title:  componentText(comp, "SUMMARY"),
+				Status: interfaces.ImportStatusSkipped,
+				Reason: "duplicate UID in file",
+			})
+			continue
+		}
+
+		masters[uid] = comp
+		if !containsString(order, uid) {
+			order = append(order, uid)
+		}
+	}
+
+	for _, uid := range order {
+		master, ok := masters[uid]
+		if !ok {
+			for _, comp := range overrides[uid] {
+				addImportResult(report, &interfaces.CalendarImportResult{
+					UID:    uid,
+					Title:  componentText(comp, "SUMMARY"),
+					Status: interfaces.ImportStatusSkipped,
+					Reason: "occurrence override without its recurring event",
+				})
+			}
+			continue
+		}
+
+		imported := s.parseEvent(userID, uid, master, overrides[uid], zones, defaultDomain, domains)
+		if imported.result.Status != interfaces.ImportStatusSkipped {
+			s.storeEvent(userID, imported, run, dryRun)
+		}
+		addImportResult(report, imported.result)
+	}
+
+	return report, nil
+}
+
+// parseEvent converts a master VEVENT and its overrides into an event with exceptions
+func (s *calendarImportService) parseEvent(userID uuid.UUID, uid string, master *ical.Component, overrides []*ical.Component,
+	zones *ical.Timezones, defaultDomain string, domains []*entities.Domain) *importedEvent {
+	result := &interfaces.CalendarImportResult{
+		UID:    uid,
+		Title:  componentText(master, "SUMMARY"),
+		Status: interfaces.ImportStatusCreated,
+	}
+	imported := &importedEvent{uid: uid, result: result}
+
+	if strings.EqualFold(componentText(master, "STATUS"), "CANCELLED") {
+		result.Status = interfaces.ImportStatusSkipped
+		result.Reason = "event is cancelled"
+		return imported
+	}
+
+	if result.Title == "" {
+		result.Title = "Untitled event"
+		result.Warnings = append(result.Warnings, "missing SUMMARY, using \"Untitled event\"")
+	}
+
+	start, end, allDay, err := parseEventTimes(master, zones)
+	if err != nil {
+		result.Status = interfaces.ImportStatusSkipped
+		result.Reason = err.Error()
+		return imported
+	}
+
+	// Times in a zone that cannot be resolved are read as UTC and may be off by hours
+	for _, tzid := range unknownTimezones(append([]*ical.Component{master}, overrides...), zones) {
+		result.Status = interfaces.ImportStatusDowngraded
+		result.Warnings = append(result.Warnings, "unknown timezone \""+tzid+"\", times imported as UTC")
+	}
+
+	domain, matched := matchDomain(master, domains, defaultDomain)
+	if !matched {
+		result.Warnings = append(result.Warnings, "no matching domain in CATEGORIES, using \""+defaultDomain+"\"")
+	}
+
+	icalUID := uid
+	event := &entities.Event{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Title:     result.Title,
+		StartDate: start,
+		EndDate:   end,
+		AllDay:    allDay,
+		Timezone:  componentTimezone(master, zones),
+		Domain:    domain,
+		ICalUID:   &icalUID,
+	}
+	imported.event = event
+
+	// Recurrence
+	rrules := master.GetAll("RRULE")
+	if len(rrules) == 0 {
+		if len(master.GetAll("RDATE")) > 0 {
+			result.Status = interfaces.ImportStatusDowngraded
+			result.Warnings = append(result.Warnings, "RDATE is not supported, imported the first occurrence only")
+		}
+		return imported
+	}
+
+	unsupported := s.applyRRule(event, rrules[0].Value)
+	if len(rrules) > 1 {
+		unsupported = append(unsupported, "multiple RRULEs")
+	}
+	if len(master.GetAll("RDATE")) > 0 {
+		unsupported = append(unsupported, "RDATE")
+	}
+
+	if len(unsupported) > 0 {
+		// Cannot express the rule - keep the first occurrence as a single event
+		event.IsRecurring = false
+		setRecurrence(event, nil)
+		result.Status = interfaces.ImportStatusDowngraded
+		result.Warnings = append(result.Warnings,
+			"unsupported recurrence ("+strings.Join(unsupported, ", ")+"), imported the first occurrence only")
+		return imported
+	}
+
+	// Deleted occurrences
+	for _, prop := range master.GetAll("EXDATE") {
+		for _, value := range strings.Split(prop.Value, ",") {
+			date, _, err := zones.ParseTimeValue(value, prop.Param("VALUE"), prop.Param("TZID"))
+			if err != nil {
+				result.Warnings = append(result.Warnings, "ignored invalid EXDATE "+value)
+				continue
+			}
+			imported.exceptions = append(imported.exceptions, newException(event, date.UTC(), "deleted"))
+		}
+	}
+
+	// Modified occurrences
+	for _, comp := range overrides {
+		exception, err := s.parseOverride(event, comp, zones, domain, domains)
+		if err != nil {
+			result.Warnings = append(result.Warnings, "ignored occurrence override: "+err.Error())
+			continue
+		}
+		imported.exceptions = append(imported.exceptions, exception)
+	}
+
+	result.Exceptions = len(imported.exceptions)
+	return imported
+}
+
+// applyRRule sets the event's recurrence rule and returns the parts it cannot express
+func (s *calendarImportService) applyRRule(event *entities.Event, value string) []string {
+	rule, err := rrule.Parse(value)
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	event.IsRecurring = true
+	setRecurrence(event, rule)
+
+	return nil
+}
+
+// parseOverride converts a VEVENT with RECURRENCE-ID into an exception of the master event
+func (s *calendarImportService) parseOverride(event *entities.Event, comp *ical.Component, zones *ical.Timezones, masterDomain string, domains []*entities.Domain) (*entities.EventException, error) {
+	originalDate, _, err := zones.ParseTime(comp.Get("RECURRENCE-ID"))
+	if err != nil {
+		return nil, errors.New("invalid RECURRENCE-ID")
+	}
+
+	if strings.EqualFold(componentText(comp, "STATUS"), "CANCELLED") {
+		return newException(event, originalDate.UTC(), "deleted"), nil
+	}
+
+	exception := newException(event, originalDate.UTC(), "modified")
+
+	if title := componentText(comp, "SUMMARY"); title != "" && title != event.Title {
+		exception.ModifiedTitle = &title
+	}
+
+	if comp.Get("DTSTART") != nil {
+		start, end, allDay, err := parseEventTimes(comp, zones)
+		if err != nil {
+			return nil, err
+		}
+		if !start.Equal(exception.OriginalDate) {
+			exception.ModifiedStartDate = &start
+		}
+		if end != nil {
+			exception.ModifiedEndDate = end
+		}
+		if allDay != event.AllDay {
+			exception.ModifiedAllDay = &allDay
+		}
+	}
+
+	if domain, matched := matchDomain(comp, domains, masterDomain); matched && domain != masterDomain {
+		exception.ModifiedDomain = &domain
+	}
+
+	return exception, nil
+}
+
+// storeEvent validates an imported event and creates it (unless this is a dry run)
+func (s *calendarImportService) storeEvent(userID uuid.UUID, imported *importedEvent, run *importRun, dryRun bool) {
+	result := imported.result
+	event := imported.event
+
+	// Duplicate detection by UID (including events accepted earlier in this run)
+	if run.uids[imported.uid] {
+		result.Status = interfaces.ImportStatusSkipped
+		result.Reason = "event with this UID was already imported"
+		return
+	}
+	if existing, err := s.eventRepo.FindEventByICalUID(userID, imported.uid); err == nil && existing != nil {
+		result.Status = interfaces.ImportStatusSkipped
+		result.Reason = "event with this UID was already imported"
+		return
+	}
+
+	// Same validation as a manually created event, but overlaps are real history: imported and reported
+	err := s.eventService.ValidateEvent(userID, event.Title, event.StartDate, event.EndDate, event.AllDay,
+		event.Timezone, event.Domain, event.IsRecurring, event.RecurrenceRule, true)
+	if err != nil {
+		result.Status = interfaces.ImportStatusSkipped
+		result.Reason = err.Error()
+		return
+	}
+
+	overlaps, err := s.findOverlaps(userID, imported, run)
+	if err != nil {
+		result.Warnings = append(result.Warnings, "could not check for overlapping events")
+	} else if warning := overlapWarning(overlaps, eventLocation(event)); warning != "" {
+		result.Warnings = append(result.Warnings, warning)
+	}
+
+	if dryRun {
+		run.accept(imported)
+		return
+	}
+
+	event.CreatedAt = time.Now()
+	event.UpdatedAt = time.Now()
+	// All or nothing: a series without its exceptions would bring back cancelled or moved occurrences
+	if err := s.eventRepo.CreateEventWithExceptions(event, imported.exceptions); err != nil {
+		result.Status = interfaces.ImportStatusSkipped
+		result.Reason = "failed to create event"
+		return
+	}
+
+	run.accept(imported)
+	eventID := event.ID
+	result.EventID = &eventID
+}
+
+// accept records an event that is (or in a dry run would be) created
+func (r *importRun) accept(imported *importedEvent) {
+	r.uids[imported.uid] = true
+	r.ids[imported.event.ID] = true
+	r.accepted = append(r.accepted, imported)
+}
+
+// findOverlaps returns the occurrences an imported event overlaps: stored events and the events accepted
+// earlier in the run (compared in memory, so a dry run reports the same as the real import)
+func (s *calendarImportService) findOverlaps(userID uuid.UUID, imported *importedEvent, run *importRun) ([]importOverlap, error) {
+	event := imported.event
+	conflicts, err := s.eventService.FindConflicts(userID, interfaces.ConflictQuery{
+		StartDate:      event.StartDate,
+		EndDate:        event.EndDate,
+		AllDay:         event.AllDay,
+		Timezone:       event.Timezone,
+		RecurrenceRule: event.RecurrenceRule,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var overlaps []importOverlap
+	for _, conflict := range conflicts {
+		if !run.ids[conflict.EventID] {
+			overlaps = append(overlaps, importOverlap{title: conflict.Title, start: conflict.OccurrenceDate})
+		}
+	}
+
+	// Like FindConflicts: the planned occurrences without exceptions, the existing ones with
+	planned := importIntervals(event, nil)
+	for _, other := range run.accepted {
+		for _, existing := range importIntervals(other.event, other.exceptions) {
+			for _, interval := range planned {
+				if interval.start.Before(existing.end) && existing.start.Before(interval.end) {
+					overlaps = append(overlaps, importOverlap{title: other.event.Title, start: existing.start})
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(overlaps, func(i, j int) bool {
+		return overlaps[i].start.Before(overlaps[j].start)
+	})
+	return overlaps, nil
+}
+
+// importIntervals returns the timed occurrences of an event within the conflict horizon (with its exceptions applied)
+func importIntervals(event *entities.Event, exceptions []*entities.EventException) []importInterval {
+	if event.AllDay || event.EndDate == nil || !event.EndDate.After(event.StartDate) {
+		return nil
+	}
+	duration := event.EndDate.Sub(event.StartDate)
+
+	starts := []time.Time{event.StartDate}
+	if event.IsRecurring && event.RecurrenceRule != nil {
+		if rule, err := rrule.Parse(*event.RecurrenceRule); err == nil {
+			dtstart := event.StartDate.In(eventLocation(event))
+			starts = rule.Between(dtstart, dtstart, dtstart.Add(conflictHorizon))
+			if len(starts) > maxConflictOccurrences {
+				starts = starts[:maxConflictOccurrences]
+			}
+		}
+	}
+
+	modified := make(map[time.Time]*entities.EventException)
+	for _, exception := range exceptions {
+		modified[exception.OriginalDate.UTC()] = exception
+	}
+
+	var intervals []importInterval
+	for _, start := range starts {
+		interval := importInterval{start: start, end: start.Add(duration)}
+		if exception, ok := modified[start.UTC()]; ok {
+			if exception.Type == "deleted" || (exception.ModifiedAllDay != nil && *exception.ModifiedAllDay) {
+				continue
+			}
+			if exception.ModifiedStartDate != nil {
+				interval.start = *exception.ModifiedStartDate
+				interval.end = interval.start.Add(duration)
+			}
+			if exception.ModifiedEndDate != nil {
+				interval.end = *exception.ModifiedEndDate
+			}
+		}
+		intervals = append(intervals, interval)
+	}
+	return intervals
+}
+
+// overlapWarning describes the overlaps of an imported event ("" if there are none)
+func overlapWarning(overlaps []importOverlap, loc *time.Location) string {
+	if len(overlaps) == 0 {
+		return ""
+	}
+
+	first := overlaps[0]
+	at := first.start.In(loc).Format("2006-01-02 15:04")
+	if len(overlaps) == 1 {
+		return fmt.Sprintf("overlaps \"%s\" on %s", first.title, at)
+	}
+	return fmt.Sprintf("overlaps %d occurrences, first \"%s\" on %s", len(overlaps), first.title, at)
+}
+
+// addImportResult appends a result to the report and updates the counters
+func addImportResult(report *interfaces.CalendarImportReport, result *interfaces.CalendarImportResult) {
+	report.Results = append(report.Results, result)
+	switch result.Status {
+	case interfaces.ImportStatusCreated:
+		report.Created++
+	case interfaces.ImportStatusSkipped:
+		report.Skipped++
+	case interfaces.ImportStatusDowngraded:
+		report.Downgraded++
+	}
+}
+
+// parseEventTimes reads DTSTART and DTEND/DURATION of a VEVENT
+func parseEventTimes(comp *ical.Component, zones *ical.Timezones) (time.Time, *time.Time, bool, error) {
+	dtstart := comp.Get("DTSTART")
+	if dtstart == nil {
+		return time.Time{}, nil, false, errors.New("missing DTSTART")
+	}
+
+	start, allDay, err := zones.ParseTime(dtstart)
+	if err != nil {
+		return time.Time{}, nil, false, errors.New("invalid DTSTART")
+	}
+	start = start.UTC()
+
+	var end time.Time
+	hasEnd := false
+	if dtend := comp.Get("DTEND"); dtend != nil {
+		end, _, err = zones.ParseTime(dtend)
+		if err != nil {
+			return time.Time{}, nil, false, errors.New("invalid DTEND")
+		}
+		end = end.UTC()
+		hasEnd = true
+	} else if duration := comp.Get("DURATION"); duration != nil {
+		d, err := ical.ParseDuration(duration.Value)
+		if err != nil {
+			return time.Time{}, nil, false, errors.New("invalid DURATION")
+		}
+		end = start.Add(d)
+		hasEnd = true
+	}
+
+	if allDay {
+		// DTEND is exclusive for all-day events; single-day events have no end date
+		if hasEnd && end.Sub(start) > 24*time.Hour {
+			lastDay := end.AddDate(0, 0, -1)
+			return start, &lastDay, true, nil
+		}
+		return start, nil, true, nil
+	}
+
+	if !hasEnd {
+		return start, nil, false, nil
+	}
+	return start, &end, false, nil
+}
+
+// componentTimezone returns the IANA zone of DTSTART's TZID, otherwise UTC
+// (UTC, floating times and unknown zones are imported as UTC)
+func componentTimezone(comp *ical.Component, zones *ical.Timezones) string {
+	if dtstart := comp.Get("DTSTART"); dtstart != nil {
+		if loc, ok := zones.Location(dtstart.Param("TZID")); ok {
+			return loc.String()
+		}
+	}
+	return "UTC"
+}
+
+// unknownTimezones returns the TZIDs of the date properties that cannot be resolved, each once
+func unknownTimezones(comps []*ical.Component, zones *ical.Timezones) []string {
+	var unknown []string
+	for _, comp := range comps {
+		for _, name := range []string{"DTSTART", "DTEND", "RECURRENCE-ID", "EXDATE"} {
+			for _, prop := range comp.GetAll(name) {
+				tzid := prop.Param("TZID")
+				if _, ok := zones.Location(tzid); !ok && !containsString(unknown, tzid) {
+					unknown = append(unknown, tzid)
+				}
+			}
+		}
+	}
+	return unknown
+}
+
+// matchDomain returns the first CATEGORIES value that is one of the user's domains
+func matchDomain(comp *ical.Component, domains []*entities.Domain, fallback string) (string, bool) {
+	for _, prop := range comp.GetAll("CATEGORIES") {
+		for _, category := range strings.Split(prop.Value, ",") {
+			category = strings.TrimSpace(ical.UnescapeText(category))
+			if domain := findDomainByName(domains, category); domain != nil {
+				return domain.Name, true
+			}
+		}
+	}
+	return fallback, false
+}
+
+// newException creates an exception for an occurrence of an event
+func newException(event *entities.Event, originalDate time.Time, exceptionType string) *entities.EventException {
+	return &entities.EventException{
+		ID:           uuid.New(),
+		EventID:      event.ID,
+		UserID:       event.UserID,
+		OriginalDate: originalDate,
+		Type:         exceptionType,
+		CreatedAt:    time.Now(),
+	}
+}
+
+// componentText returns the unescaped value of a TEXT property, or ""
+func componentText(comp *ical.Component, name string) string {
+	if p := comp.Get(name); p != nil {
+		return strings.TrimSpace(ical.UnescapeText(p.Value))
+	}
+	return ""
+}
+
+// containsString checks if a slice contains a string
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,228 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/ical"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func (r *fakeEventRepo) CreateEventWithExceptions(event *entities.Event, exceptions []*entities.EventException) error {
+	if r.failCreate {
+		return errors.New("insert failed")
+	}
+	r.events = append(r.events, event)
+	r.exceptions = append(r.exceptions, exceptions...)
+	return nil
+}
+
+func (r *fakeEventRepo) FindEventByICalUID(userID uuid.UUID, icalUID string) (*entities.Event, error) {
+	for _, event := range r.events {
+		if event.ICalUID != nil && *event.ICalUID == icalUID {
+			return event, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+type fakeDomainRepo struct {
+	interfaces.DomainRepository
+	domains []*entities.Domain
+}
+
+func (r *fakeDomainRepo) FindDomainsByUserID(userID uuid.UUID, includeArchived bool) ([]*entities.Domain, error) {
+	return r.domains, nil
+}
+
+func TestParseEventTimezones(t *testing.T) {
+	tests := []struct {
+		name         string
+		tzid         string
+		wantTimezone string
+		wantStart    time.Time
+		wantStatus   string
+	}{
+		{
+			name:         "IANA zone",
+			tzid:         "Europe/Berlin",
+			wantTimezone: "Europe/Berlin",
+			wantStart:    time.Date(2026, 10, 26, 8, 0, 0, 0, time.UTC),
+			wantStatus:   interfaces.ImportStatusCreated,
+		},
+		{
+			name:         "Windows zone",
+			tzid:         "W. Europe Standard Time",
+			wantTimezone: "Europe/Berlin",
+			wantStart:    time.Date(2026, 10, 26, 8, 0, 0, 0, time.UTC),
+			wantStatus:   interfaces.ImportStatusCreated,
+		},
+		{
+			name:         "VTIMEZONE with X-LIC-LOCATION",
+			tzid:         "Office",
+			wantTimezone: "America/New_York",
+			wantStart:    time.Date(2026, 10, 26, 13, 0, 0, 0, time.UTC),
+			wantStatus:   interfaces.ImportStatusCreated,
+		},
+		{
+			name:         "unknown zone",
+			tzid:         "Somewhere Standard Time",
+			wantTimezone: "UTC",
+			wantStart:    time.Date(2026, 10, 26, 9, 0, 0, 0, time.UTC),
+			wantStatus:   interfaces.ImportStatusDowngraded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cal, err := ical.Decode(strings.NewReader(strings.Join([]string{
+				"BEGIN:VCALENDAR",
+				"BEGIN:VTIMEZONE",
+				"TZID:Office",
+				"X-LIC-LOCATION:America/New_York",
+				"END:VTIMEZONE",
+				"BEGIN:VEVENT",
+				"UID:meeting@example.com",
+				"SUMMARY:Meeting",
+				"DTSTART;TZID=" + tt.tzid + ":20261026T090000",
+				"DTEND;TZID=" + tt.tzid + ":20261026T100000",
+				"END:VEVENT",
+				"END:VCALENDAR",
+			}, "\r\n")))
+			if err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			master := cal.Components[1]
+
+			s := &calendarImportService{}
+			imported := s.parseEvent(uuid.New(), "meeting@example.com", master, nil, ical.NewTimezones(cal), "Personal", nil)
+
+			if imported.result.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s (warnings %v)", imported.result.Status, tt.wantStatus, imported.result.Warnings)
+			}
+			if imported.event.Timezone != tt.wantTimezone {
+				t.Errorf("timezone = %s, want %s", imported.event.Timezone, tt.wantTimezone)
+			}
+			if !imported.event.StartDate.Equal(tt.wantStart) {
+				t.Errorf("start = %s, want %s", imported.event.StartDate, tt.wantStart)
+			}
+			warned := len(imported.result.Warnings) > 0 && strings.HasPrefix(imported.result.Warnings[0], "unknown timezone")
+			if warned != (tt.wantStatus == interfaces.ImportStatusDowngraded) {
+				t.Errorf("warnings = %v", imported.result.Warnings)
+			}
+		})
+	}
+}
+
+func TestImportICSOverlaps(t *testing.T) {
+	userID := uuid.New()
+	file := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"UID:planning@example.com",
+		"SUMMARY:Planning",
+		"CATEGORIES:Personal",
+		"DTSTART:20261019T090000Z",
+		"DTEND:20261019T100000Z",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:review@example.com",
+		"SUMMARY:Review",
+		"CATEGORIES:Personal",
+		"DTSTART:20261019T093000Z",
+		"DTEND:20261019T103000Z",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:lunch@example.com",
+		"SUMMARY:Lunch",
+		"CATEGORIES:Personal",
+		"DTSTART:20261019T120000Z",
+		"DTEND:20261019T130000Z",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	type row struct {
+		status   string
+		warnings string
+	}
+	want := []row{
+		{interfaces.ImportStatusCreated, ""},
+		{interfaces.ImportStatusCreated, `overlaps 2 occurrences, first "Planning" on 2026-10-19 09:00`},
+		{interfaces.ImportStatusCreated, ""},
+	}
+
+	for _, dryRun := range []bool{true, false} {
+		// An existing event overlaps the review, the planning from the same file too
+		end := time.Date(2026, 10, 19, 11, 0, 0, 0, time.UTC)
+		existing := &entities.Event{ID: uuid.New(), UserID: userID, Title: "1:1", StartDate: time.Date(2026, 10, 19, 10, 15, 0, 0, time.UTC), EndDate: &end, Timezone: "UTC"}
+		eventRepo := &fakeEventRepo{events: []*entities.Event{existing}}
+		domainRepo := &fakeDomainRepo{domains: []*entities.Domain{{ID: uuid.New(), UserID: userID, Name: "Personal"}}}
+		users := &fakeUserRepo{user: &entities.User{ID: userID, Timezone: "UTC"}}
+		s := &calendarImportService{
+			eventService: &eventService{eventRepo: eventRepo, userRepo: users, domainRepo: domainRepo},
+			eventRepo:    eventRepo,
+			domainRepo:   domainRepo,
+		}
+
+		report, err := s.ImportICS(userID, strings.NewReader(file), "Personal", dryRun)
+		if err != nil {
+			t.Fatalf("dry run %t: ImportICS: %v", dryRun, err)
+		}
+		if len(report.Results) != len(want) {
+			t.Fatalf("dry run %t: got %d results, want %d", dryRun, len(report.Results), len(want))
+		}
+		for i, result := range report.Results {
+			got := row{result.Status, strings.Join(result.Warnings, "; ")}
+			if got != want[i] {
+				t.Errorf("dry run %t: %s = %+v, want %+v", dryRun, result.Title, got, want[i])
+			}
+		}
+		if created := len(eventRepo.events) - 1; dryRun && created != 0 || !dryRun && created != 3 {
+			t.Errorf("dry run %t: created %d events", dryRun, created)
+		}
+	}
+}
+
+func TestImportICSSkipsEventWhenStoringFails(t *testing.T) {
+	userID := uuid.New()
+	file := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"UID:standup@example.com",
+		"SUMMARY:Standup",
+		"CATEGORIES:Personal",
+		"DTSTART:20261019T090000Z",
+		"DTEND:20261019T091500Z",
+		"RRULE:FREQ=DAILY;COUNT=5",
+		"EXDATE:20261020T090000Z",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	eventRepo := &fakeEventRepo{failCreate: true}
+	domainRepo := &fakeDomainRepo{domains: []*entities.Domain{{ID: uuid.New(), UserID: userID, Name: "Personal"}}}
+	s := &calendarImportService{
+		eventService: &eventService{eventRepo: eventRepo, userRepo: &fakeUserRepo{user: &entities.User{ID: userID}}, domainRepo: domainRepo},
+		eventRepo:    eventRepo,
+		domainRepo:   domainRepo,
+	}
+
+	report, err := s.ImportICS(userID, strings.NewReader(file), "Personal", false)
+	if err != nil {
+		t.Fatalf("ImportICS: %v", err)
+	}
+	result := report.Results[0]
+	if result.Status != interfaces.ImportStatusSkipped || result.EventID != nil || report.Skipped != 1 {
+		t.Errorf("result = %+v, want skipped without event", result)
+	}
+	if len(eventRepo.events) != 0 || len(eventRepo.exceptions) != 0 {
+		t.Errorf("stored %d events and %d exceptions, want none", len(eventRepo.events), len(eventRepo.exceptions))
+	}
+}
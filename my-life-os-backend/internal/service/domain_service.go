@@ -0,0 +1,262 @@
+package service
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
+
+	"github.com/google/uuid"
+)
+
+// domainColorPattern matches hex colors like #3b82f6
+var domainColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+type domainService struct {
+	domainRepo interfaces.DomainRepository
+}
+
+// NewDomainService creates a new domain service
+func NewDomainService(domainRepo interfaces.DomainRepository) interfaces.DomainService {
+	return &domainService{
+		domainRepo: domainRepo,
+	}
+}
+
+// CreateDomain creates a new domain
+func (s *domainService) CreateDomain(userID uuid.UUID, name, color, icon string) (*entities.Domain, error) {
+	name = strings.TrimSpace(name)
+
+	// Validate required fields
+	if name == "" {
+		return nil, errors.New("name is required")
+	}
+	if color == "" {
+		color = entities.DefaultDomainColor
+	}
+	if !domainColorPattern.MatchString(color) {
+		return nil, errors.New("invalid color")
+	}
+
+	// Names are unique per user (case-insensitive)
+	existing, err := userDomains(s.domainRepo, userID, true)
+	if err != nil {
+		return nil, err
+	}
+	if findDomainByName(existing, name) != nil {
+		return nil, errors.New("a domain with this name already exists")
+	}
+
+	// Create domain
+	domain := &entities.Domain{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Name:      name,
+		Color:     color,
+		Icon:      icon,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	err = s.domainRepo.CreateDomain(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain, nil
+}
+
+// GetDomain retrieves a single domain (ensures user owns it)
+func (s *domainService) GetDomain(domainID, userID uuid.UUID) (*entities.Domain, error) {
+	domain, err := s.domainRepo.FindDomainByID(domainID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify ownership
+	if domain.UserID != userID {
+		return nil, errors.New("unauthorized: domain does not belong to user")
+	}
+
+	return domain, nil
+}
+
+// GetUserDomains retrieves the domains of a user (the defaults are created on first use)
+func (s *domainService) GetUserDomains(userID uuid.UUID, includeArchived bool) ([]*entities.Domain, error) {
+	return userDomains(s.domainRepo, userID, includeArchived)
+}
+
+// UpdateDomain updates a domain, renaming rewrites all tasks and events using it
+func (s *domainService) UpdateDomain(domainID, userID uuid.UUID, name, color, icon string, archived bool) (*entities.Domain, error) {
+	// Get domain and verify ownership
+	domain, err := s.GetDomain(domainID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	name = strings.TrimSpace(name)
+
+	// Validate required fields
+	if name == "" {
+		return nil, errors.New("name is required")
+	}
+	if color == "" {
+		color = domain.Color
+	}
+	if !domainColorPattern.MatchString(color) {
+		return nil, errors.New("invalid color")
+	}
+
+	// Renaming onto another domain is a merge
+	if name != domain.Name {
+		existing, err := userDomains(s.domainRepo, userID, true)
+		if err != nil {
+			return nil, err
+		}
+		if other := findDomainByName(existing, name); other != nil && other.ID != domain.ID {
+			return nil, errors.New("a domain with this name already exists, merge the domains instead")
+		}
+	}
+
+	oldName := domain.Name
+
+	// Update fields
+	domain.Name = name
+	domain.Color = color
+	domain.Icon = icon
+	domain.Archived = archived
+	domain.UpdatedAt = time.Now()
+
+	err = s.domainRepo.UpdateDomain(domain, oldName)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain, nil
+}
+
+// MergeDomain moves all tasks and events of a domain into another one and deletes it
+func (s *domainService) MergeDomain(sourceID, targetID, userID uuid.UUID) (*entities.Domain, error) {
+	if sourceID == targetID {
+		return nil, errors.New("cannot merge a domain into itself")
+	}
+
+	// Get both domains and verify ownership
+	source, err := s.GetDomain(sourceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.GetDomain(targetID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.domainRepo.MergeDomains(source, target)
+	if err != nil {
+		return nil, err
+	}
+
+	return target, nil
+}
+
+// DeleteDomain deletes an unused domain
+func (s *domainService) DeleteDomain(domainID, userID uuid.UUID) error {
+	// Verify ownership first
+	domain, err := s.GetDomain(domainID, userID)
+	if err != nil {
+		return err
+	}
+
+	// Check if tasks or events still use the domain
+	count, err := s.domainRepo.CountDomainReferences(userID, domain.Name)
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return errors.New("cannot delete domain that is in use, archive or merge it instead")
+	}
+
+	return s.domainRepo.DeleteDomain(domainID)
+}
+
+// userDomains retrieves the domains of a user, creating the default domains for users without any
+func userDomains(domainRepo interfaces.DomainRepository, userID uuid.UUID, includeArchived bool) ([]*entities.Domain, error) {
+	domains, err := domainRepo.FindDomainsByUserID(userID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(domains) == 0 {
+		if err := domainRepo.CreateDomainsIfNotExist(newDefaultDomains(userID)); err != nil {
+			return nil, err
+		}
+		domains, err = domainRepo.FindDomainsByUserID(userID, true)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if includeArchived {
+		return domains, nil
+	}
+
+	var active []*entities.Domain
+	for _, domain := range domains {
+		if !domain.Archived {
+			active = append(active, domain)
+		}
+	}
+	return active, nil
+}
+
+// resolveDomain returns the name of the user's domain matching name (case-insensitive).
+// Archived domains cannot be assigned anymore.
+func resolveDomain(domainRepo interfaces.DomainRepository, userID uuid.UUID, name string) (string, error) {
+	domains, err := userDomains(domainRepo, userID, true)
+	if err != nil {
+		return "", err
+	}
+
+	domain := findDomainByName(domains, name)
+	if domain == nil {
+		return "", errors.New("invalid domain")
+	}
+	if domain.Archived {
+		return "", errors.New("domain is archived")
+	}
+
+	return domain.Name, nil
+}
+
+// findDomainByName finds a domain by name (case-insensitive)
+func findDomainByName(domains []*entities.Domain, name string) *entities.Domain {
+	name = strings.TrimSpace(name)
+	for _, domain := range domains {
+		if strings.EqualFold(domain.Name, name) {
+			return domain
+		}
+	}
+	return nil
+}
+
+// newDefaultDomains creates the default domains for a user
+func newDefaultDomains(userID uuid.UUID) []*entities.Domain {
+	now := time.Now()
+	domains := make([]*entities.Domain, len(entities.DefaultDomains))
+	for i, d := range entities.DefaultDomains {
+		domains[i] = &entities.Domain{
+			ID:        uuid.New(),
+			UserID:    userID,
+			Name:      d.Name,
+			Color:     d.Color,
+			Icon:      d.Icon,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+	}
+	return domains
+}
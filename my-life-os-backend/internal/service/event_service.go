@@ -1,112 +1,62 @@
 package service
 
 import (
-	"encoding/json"
 	"errors"
+	"math"
+	"sort"
 	"time"
 
 	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
 	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/rrule"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// conflictHorizon limits how far ahead the occurrences of a recurring event are checked for conflicts
+	conflictHorizon = 365 * 24 * time.Hour
+
+	maxConflictOccurrences = 500
 )
 
 type eventService struct {
-	eventRepo interfaces.EventRepository
+	eventRepo  interfaces.EventRepository
+	userRepo   interfaces.UserRepository
+	domainRepo interfaces.DomainRepository
 }
 
 // NewEventService creates a new event service
-func NewEventService(eventRepo interfaces.EventRepository) interfaces.EventService {
+func NewEventService(eventRepo interfaces.EventRepository, userRepo interfaces.UserRepository, domainRepo interfaces.DomainRepository) interfaces.EventService {
 	return &eventService{
-		eventRepo: eventRepo,
+		eventRepo:  eventRepo,
+		userRepo:   userRepo,
+		domainRepo: domainRepo,
 	}
 }
 
 // CreateEvent creates a new event
 func (s *eventService) CreateEvent(userID uuid.UUID, title string, startDate time.Time, endDate *time.Time,
-	allDay bool, domain string, isRecurring bool, recurrenceType *string, recurrenceEnd *time.Time,
-	recurrenceDays *string, hideFromAgenda bool) (*entities.Event, error) {
-
-	// Validate required fields
-	if title == "" {
-		return nil, errors.New("title is required")
-	}
+	allDay bool, timezone string, domain string, isRecurring bool, recurrenceRule *string, hideFromAgenda bool, allowConflict bool) (*entities.Event, error) {
 
-	// Validate domain
-	validDomains := []string{
-		"Work", "University", "Personal", "Coding Time", "Study",
-		"Health", "Social", "Holidays", "Travel", "Maintenance", "Entertainment", "Family",
-	}
-	isDomainValid := false
-	for _, d := range validDomains {
-		if domain == d {
-			isDomainValid = true
-			break
-		}
-	}
-	if !isDomainValid {
-		return nil, errors.New("invalid domain")
+	if err := s.ValidateEvent(userID, title, startDate, endDate, allDay, timezone, domain, isRecurring, recurrenceRule, allowConflict); err != nil {
+		return nil, err
 	}
 
-	// Validate recurrence if recurring
-	if isRecurring {
-		if recurrenceType == nil || *recurrenceType == "" {
-			return nil, errors.New("recurrence type is required for recurring events")
-		}
-
-		validRecurrenceTypes := []string{"daily", "weekly", "monthly", "yearly"}
-		isTypeValid := false
-		for _, t := range validRecurrenceTypes {
-			if *recurrenceType == t {
-				isTypeValid = true
-				break
-			}
-		}
-		if !isTypeValid {
-			return nil, errors.New("invalid recurrence type")
-		}
-
-		// Validate weekly recurrence days
-		if *recurrenceType == "weekly" {
-			if recurrenceDays == nil || *recurrenceDays == "" {
-				return nil, errors.New("recurrence days are required for weekly recurring events")
-			}
-
-			// Validate JSON format
-			var days []string
-			if err := json.Unmarshal([]byte(*recurrenceDays), &days); err != nil {
-				return nil, errors.New("invalid recurrence days format")
-			}
-
-			// Validate day names
-			validDays := map[string]bool{
-				"monday": true, "tuesday": true, "wednesday": true, "thursday": true,
-				"friday": true, "saturday": true, "sunday": true,
-			}
-			for _, day := range days {
-				if !validDays[day] {
-					return nil, errors.New("invalid day name in recurrence days")
-				}
-			}
-		}
+	timezone, err := s.resolveTimezone(userID, timezone)
+	if err != nil {
+		return nil, err
 	}
 
-	// Validate time range for non-all-day events
-	if !allDay && endDate != nil {
-		if endDate.Before(startDate) {
-			return nil, errors.New("end date cannot be before start date")
-		}
+	domain, err = resolveDomain(s.domainRepo, userID, domain)
+	if err != nil {
+		return nil, err
 	}
 
-	// Check for conflicts (only for non-all-day events)
-	if !allDay && endDate != nil {
-		hasConflict, err := s.CheckConflict(userID, startDate, endDate, allDay, nil)
-		if err != nil {
-			return nil, err
-		}
-		if hasConflict {
-			return nil, errors.New("event conflicts with an existing event")
-		}
+	rule, err := parseRecurrenceRule(isRecurring, recurrenceRule)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create event
@@ -117,17 +67,16 @@ func (s *eventService) CreateEvent(userID uuid.UUID, title string, startDate tim
 		StartDate:      startDate,
 		EndDate:        endDate,
 		AllDay:         allDay,
+		Timezone:       timezone,
 		Domain:         domain,
 		IsRecurring:    isRecurring,
-		RecurrenceType: recurrenceType,
-		RecurrenceEnd:  recurrenceEnd,
-		RecurrenceDays: recurrenceDays,
 		HideFromAgenda: hideFromAgenda,
 		CreatedAt:      time.Now(),
 		UpdatedAt:      time.Now(),
 	}
+	setRecurrence(event, rule)
 
-	err := s.eventRepo.CreateEvent(event)
+	err = s.eventRepo.CreateEvent(event)
 	if err != nil {
 		return nil, err
 	}
@@ -135,6 +84,161 @@ func (s *eventService) CreateEvent(userID uuid.UUID, title string, startDate tim
 	return event, nil
 }
 
+// ValidateEvent validates the fields of a new event and checks for conflicts
+func (s *eventService) ValidateEvent(userID uuid.UUID, title string, startDate time.Time, endDate *time.Time, allDay bool,
+	timezone string, domain string, isRecurring bool, recurrenceRule *string, allowConflict bool) error {
+
+	// Validate required fields
+	if title == "" {
+		return errors.New("title is required")
+	}
+
+	// Validate timezone (empty = user's timezone)
+	if timezone != "" {
+		if _, err := time.LoadLocation(timezone); err != nil {
+			return errors.New("invalid timezone")
+		}
+	}
+
+	// Validate domain (one of the user's domains)
+	if _, err := resolveDomain(s.domainRepo, userID, domain); err != nil {
+		return err
+	}
+
+	// Validate recurrence rule if recurring
+	if _, err := parseRecurrenceRule(isRecurring, recurrenceRule); err != nil {
+		return err
+	}
+
+	// Validate time range for non-all-day events
+	if !allDay && endDate != nil {
+		if endDate.Before(startDate) {
+			return errors.New("end date cannot be before start date")
+		}
+	}
+
+	// Check for conflicts (only for non-all-day events)
+	if !allowConflict {
+		if !isRecurring {
+			recurrenceRule = nil
+		}
+		return s.checkConflicts(userID, interfaces.ConflictQuery{
+			StartDate:      startDate,
+			EndDate:        endDate,
+			AllDay:         allDay,
+			Timezone:       timezone,
+			RecurrenceRule: recurrenceRule,
+		})
+	}
+
+	return nil
+}
+
+// parseRecurrenceRule parses the RRULE of a recurring event (nil for non-recurring events)
+func parseRecurrenceRule(isRecurring bool, recurrenceRule *string) (*rrule.Rule, error) {
+	if !isRecurring {
+		return nil, nil
+	}
+
+	if recurrenceRule == nil || *recurrenceRule == "" {
+		return nil, errors.New("recurrence rule is required for recurring events")
+	}
+
+	rule, err := rrule.Parse(*recurrenceRule)
+	if err != nil {
+		return nil, errors.New("invalid recurrence rule: " + err.Error())
+	}
+
+	return rule, nil
+}
+
+// setRecurrence stores the rule on an event and derives RecurrenceEnd (used to find recurring events by date range)
+func setRecurrence(event *entities.Event, rule *rrule.Rule) {
+	if rule == nil {
+		event.RecurrenceRule = nil
+		event.RecurrenceEnd = nil
+		return
+	}
+
+	value := rule.String()
+	event.RecurrenceRule = &value
+	event.RecurrenceEnd = rule.Last(event.StartDate.In(eventLocation(event)))
+
+	// A rule ending before its first occurrence still needs an end for range queries
+	if event.RecurrenceEnd == nil && rule.Until != nil {
+		until := *rule.Until
+		event.RecurrenceEnd = &until
+	}
+}
+
+// endRecurrenceBefore ends a series right before the given occurrence (used by the "following" scope)
+func endRecurrenceBefore(event *entities.Event, occurrenceDate time.Time) error {
+	if event.RecurrenceRule == nil {
+		return errors.New("event has no recurrence rule")
+	}
+
+	rule, err := rrule.Parse(*event.RecurrenceRule)
+	if err != nil {
+		return err
+	}
+
+	until := occurrenceDate.Add(-time.Second)
+	rule.Count = 0
+	rule.Until = &until
+	setRecurrence(event, rule)
+
+	return nil
+}
+
+// eventLocation returns the timezone an event is planned in (UTC if unknown)
+func eventLocation(event *entities.Event) *time.Location {
+	if event.Timezone == "" {
+		return time.UTC
+	}
+
+	loc, err := time.LoadLocation(event.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// resolveTimezone validates a timezone name, defaulting to the user's timezone
+func (s *eventService) resolveTimezone(userID uuid.UUID, timezone string) (string, error) {
+	return resolveUserTimezone(s.userRepo, userID, timezone)
+}
+
+// resolveUserTimezone validates a timezone name, defaulting to the user's timezone (or UTC)
+func resolveUserTimezone(userRepo interfaces.UserRepository, userID uuid.UUID, timezone string) (string, error) {
+	if timezone == "" {
+		user, err := userRepo.FindUserByID(userID)
+		if err != nil || user.Timezone == "" {
+			return "UTC", nil
+		}
+		timezone = user.Timezone
+	}
+
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return "", errors.New("invalid timezone")
+	}
+	return timezone, nil
+}
+
+// toTimezone converts the dates of events into the given timezone (for responses)
+func toTimezone(events []*entities.Event, loc *time.Location) {
+	for _, event := range events {
+		event.StartDate = event.StartDate.In(loc)
+		if event.EndDate != nil {
+			end := event.EndDate.In(loc)
+			event.EndDate = &end
+		}
+		if event.RecurrenceEnd != nil {
+			recurrenceEnd := event.RecurrenceEnd.In(loc)
+			event.RecurrenceEnd = &recurrenceEnd
+		}
+	}
+}
+
 // GetEvent retrieves a single event (ensures user owns it)
 func (s *eventService) GetEvent(eventID, userID uuid.UUID) (*entities.Event, error) {
 	event, err := s.eventRepo.FindEventByID(eventID)
@@ -147,11 +251,26 @@ func (s *eventService) GetEvent(eventID, userID uuid.UUID) (*entities.Event, err
 		return nil, errors.New("unauthorized: event does not belong to user")
 	}
 
+	// Respond in the user's timezone
+	timezone, err := s.resolveTimezone(userID, "")
+	if err != nil {
+		return nil, err
+	}
+	loc, _ := time.LoadLocation(timezone)
+	toTimezone([]*entities.Event{event}, loc)
+
 	return event, nil
 }
 
-// GetUserEventsInRange retrieves all events (expanded occurrences) for a user in date range
-func (s *eventService) GetUserEventsInRange(userID uuid.UUID, start, end time.Time) ([]*entities.Event, error) {
+// GetUserEventsInRange retrieves all events (expanded occurrences) for a user in date range.
+// Dates are returned in the given timezone (empty = user's timezone).
+func (s *eventService) GetUserEventsInRange(userID uuid.UUID, start, end time.Time, timezone string) ([]*entities.Event, error) {
+	timezone, err := s.resolveTimezone(userID, timezone)
+	if err != nil {
+		return nil, err
+	}
+	loc, _ := time.LoadLocation(timezone)
+
 	// Get base events from repository
 	baseEvents, err := s.eventRepo.FindEventsByUserIDAndDateRange(userID, start, end)
 	if err != nil {
@@ -181,6 +300,8 @@ func (s *eventService) GetUserEventsInRange(userID uuid.UUID, start, end time.Ti
 		}
 	}
 
+	toTimezone(expandedEvents, loc)
+
 	return expandedEvents, nil
 }
 
@@ -188,91 +309,19 @@ func (s *eventService) GetUserEventsInRange(userID uuid.UUID, start, end time.Ti
 func (s *eventService) expandRecurringEvent(baseEvent *entities.Event, start, end time.Time) []*entities.Event {
 	var occurrences []*entities.Event
 
-	if baseEvent.RecurrenceType == nil {
+	if baseEvent.RecurrenceRule == nil {
 		return occurrences
 	}
 
-	current := baseEvent.StartDate
-
-	// Ensure current is within range
-	if current.Before(start) {
-		current = start
-	}
-
-	// Calculate recurrence end
-	recurrenceEnd := end
-	if baseEvent.RecurrenceEnd != nil && baseEvent.RecurrenceEnd.Before(end) {
-		recurrenceEnd = *baseEvent.RecurrenceEnd
+	rule, err := rrule.Parse(*baseEvent.RecurrenceRule)
+	if err != nil {
+		return occurrences
 	}
 
-	switch *baseEvent.RecurrenceType {
-	case "daily":
-		for current.Before(recurrenceEnd) || current.Equal(recurrenceEnd) {
-			if (current.After(start) || current.Equal(start)) && (current.Before(end) || current.Equal(end)) {
-				occurrence := s.createOccurrence(baseEvent, current)
-				occurrences = append(occurrences, occurrence)
-			}
-			current = current.AddDate(0, 0, 1)
-		}
-
-	case "weekly":
-		if baseEvent.RecurrenceDays == nil {
-			return occurrences
-		}
-
-		var selectedDays []string
-		if err := json.Unmarshal([]byte(*baseEvent.RecurrenceDays), &selectedDays); err != nil {
-			return occurrences
-		}
-
-		dayMap := map[string]time.Weekday{
-			"sunday": time.Sunday, "monday": time.Monday, "tuesday": time.Tuesday,
-			"wednesday": time.Wednesday, "thursday": time.Thursday,
-			"friday": time.Friday, "saturday": time.Saturday,
-		}
-
-		for current.Before(recurrenceEnd) || current.Equal(recurrenceEnd) {
-			currentWeekday := current.Weekday()
-			for _, dayName := range selectedDays {
-				if dayMap[dayName] == currentWeekday {
-					if (current.After(start) || current.Equal(start)) && (current.Before(end) || current.Equal(end)) {
-						occurrence := s.createOccurrence(baseEvent, current)
-						occurrences = append(occurrences, occurrence)
-					}
-					break
-				}
-			}
-			current = current.AddDate(0, 0, 1)
-		}
-
-	case "monthly":
-		targetDay := baseEvent.StartDate.Day()
-		for current.Before(recurrenceEnd) || current.Equal(recurrenceEnd) {
-			if current.Day() == targetDay {
-				if (current.After(start) || current.Equal(start)) && (current.Before(end) || current.Equal(end)) {
-					occurrence := s.createOccurrence(baseEvent, current)
-					occurrences = append(occurrences, occurrence)
-				}
-			}
-			current = current.AddDate(0, 1, 0)
-			// Adjust for months with fewer days
-			if current.Day() < targetDay {
-				current = time.Date(current.Year(), current.Month(), targetDay, current.Hour(), current.Minute(), current.Second(), current.Nanosecond(), current.Location())
-			}
-		}
-
-	case "yearly":
-		targetMonth := baseEvent.StartDate.Month()
-		targetDay := baseEvent.StartDate.Day()
-		for current.Before(recurrenceEnd) || current.Equal(recurrenceEnd) {
-			if current.Month() == targetMonth && current.Day() == targetDay {
-				if (current.After(start) || current.Equal(start)) && (current.Before(end) || current.Equal(end)) {
-					occurrence := s.createOccurrence(baseEvent, current)
-					occurrences = append(occurrences, occurrence)
-				}
-			}
-			current = current.AddDate(1, 0, 0)
-		}
+	// Expand in the event's timezone, so occurrences keep their local time across DST changes
+	dtstart := baseEvent.StartDate.In(eventLocation(baseEvent))
+	for _, occurrenceDate := range rule.Between(dtstart, start, end) {
+		occurrences = append(occurrences, s.createOccurrence(baseEvent, occurrenceDate))
 	}
 
 	return occurrences
@@ -298,6 +347,7 @@ func (s *eventService) createOccurrence(baseEvent *entities.Event, occurrenceDat
 func (s *eventService) applyExceptions(occurrences []*entities.Event, exceptions []*entities.EventException) []*entities.Event {
 	var result []*entities.Event
 
+	exceptions = effectiveExceptions(exceptions)
 	for _, occurrence := range occurrences {
 		isDeleted := false
 
@@ -308,22 +358,7 @@ func (s *eventService) applyExceptions(occurrences []*entities.Event, exceptions
 					isDeleted = true
 					break
 				} else if exception.Type == "modified" {
-					// Apply modifications
-					if exception.ModifiedTitle != nil {
-						occurrence.Title = *exception.ModifiedTitle
-					}
-					if exception.ModifiedStartDate != nil {
-						occurrence.StartDate = *exception.ModifiedStartDate
-					}
-					if exception.ModifiedEndDate != nil {
-						occurrence.EndDate = exception.ModifiedEndDate
-					}
-					if exception.ModifiedDomain != nil {
-						occurrence.Domain = *exception.ModifiedDomain
-					}
-					if exception.ModifiedAllDay != nil {
-						occurrence.AllDay = *exception.ModifiedAllDay
-					}
+					applyModification(occurrence, exception)
 					break
 				}
 			}
@@ -337,10 +372,53 @@ func (s *eventService) applyExceptions(occurrences []*entities.Event, exceptions
 	return result
 }
 
+// effectiveExceptions keeps one exception per occurrence: a deletion, or else the newest modification
+// (every "this" edit of an occurrence adds another modification)
+func effectiveExceptions(exceptions []*entities.EventException) []*entities.EventException {
+	byDate := make(map[time.Time]*entities.EventException)
+	var dates []time.Time
+	for _, exception := range exceptions {
+		date := exception.OriginalDate.UTC()
+		current, ok := byDate[date]
+		if !ok {
+			dates = append(dates, date)
+		}
+		if !ok || (current.Type != "deleted" &&
+			(exception.Type == "deleted" || exception.CreatedAt.After(current.CreatedAt))) {
+			byDate[date] = exception
+		}
+	}
+
+	result := make([]*entities.EventException, len(dates))
+	for i, date := range dates {
+		result[i] = byDate[date]
+	}
+	return result
+}
+
+// applyModification applies the modified fields of an exception to an occurrence
+func applyModification(occurrence *entities.Event, exception *entities.EventException) {
+	if exception.ModifiedTitle != nil {
+		occurrence.Title = *exception.ModifiedTitle
+	}
+	if exception.ModifiedStartDate != nil {
+		occurrence.StartDate = *exception.ModifiedStartDate
+	}
+	if exception.ModifiedEndDate != nil {
+		occurrence.EndDate = exception.ModifiedEndDate
+	}
+	if exception.ModifiedDomain != nil {
+		occurrence.Domain = *exception.ModifiedDomain
+	}
+	if exception.ModifiedAllDay != nil {
+		occurrence.AllDay = *exception.ModifiedAllDay
+	}
+}
+
 // UpdateEvent updates an event (with edit scope: "this", "following", "all")
 func (s *eventService) UpdateEvent(eventID, userID uuid.UUID, occurrenceDate *time.Time, editScope string,
-	title string, startDate time.Time, endDate *time.Time, allDay bool, domain string,
-	recurrenceType *string, recurrenceEnd *time.Time, recurrenceDays *string, hideFromAgenda bool) (*entities.Event, error) {
+	title string, startDate time.Time, endDate *time.Time, allDay bool, timezone string, domain string,
+	recurrenceRule *string, hideFromAgenda bool, allowConflict bool) (*entities.Event, error) {
 
 	// Get base event and verify ownership
 	baseEvent, err := s.GetEvent(eventID, userID)
@@ -353,6 +431,19 @@ func (s *eventService) UpdateEvent(eventID, userID uuid.UUID, occurrenceDate *ti
 		return nil, errors.New("invalid edit scope")
 	}
 
+	// Keep the event's timezone unless a new one is given
+	if timezone == "" {
+		timezone = baseEvent.Timezone
+	}
+
+	// Validate domain (events keep an archived domain until it is changed)
+	if domain != baseEvent.Domain {
+		domain, err = resolveDomain(s.domainRepo, userID, domain)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// For non-recurring events, only "all" scope makes sense
 	if !baseEvent.IsRecurring && editScope != "all" {
 		return nil, errors.New("non-recurring events can only use 'all' scope")
@@ -365,6 +456,21 @@ func (s *eventService) UpdateEvent(eventID, userID uuid.UUID, occurrenceDate *ti
 			return nil, errors.New("occurrence date is required for 'this' scope")
 		}
 
+		// The moved occurrence must not collide with other events (or other occurrences of its series)
+		if !allowConflict {
+			err := s.checkConflicts(userID, interfaces.ConflictQuery{
+				StartDate:         startDate,
+				EndDate:           endDate,
+				AllDay:            allDay,
+				Timezone:          timezone,
+				ExcludeEventID:    &eventID,
+				ExcludeOccurrence: occurrenceDate,
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		exception := &entities.EventException{
 			ID:           uuid.New(),
 			EventID:      eventID,
@@ -399,16 +505,41 @@ func (s *eventService) UpdateEvent(eventID, userID uuid.UUID, occurrenceDate *ti
 		return baseEvent, nil
 
 	case "following":
-		// End current recurrence at the day before occurrence
+		// End current recurrence right before the occurrence
 		if occurrenceDate == nil {
 			return nil, errors.New("occurrence date is required for 'following' scope")
 		}
 
-		dayBefore := occurrenceDate.AddDate(0, 0, -1)
-		baseEvent.RecurrenceEnd = &dayBefore
+		rule, err := parseRecurrenceRule(true, recurrenceRule)
+		if err != nil {
+			return nil, err
+		}
+
+		timezone, err = s.resolveTimezone(userID, timezone)
+		if err != nil {
+			return nil, err
+		}
+
+		if !allowConflict {
+			err := s.checkConflicts(userID, interfaces.ConflictQuery{
+				StartDate:      startDate,
+				EndDate:        endDate,
+				AllDay:         allDay,
+				Timezone:       timezone,
+				RecurrenceRule: recurrenceRule,
+				ExcludeEventID: &eventID,
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if err := endRecurrenceBefore(baseEvent, *occurrenceDate); err != nil {
+			return nil, err
+		}
 		baseEvent.UpdatedAt = time.Now()
 
-		err := s.eventRepo.UpdateEvent(baseEvent)
+		err = s.eventRepo.UpdateEvent(baseEvent)
 		if err != nil {
 			return nil, err
 		}
@@ -421,15 +552,15 @@ func (s *eventService) UpdateEvent(eventID, userID uuid.UUID, occurrenceDate *ti
 			StartDate:      startDate,
 			EndDate:        endDate,
 			AllDay:         allDay,
+			Timezone:       timezone,
 			Domain:         domain,
 			IsRecurring:    true,
-			RecurrenceType: recurrenceType,
-			RecurrenceEnd:  recurrenceEnd,
-			RecurrenceDays: recurrenceDays,
 			HideFromAgenda: hideFromAgenda,
+			ParentEventID:  &eventID,
 			CreatedAt:      time.Now(),
 			UpdatedAt:      time.Now(),
 		}
+		setRecurrence(newEvent, rule)
 
 		err = s.eventRepo.CreateEvent(newEvent)
 		if err != nil {
@@ -439,19 +570,44 @@ func (s *eventService) UpdateEvent(eventID, userID uuid.UUID, occurrenceDate *ti
 		return newEvent, nil
 
 	case "all":
+		rule, err := parseRecurrenceRule(baseEvent.IsRecurring, recurrenceRule)
+		if err != nil {
+			return nil, err
+		}
+
+		timezone, err = s.resolveTimezone(userID, timezone)
+		if err != nil {
+			return nil, err
+		}
+
+		if !allowConflict {
+			query := interfaces.ConflictQuery{
+				StartDate:      startDate,
+				EndDate:        endDate,
+				AllDay:         allDay,
+				Timezone:       timezone,
+				ExcludeEventID: &eventID,
+			}
+			if rule != nil {
+				query.RecurrenceRule = recurrenceRule
+			}
+			if err := s.checkConflicts(userID, query); err != nil {
+				return nil, err
+			}
+		}
+
 		// Update base event directly
 		baseEvent.Title = title
 		baseEvent.StartDate = startDate
 		baseEvent.EndDate = endDate
 		baseEvent.AllDay = allDay
+		baseEvent.Timezone = timezone
 		baseEvent.Domain = domain
-		baseEvent.RecurrenceType = recurrenceType
-		baseEvent.RecurrenceEnd = recurrenceEnd
-		baseEvent.RecurrenceDays = recurrenceDays
 		baseEvent.HideFromAgenda = hideFromAgenda
 		baseEvent.UpdatedAt = time.Now()
+		setRecurrence(baseEvent, rule)
 
-		err := s.eventRepo.UpdateEvent(baseEvent)
+		err = s.eventRepo.UpdateEvent(baseEvent)
 		if err != nil {
 			return nil, err
 		}
@@ -500,13 +656,14 @@ func (s *eventService) DeleteEvent(eventID, userID uuid.UUID, occurrenceDate *ti
 		return s.eventRepo.CreateEventException(exception)
 
 	case "following":
-		// End recurrence at the day before occurrence
+		// End recurrence right before the occurrence
 		if occurrenceDate == nil {
 			return errors.New("occurrence date is required for 'following' scope")
 		}
 
-		dayBefore := occurrenceDate.AddDate(0, 0, -1)
-		baseEvent.RecurrenceEnd = &dayBefore
+		if err := endRecurrenceBefore(baseEvent, *occurrenceDate); err != nil {
+			return err
+		}
 		baseEvent.UpdatedAt = time.Now()
 
 		return s.eventRepo.UpdateEvent(baseEvent)
@@ -520,49 +677,235 @@ func (s *eventService) DeleteEvent(eventID, userID uuid.UUID, occurrenceDate *ti
 	}
 }
 
-// CheckConflict checks if event conflicts with existing events (only for non-all-day)
-func (s *eventService) CheckConflict(userID uuid.UUID, startDate time.Time, endDate *time.Time, allDay bool, excludeEventID *uuid.UUID) (bool, error) {
-	// Skip conflict check for all-day events
-	if allDay {
-		return false, nil
+// GetEventExceptions retrieves the deleted and modified occurrences of a recurring event
+func (s *eventService) GetEventExceptions(eventID, userID uuid.UUID) ([]*entities.EventException, error) {
+	// Verify ownership first
+	_, err := s.GetEvent(eventID, userID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Skip if no end date
-	if endDate == nil {
-		return false, nil
+	return s.eventRepo.FindEventExceptionsByEventID(eventID)
+}
+
+// RestoreOccurrence brings back an occurrence deleted with the "this" scope
+func (s *eventService) RestoreOccurrence(eventID, exceptionID, userID uuid.UUID) error {
+	return s.removeException(eventID, exceptionID, userID, "deleted")
+}
+
+// RevertOccurrence resets an occurrence edited with the "this" scope to the series values
+func (s *eventService) RevertOccurrence(eventID, exceptionID, userID uuid.UUID) error {
+	return s.removeException(eventID, exceptionID, userID, "modified")
+}
+
+// removeException deletes an exception and every other exception of the same type for that occurrence
+// (an occurrence edited several times has one exception per edit)
+func (s *eventService) removeException(eventID, exceptionID, userID uuid.UUID, exceptionType string) error {
+	// Verify ownership first
+	_, err := s.GetEvent(eventID, userID)
+	if err != nil {
+		return err
 	}
 
-	// Get events in the same time range
-	rangeStart := startDate.AddDate(0, 0, -1)
-	rangeEnd := endDate.AddDate(0, 0, 1)
+	exception, err := s.eventRepo.FindEventExceptionByID(exceptionID)
+	if err != nil {
+		return err
+	}
+	if exception.EventID != eventID {
+		return errors.New("exception does not belong to event")
+	}
+	if exception.Type != exceptionType {
+		return errors.New("occurrence is not " + exceptionType)
+	}
 
-	events, err := s.eventRepo.FindEventsByUserIDAndDateRange(userID, rangeStart, rangeEnd)
+	exceptions, err := s.eventRepo.FindEventExceptionsByEventID(eventID)
 	if err != nil {
-		return false, err
+		return err
 	}
 
-	for _, event := range events {
-		// Skip the event we're excluding (for updates)
-		if excludeEventID != nil && event.ID == *excludeEventID {
+	for _, e := range exceptions {
+		if e.Type != exceptionType || !e.OriginalDate.Equal(exception.OriginalDate) {
 			continue
 		}
+		if err := s.eventRepo.DeleteEventException(e.ID); err != nil {
+			return err
+		}
+	}
 
-		// Skip all-day events
-		if event.AllDay {
-			continue
+	return nil
+}
+
+// MergeSplit merges a series split off by a "following" edit back into its parent series.
+// The parent continues with its own values until the end of the split.
+func (s *eventService) MergeSplit(eventID, userID uuid.UUID) (*entities.Event, error) {
+	split, err := s.GetEvent(eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if split.ParentEventID == nil {
+		return nil, errors.New("event was not split from another series")
+	}
+	if !split.IsRecurring {
+		return nil, errors.New("only recurring events can be merged")
+	}
+
+	parent, err := s.GetEvent(*split.ParentEventID, userID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.New("parent series no longer exists")
 		}
+		return nil, err
+	}
 
-		// Skip if no end date
-		if event.EndDate == nil {
+	rule, err := parseRecurrenceRule(parent.IsRecurring, parent.RecurrenceRule)
+	if err != nil {
+		return nil, err
+	}
+	if rule == nil {
+		return nil, errors.New("parent event is not recurring")
+	}
+
+	// Extend the parent to the last day of the split (the parent may be planned later on that day)
+	loc := eventLocation(parent)
+	rule.Count = 0
+	rule.Until = nil
+	if split.RecurrenceEnd != nil {
+		last := split.RecurrenceEnd.In(loc)
+		until := time.Date(last.Year(), last.Month(), last.Day()+1, 0, 0, 0, 0, loc).Add(-time.Second)
+		rule.Until = &until
+	}
+	setRecurrence(parent, rule)
+	parent.UpdatedAt = time.Now()
+
+	// Keep deleted/modified occurrences of the split on the parent occurrence of the same day
+	exceptions, err := s.eventRepo.FindEventExceptionsByEventID(split.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	dtstart := parent.StartDate.In(loc)
+	var moved []*entities.EventException
+	for _, exception := range exceptions {
+		original := exception.OriginalDate.In(loc)
+		dayStart := time.Date(original.Year(), original.Month(), original.Day(), 0, 0, 0, 0, loc)
+		dayEnd := time.Date(original.Year(), original.Month(), original.Day()+1, 0, 0, 0, 0, loc).Add(-time.Second)
+
+		if occurrences := rule.Between(dtstart, dayStart, dayEnd); len(occurrences) > 0 {
+			exception.OriginalDate = occurrences[0]
+			moved = append(moved, exception)
+		}
+	}
+
+	err = s.eventRepo.MergeEventSeries(parent, split, moved)
+	if err != nil {
+		return nil, err
+	}
+
+	return parent, nil
+}
+
+// FindConflicts returns every existing occurrence that overlaps the planned event (only for non-all-day),
+// recurring events are checked occurrence by occurrence
+func (s *eventService) FindConflicts(userID uuid.UUID, query interfaces.ConflictQuery) ([]*interfaces.EventConflict, error) {
+	// All-day events and events without a duration never conflict
+	if query.AllDay || query.EndDate == nil || !query.EndDate.After(query.StartDate) {
+		return nil, nil
+	}
+	duration := query.EndDate.Sub(query.StartDate)
+
+	timezone, err := s.resolveTimezone(userID, query.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	loc, _ := time.LoadLocation(timezone)
+
+	// Planned occurrences (a single one for non-recurring events)
+	planned := []time.Time{query.StartDate.In(loc)}
+	if query.RecurrenceRule != nil && *query.RecurrenceRule != "" {
+		rule, err := rrule.Parse(*query.RecurrenceRule)
+		if err != nil {
+			return nil, errors.New("invalid recurrence rule: " + err.Error())
+		}
+
+		dtstart := query.StartDate.In(loc)
+		planned = rule.Between(dtstart, dtstart, dtstart.Add(conflictHorizon))
+		if len(planned) > maxConflictOccurrences {
+			planned = planned[:maxConflictOccurrences]
+		}
+	}
+	if len(planned) == 0 {
+		return nil, nil
+	}
+
+	// Existing occurrences around the planned ones (with exceptions applied)
+	rangeStart := planned[0].Add(-busyLookback)
+	rangeEnd := planned[len(planned)-1].Add(duration)
+	events, err := s.GetUserEventsInRange(userID, rangeStart, rangeEnd, timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	var conflicts []*interfaces.EventConflict
+	for _, event := range events {
+		// All-day events and events without an end do not block time
+		if event.AllDay || event.EndDate == nil {
 			continue
 		}
 
-		// Check for overlap
-		// Events overlap if: start1 < end2 AND start2 < end1
-		if startDate.Before(*event.EndDate) && event.StartDate.Before(*endDate) {
-			return true, nil
+		// Skip the event (or occurrence) being edited
+		if query.ExcludeEventID != nil && event.ID == *query.ExcludeEventID {
+			if query.ExcludeOccurrence == nil || event.StartDate.Equal(*query.ExcludeOccurrence) {
+				continue
+			}
+		}
+
+		for _, start := range planned {
+			end := start.Add(duration)
+
+			// Events overlap if: start1 < end2 AND start2 < end1
+			if !start.Before(*event.EndDate) || !event.StartDate.Before(end) {
+				continue
+			}
+
+			overlapStart := start
+			if event.StartDate.After(overlapStart) {
+				overlapStart = event.StartDate
+			}
+			overlapEnd := end
+			if event.EndDate.Before(overlapEnd) {
+				overlapEnd = *event.EndDate
+			}
+
+			conflicts = append(conflicts, &interfaces.EventConflict{
+				EventID:        event.ID,
+				Title:          event.Title,
+				OccurrenceDate: event.StartDate,
+				EndDate:        *event.EndDate,
+				IsRecurring:    event.IsRecurring,
+				PlannedStart:   start,
+				OverlapMinutes: int(math.Ceil(overlapEnd.Sub(overlapStart).Minutes())),
+			})
 		}
 	}
 
-	return false, nil
+	sort.SliceStable(conflicts, func(i, j int) bool {
+		if !conflicts[i].PlannedStart.Equal(conflicts[j].PlannedStart) {
+			return conflicts[i].PlannedStart.Before(conflicts[j].PlannedStart)
+		}
+		return conflicts[i].OccurrenceDate.Before(conflicts[j].OccurrenceDate)
+	})
+
+	return conflicts, nil
+}
+
+// checkConflicts returns an EventConflictError if the planned event overlaps existing events
+func (s *eventService) checkConflicts(userID uuid.UUID, query interfaces.ConflictQuery) error {
+	conflicts, err := s.FindConflicts(userID, query)
+	if err != nil {
+		return err
+	}
+	if len(conflicts) > 0 {
+		return &interfaces.EventConflictError{Conflicts: conflicts}
+	}
+	return nil
 }
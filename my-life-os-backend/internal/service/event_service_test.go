@@ -0,0 +1,273 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
+
+	"github.com/google/uuid"
+)
+
+type fakeEventRepo struct {
+	interfaces.EventRepository
+	events     []*entities.Event
+	exceptions []*entities.EventException
+	failCreate bool
+}
+
+func (r *fakeEventRepo) FindEventsByUserIDAndDateRange(userID uuid.UUID, start, end time.Time) ([]*entities.Event, error) {
+	// Copies, the service converts the dates of the returned events
+	var events []*entities.Event
+	for _, event := range r.events {
+		copied := *event
+		events = append(events, &copied)
+	}
+	return events, nil
+}
+
+func (r *fakeEventRepo) FindEventExceptionsByDateRange(eventID uuid.UUID, start, end time.Time) ([]*entities.EventException, error) {
+	var exceptions []*entities.EventException
+	for _, exception := range r.exceptions {
+		if exception.EventID == eventID {
+			exceptions = append(exceptions, exception)
+		}
+	}
+	return exceptions, nil
+}
+
+type fakeUserRepo struct {
+	interfaces.UserRepository
+	user *entities.User
+}
+
+func (r *fakeUserRepo) FindUserByID(id uuid.UUID) (*entities.User, error) {
+	return r.user, nil
+}
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("load %s: %v", name, err)
+	}
+	return loc
+}
+
+func TestGetUserEventsInRangeAcrossDST(t *testing.T) {
+	berlin := mustLoadLocation(t, "Europe/Berlin")
+	userID := uuid.New()
+
+	// Weekly standup on Sundays 09:00-10:00 Berlin time, stored in UTC like the database returns it
+	start := time.Date(2026, 10, 18, 9, 0, 0, 0, berlin).UTC()
+	end := start.Add(time.Hour)
+	rule := "FREQ=WEEKLY;BYDAY=SU"
+	standup := &entities.Event{
+		ID:             uuid.New(),
+		UserID:         userID,
+		Title:          "Standup",
+		StartDate:      start,
+		EndDate:        &end,
+		Timezone:       "Europe/Berlin",
+		IsRecurring:    true,
+		RecurrenceRule: &rule,
+	}
+
+	// A one-off event after the change
+	dinnerStart := time.Date(2026, 10, 30, 19, 30, 0, 0, berlin).UTC()
+	dinner := &entities.Event{ID: uuid.New(), UserID: userID, Title: "Dinner", StartDate: dinnerStart, Timezone: "Europe/Berlin"}
+
+	repo := &fakeEventRepo{events: []*entities.Event{standup, dinner}}
+	s := &eventService{eventRepo: repo, userRepo: &fakeUserRepo{user: &entities.User{ID: userID, Timezone: "Europe/Berlin"}}}
+
+	from := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 11, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		timezone string
+		want     []string // Start - end in the response timezone
+	}{
+		{
+			name:     "user timezone",
+			timezone: "",
+			want: []string{
+				"2026-10-18 09:00 +0200 - 10:00",
+				"2026-10-25 09:00 +0100 - 10:00",
+				"2026-11-01 09:00 +0100 - 10:00",
+				"2026-10-30 19:30 +0100 - ",
+			},
+		},
+		{
+			name:     "other timezone follows the event's zone",
+			timezone: "America/New_York",
+			want: []string{
+				"2026-10-18 03:00 -0400 - 04:00",
+				"2026-10-25 04:00 -0400 - 05:00", // Berlin changed, New York did not yet
+				"2026-11-01 03:00 -0500 - 04:00",
+				"2026-10-30 14:30 -0400 - ",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := s.GetUserEventsInRange(userID, from, to, tt.timezone)
+			if err != nil {
+				t.Fatalf("GetUserEventsInRange: %v", err)
+			}
+			if len(events) != len(tt.want) {
+				t.Fatalf("got %d events, want %d", len(events), len(tt.want))
+			}
+
+			var got []string
+			for _, event := range events {
+				formatted := event.StartDate.Format("2006-01-02 15:04 -0700") + " - "
+				if event.EndDate != nil {
+					formatted += event.EndDate.Format("15:04")
+				}
+				got = append(got, formatted)
+			}
+			// Recurring occurrences come first, then the one-off event (repository order)
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("event %d = %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestGetUserEventsInRangeExceptionAfterDST(t *testing.T) {
+	berlin := mustLoadLocation(t, "Europe/Berlin")
+	userID := uuid.New()
+
+	start := time.Date(2026, 10, 18, 9, 0, 0, 0, berlin).UTC()
+	rule := "FREQ=WEEKLY;BYDAY=SU"
+	standup := &entities.Event{
+		ID:             uuid.New(),
+		UserID:         userID,
+		StartDate:      start,
+		Timezone:       "Europe/Berlin",
+		IsRecurring:    true,
+		RecurrenceRule: &rule,
+	}
+
+	// The occurrence after the change is deleted by its instant (09:00 CET = 08:00 UTC)
+	repo := &fakeEventRepo{
+		events: []*entities.Event{standup},
+		exceptions: []*entities.EventException{{
+			ID:           uuid.New(),
+			EventID:      standup.ID,
+			OriginalDate: time.Date(2026, 10, 25, 8, 0, 0, 0, time.UTC),
+			Type:         "deleted",
+		}},
+	}
+	s := &eventService{eventRepo: repo, userRepo: &fakeUserRepo{user: &entities.User{ID: userID}}}
+
+	events, err := s.GetUserEventsInRange(userID, time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC), time.Date(2026, 11, 2, 0, 0, 0, 0, time.UTC), "Europe/Berlin")
+	if err != nil {
+		t.Fatalf("GetUserEventsInRange: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want 2", len(events))
+	}
+	for _, event := range events {
+		if event.StartDate.Day() == 25 {
+			t.Errorf("deleted occurrence %s is still listed", event.StartDate)
+		}
+		if event.StartDate.Hour() != 9 {
+			t.Errorf("occurrence %s is not at 09:00 local time", event.StartDate)
+		}
+	}
+}
+
+func TestEffectiveExceptions(t *testing.T) {
+	occurrence := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+	created := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	title := func(v string) *string { return &v }
+	modified := func(name string, minutes int) *entities.EventException {
+		return &entities.EventException{OriginalDate: occurrence, Type: "modified", ModifiedTitle: title(name), CreatedAt: created.Add(time.Duration(minutes) * time.Minute)}
+	}
+	deleted := &entities.EventException{OriginalDate: occurrence, Type: "deleted", CreatedAt: created}
+
+	tests := []struct {
+		name       string
+		exceptions []*entities.EventException
+		wantType   string
+		wantTitle  string
+	}{
+		{"single modification", []*entities.EventException{modified("Moved", 0)}, "modified", "Moved"},
+		{"newest modification wins", []*entities.EventException{modified("Second", 5), modified("First", 0), modified("Third", 10)}, "modified", "Third"},
+		{"deletion wins over later modifications", []*entities.EventException{modified("Moved", 0), deleted, modified("Again", 10)}, "deleted", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := effectiveExceptions(tt.exceptions)
+			if len(got) != 1 {
+				t.Fatalf("got %d exceptions, want 1", len(got))
+			}
+			if got[0].Type != tt.wantType {
+				t.Errorf("type = %q, want %q", got[0].Type, tt.wantType)
+			}
+			if tt.wantTitle != "" && *got[0].ModifiedTitle != tt.wantTitle {
+				t.Errorf("title = %q, want %q", *got[0].ModifiedTitle, tt.wantTitle)
+			}
+		})
+	}
+}
+
+func TestToTimezone(t *testing.T) {
+	berlin := mustLoadLocation(t, "Europe/Berlin")
+
+	tests := []struct {
+		name      string
+		start     time.Time // UTC
+		wantStart string
+		wantEnd   string
+	}{
+		{
+			name:      "summer time",
+			start:     time.Date(2026, 10, 24, 7, 0, 0, 0, time.UTC),
+			wantStart: "2026-10-24 09:00 +0200",
+			wantEnd:   "2026-10-24 10:30 +0200",
+		},
+		{
+			name:      "winter time",
+			start:     time.Date(2026, 10, 26, 8, 0, 0, 0, time.UTC),
+			wantStart: "2026-10-26 09:00 +0100",
+			wantEnd:   "2026-10-26 10:30 +0100",
+		},
+		{
+			name:      "end crosses the fall-back hour",
+			start:     time.Date(2026, 10, 25, 0, 30, 0, 0, time.UTC),
+			wantStart: "2026-10-25 02:30 +0200",
+			wantEnd:   "2026-10-25 03:00 +0100", // 90 minutes later, one hour repeated
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			end := tt.start.Add(90 * time.Minute)
+			recurrenceEnd := tt.start.AddDate(0, 1, 0)
+			event := &entities.Event{StartDate: tt.start, EndDate: &end, RecurrenceEnd: &recurrenceEnd}
+
+			toTimezone([]*entities.Event{event}, berlin)
+
+			if got := event.StartDate.Format("2006-01-02 15:04 -0700"); got != tt.wantStart {
+				t.Errorf("start = %s, want %s", got, tt.wantStart)
+			}
+			if got := event.EndDate.Format("2006-01-02 15:04 -0700"); got != tt.wantEnd {
+				t.Errorf("end = %s, want %s", got, tt.wantEnd)
+			}
+			if !event.StartDate.Equal(tt.start) || !event.EndDate.Equal(end) || !event.RecurrenceEnd.Equal(recurrenceEnd) {
+				t.Error("converting changed the instants")
+			}
+			if event.RecurrenceEnd.Location() != berlin {
+				t.Errorf("recurrence end is in %s, want Europe/Berlin", event.RecurrenceEnd.Location())
+			}
+		})
+	}
+}
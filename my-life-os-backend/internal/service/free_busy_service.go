@@ -0,0 +1,280 @@
+package service
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// busyLookback finds events that start before the queried range but still last into it
+	// (events that started earlier are not considered, see FreeBusyService)
+	busyLookback = 31 * 24 * time.Hour
+
+	// maxFreeBusyRange limits how far a single query may reach
+	maxFreeBusyRange = 92 * 24 * time.Hour
+
+	defaultSlotLimit = 10
+	maxSlotLimit     = 100
+)
+
+type freeBusyService struct {
+	eventService interfaces.EventService
+	userRepo     interfaces.UserRepository
+}
+
+// NewFreeBusyService creates a new free/busy service
+func NewFreeBusyService(eventService interfaces.EventService, userRepo interfaces.UserRepository) interfaces.FreeBusyService {
+	return &freeBusyService{
+		eventService: eventService,
+		userRepo:     userRepo,
+	}
+}
+
+// GetBusyIntervals returns the busy intervals of a user (recurrences and exceptions included)
+func (s *freeBusyService) GetBusyIntervals(userID uuid.UUID, query interfaces.FreeBusyQuery) ([]*interfaces.BusyInterval, error) {
+	if err := validateFreeBusyRange(query); err != nil {
+		return nil, err
+	}
+
+	return s.busyIntervals(userID, query, query.Start, query.End)
+}
+
+// validateFreeBusyRange checks the queried range
+func validateFreeBusyRange(query interfaces.FreeBusyQuery) error {
+	if !query.Start.Before(query.End) {
+		return errors.New("start must be before end")
+	}
+	if query.End.Sub(query.Start) > maxFreeBusyRange {
+		return errors.New("date range is too large")
+	}
+	return nil
+}
+
+// busyIntervals returns the busy intervals between two moments, clipped to them
+func (s *freeBusyService) busyIntervals(userID uuid.UUID, query interfaces.FreeBusyQuery, rangeStart, rangeEnd time.Time) ([]*interfaces.BusyInterval, error) {
+	timezone, err := resolveUserTimezone(s.userRepo, userID, query.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	loc, _ := time.LoadLocation(timezone)
+
+	events, err := s.eventService.GetUserEventsInRange(userID, rangeStart.Add(-busyLookback), rangeEnd, timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	var intervals []*interfaces.BusyInterval
+	for _, event := range events {
+		if isIgnoredDomain(event.Domain, query.IgnoreDomains) {
+			continue
+		}
+		if event.AllDay && query.IgnoreAllDay {
+			continue
+		}
+
+		start, end, ok := busyRange(event, loc)
+		if !ok {
+			continue
+		}
+
+		// Clip to the queried range
+		if start.Before(rangeStart) {
+			start = rangeStart.In(loc)
+		}
+		if end.After(rangeEnd) {
+			end = rangeEnd.In(loc)
+		}
+		if !start.Before(end) {
+			continue
+		}
+
+		intervals = append(intervals, &interfaces.BusyInterval{
+			Start:   start,
+			End:     end,
+			EventID: event.ID,
+			Title:   event.Title,
+			Domain:  event.Domain,
+			AllDay:  event.AllDay,
+		})
+	}
+
+	sort.SliceStable(intervals, func(i, j int) bool {
+		return intervals[i].Start.Before(intervals[j].Start)
+	})
+
+	return intervals, nil
+}
+
+// FindFreeSlots returns free slots for the requested duration, ranked by how busy their day is
+func (s *freeBusyService) FindFreeSlots(userID uuid.UUID, query interfaces.FreeBusyQuery) ([]*interfaces.FreeSlot, error) {
+	if query.Duration <= 0 {
+		return nil, errors.New("duration must be positive")
+	}
+	if query.Buffer < 0 {
+		return nil, errors.New("buffer cannot be negative")
+	}
+	if query.WorkdayStart < 0 || query.WorkdayEnd > 24*60 || query.WorkdayStart >= query.WorkdayEnd {
+		return nil, errors.New("invalid working hours")
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultSlotLimit
+	}
+	if limit > maxSlotLimit {
+		limit = maxSlotLimit
+	}
+
+	if err := validateFreeBusyRange(query); err != nil {
+		return nil, err
+	}
+
+	// Events within the buffer of the range still block its edges (a meeting until 08:55 blocks 09:00 with 15 minutes)
+	busy, err := s.busyIntervals(userID, query, query.Start.Add(-query.Buffer), query.End.Add(query.Buffer))
+	if err != nil {
+		return nil, err
+	}
+
+	timezone, err := resolveUserTimezone(s.userRepo, userID, query.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	loc, _ := time.LoadLocation(timezone)
+
+	blocked := mergeBusyIntervals(busy, query.Buffer)
+
+	var slots []*interfaces.FreeSlot
+	first := query.Start.In(loc)
+	for day := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, loc); day.Before(query.End); day = day.AddDate(0, 0, 1) {
+		// Working hours of this day (time.Date keeps the wall-clock time across DST changes)
+		windowStart := time.Date(day.Year(), day.Month(), day.Day(), 0, query.WorkdayStart, 0, 0, loc)
+		windowEnd := time.Date(day.Year(), day.Month(), day.Day(), 0, query.WorkdayEnd, 0, 0, loc)
+		if windowStart.Before(query.Start) {
+			windowStart = query.Start.In(loc)
+		}
+		if windowEnd.After(query.End) {
+			windowEnd = query.End.In(loc)
+		}
+		if !windowStart.Before(windowEnd) {
+			continue
+		}
+
+		dayBusy := busyMinutes(busy, windowStart, windowEnd)
+
+		// Walk the gaps between blocked intervals
+		cursor := windowStart
+		for _, b := range blocked {
+			if !b[1].After(cursor) {
+				continue
+			}
+			if !b[0].Before(windowEnd) {
+				break
+			}
+			if b[0].Sub(cursor) >= query.Duration {
+				slots = append(slots, newFreeSlot(cursor, b[0], query.Duration, dayBusy))
+			}
+			cursor = b[1]
+		}
+		if windowEnd.Sub(cursor) >= query.Duration {
+			slots = append(slots, newFreeSlot(cursor, windowEnd, query.Duration, dayBusy))
+		}
+	}
+
+	// Prefer quiet days, then earlier slots
+	sort.SliceStable(slots, func(i, j int) bool {
+		if slots[i].DayBusyMinutes != slots[j].DayBusyMinutes {
+			return slots[i].DayBusyMinutes < slots[j].DayBusyMinutes
+		}
+		return slots[i].Start.Before(slots[j].Start)
+	})
+
+	if len(slots) > limit {
+		slots = slots[:limit]
+	}
+
+	return slots, nil
+}
+
+// busyRange returns the period an event blocks (all-day events block their whole days)
+func busyRange(event *entities.Event, loc *time.Location) (time.Time, time.Time, bool) {
+	if event.AllDay {
+		start := event.StartDate.In(loc)
+		last := start
+		if event.EndDate != nil && event.EndDate.After(event.StartDate) {
+			last = event.EndDate.In(loc)
+		}
+		dayStart := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc)
+		dayEnd := time.Date(last.Year(), last.Month(), last.Day(), 0, 0, 0, 0, loc).AddDate(0, 0, 1)
+		return dayStart, dayEnd, true
+	}
+
+	// Events without an end have no duration
+	if event.EndDate == nil || !event.EndDate.After(event.StartDate) {
+		return time.Time{}, time.Time{}, false
+	}
+
+	return event.StartDate.In(loc), event.EndDate.In(loc), true
+}
+
+// mergeBusyIntervals widens intervals by the buffer and merges overlapping ones
+func mergeBusyIntervals(busy []*interfaces.BusyInterval, buffer time.Duration) [][2]time.Time {
+	var merged [][2]time.Time
+	for _, b := range busy {
+		start := b.Start.Add(-buffer)
+		end := b.End.Add(buffer)
+
+		if n := len(merged); n > 0 && !start.After(merged[n-1][1]) {
+			if end.After(merged[n-1][1]) {
+				merged[n-1][1] = end
+			}
+			continue
+		}
+		merged = append(merged, [2]time.Time{start, end})
+	}
+	return merged
+}
+
+// busyMinutes sums the busy time within a window (overlapping events are counted once)
+func busyMinutes(busy []*interfaces.BusyInterval, windowStart, windowEnd time.Time) int {
+	var total time.Duration
+	for _, b := range mergeBusyIntervals(busy, 0) {
+		start, end := b[0], b[1]
+		if start.Before(windowStart) {
+			start = windowStart
+		}
+		if end.After(windowEnd) {
+			end = windowEnd
+		}
+		if start.Before(end) {
+			total += end.Sub(start)
+		}
+	}
+	return int(total.Minutes())
+}
+
+// newFreeSlot creates a slot at the beginning of a free gap
+func newFreeSlot(gapStart, gapEnd time.Time, duration time.Duration, dayBusy int) *interfaces.FreeSlot {
+	return &interfaces.FreeSlot{
+		Start:          gapStart,
+		End:            gapStart.Add(duration),
+		FreeUntil:      gapEnd,
+		DayBusyMinutes: dayBusy,
+	}
+}
+
+// isIgnoredDomain checks if a domain is in the ignore list (case-insensitive)
+func isIgnoredDomain(domain string, ignored []string) bool {
+	for _, d := range ignored {
+		if strings.EqualFold(strings.TrimSpace(d), domain) {
+			return true
+		}
+	}
+	return false
+}
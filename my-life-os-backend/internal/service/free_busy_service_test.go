@@ -0,0 +1,59 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
+
+	"github.com/google/uuid"
+)
+
+func TestFindFreeSlotsBufferAtRangeEdges(t *testing.T) {
+	berlin := mustLoadLocation(t, "Europe/Berlin")
+	userID := uuid.New()
+	at := func(hour, minute int) time.Time { return time.Date(2026, 10, 19, hour, minute, 0, 0, berlin) }
+	event := func(start, end time.Time) *entities.Event {
+		return &entities.Event{ID: uuid.New(), UserID: userID, Title: "Meeting", StartDate: start, EndDate: &end}
+	}
+
+	tests := []struct {
+		name   string
+		events []*entities.Event
+		want   string // First free gap
+	}{
+		{"no events", nil, "09:00-10:00"},
+		{"meeting ending before the range blocks its start", []*entities.Event{event(at(8, 0), at(8, 55))}, "09:10-10:00"},
+		{"meeting ending long before the range", []*entities.Event{event(at(7, 0), at(8, 30))}, "09:00-10:00"},
+		{"meeting starting after the range blocks its end", []*entities.Event{event(at(10, 5), at(11, 0))}, "09:00-09:50"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &freeBusyService{
+				eventService: &fakeEventService{events: tt.events},
+				userRepo:     &fakeUserRepo{user: &entities.User{ID: userID, Timezone: "Europe/Berlin"}},
+			}
+
+			slots, err := s.FindFreeSlots(userID, interfaces.FreeBusyQuery{
+				Start:        at(9, 0),
+				End:          at(10, 0),
+				Duration:     30 * time.Minute,
+				Buffer:       15 * time.Minute,
+				WorkdayStart: 0,
+				WorkdayEnd:   24 * 60,
+			})
+			if err != nil {
+				t.Fatalf("FindFreeSlots: %v", err)
+			}
+			if len(slots) == 0 {
+				t.Fatalf("no free slot, want one at %s", tt.want)
+			}
+			got := slots[0].Start.In(berlin).Format("15:04") + "-" + slots[0].FreeUntil.In(berlin).Format("15:04")
+			if got != tt.want {
+				t.Errorf("first gap %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -167,7 +167,7 @@ func (s *projectService) UpdateProject(projectID, userID uuid.UUID, title, descr
 	return s.projectRepo.FindProjectByID(projectID)
 }
 
-// DeleteProject deletes a project
+// DeleteProject moves a project to the trash
 func (s *projectService) DeleteProject(projectID, userID uuid.UUID) error {
 	// Verify ownership first
 	_, err := s.GetProject(projectID, userID)
@@ -232,19 +232,66 @@ func (s *projectService) UnassignTaskFromProject(projectID, taskID, userID uuid.
 	return s.projectRepo.UnassignTask(projectID, taskID)
 }
 
-// GetProjectTasks retrieves all tasks assigned to a project
-func (s *projectService) GetProjectTasks(projectID, userID uuid.UUID) ([]*entities.ProjectTask, error) {
+// GetProjectTasks retrieves all tasks assigned to a project with the dependencies touching them
+func (s *projectService) GetProjectTasks(projectID, userID uuid.UUID) (*interfaces.ProjectTaskGraph, error) {
 	// Verify project ownership
 	_, err := s.GetProject(projectID, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.projectRepo.FindProjectTasks(projectID)
+	projectTasks, err := s.projectRepo.FindProjectTasks(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Project tasks and their subtasks are the nodes of the graph
+	inProject := make(map[uuid.UUID]bool)
+	var ids []uuid.UUID
+	var collect func(task *entities.Task)
+	collect = func(task *entities.Task) {
+		inProject[task.ID] = true
+		ids = append(ids, task.ID)
+		for _, subtask := range task.Subtasks {
+			collect(subtask)
+		}
+	}
+	for _, pt := range projectTasks {
+		collect(&pt.Task)
+	}
+
+	dependencies, err := s.taskRepo.FindTaskDependencies(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tasks of other projects (or none) that block or are blocked by project tasks
+	var externalIDs []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	for _, dependency := range dependencies {
+		for _, id := range []uuid.UUID{dependency.TaskID, dependency.BlockedByID} {
+			if !inProject[id] && !seen[id] {
+				seen[id] = true
+				externalIDs = append(externalIDs, id)
+			}
+		}
+	}
+
+	externalTasks, err := s.taskRepo.FindTasksByIDs(externalIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &interfaces.ProjectTaskGraph{
+		Tasks:         projectTasks,
+		Dependencies:  dependencies,
+		ExternalTasks: externalTasks,
+	}, nil
 }
 
 // CalculateProgress calculates the completion percentage of a project
-func (s *projectService) CalculateProgress(projectID uuid.UUID) (float64, error) {
+// (optionally counting the completed part of tasks with subtasks)
+func (s *projectService) CalculateProgress(projectID uuid.UUID, includeSubtasks bool) (float64, error) {
 	projectTasks, err := s.projectRepo.FindProjectTasks(projectID)
 	if err != nil {
 		return 0, err
@@ -254,12 +301,10 @@ func (s *projectService) CalculateProgress(projectID uuid.UUID) (float64, error)
 		return 0.0, nil
 	}
 
-	completedCount := 0
+	completed := 0.0
 	for _, pt := range projectTasks {
-		if pt.Task.Status == entities.StatusDone {
-			completedCount++
-		}
+		completed += pt.Task.Completion(includeSubtasks)
 	}
 
-	return float64(completedCount) / float64(len(projectTasks)) * 100, nil
+	return completed / float64(len(projectTasks)) * 100, nil
 }
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/quickadd"
+)
+
+type quickAddService struct {
+	taskService  interfaces.TaskService
+	eventService interfaces.EventService
+	domainRepo   interfaces.DomainRepository
+	userRepo     interfaces.UserRepository
+}
+
+// NewQuickAddService creates a new quick-add service
+func NewQuickAddService(taskService interfaces.TaskService, eventService interfaces.EventService, domainRepo interfaces.DomainRepository, userRepo interfaces.UserRepository) interfaces.QuickAddService {
+	return &quickAddService{
+		taskService:  taskService,
+		eventService: eventService,
+		domainRepo:   domainRepo,
+		userRepo:     userRepo,
+	}
+}
+
+// PreviewQuickAdd interprets an input without creating anything (for confirmation)
+func (s *quickAddService) PreviewQuickAdd(userID uuid.UUID, input interfaces.QuickAddInput) (*quickadd.Result, error) {
+	result, _, err := s.interpret(userID, input)
+	return result, err
+}
+
+// QuickAdd interprets an input and creates the task or event
+func (s *quickAddService) QuickAdd(userID uuid.UUID, input interfaces.QuickAddInput) (*interfaces.QuickAddResult, error) {
+	result, timezone, err := s.interpret(userID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	domain := result.Domain
+	if domain == "" {
+		domain = input.Domain
+	}
+	if domain == "" {
+		return nil, errors.New("domain is required (add @Domain to the text)")
+	}
+
+	if result.Type == quickadd.TypeEvent {
+		var recurrenceRule *string
+		if result.RecurrenceRule != "" {
+			recurrenceRule = &result.RecurrenceRule
+		}
+
+		event, err := s.eventService.CreateEvent(userID, result.Title, *result.Start, result.End, result.AllDay, timezone, domain, recurrenceRule != nil, recurrenceRule, false, input.AllowConflict)
+		if err != nil {
+			return nil, err
+		}
+		return &interfaces.QuickAddResult{Interpretation: result, Event: event}, nil
+	}
+
+	var deadline *string
+	if result.Deadline != nil {
+		formatted := result.Deadline.Format(time.RFC3339)
+		deadline = &formatted
+	}
+
+	var recurrence *interfaces.TaskRecurrence
+	if result.RecurrenceRule != "" {
+		recurrence = &interfaces.TaskRecurrence{
+			Type:           entities.RecurrenceSchedule,
+			RecurrenceRule: result.RecurrenceRule,
+			Timezone:       timezone,
+		}
+	}
+
+	task, err := s.taskService.CreateTask(userID, result.Title, "", result.Priority, domain, deadline, nil, recurrence, result.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	return &interfaces.QuickAddResult{Interpretation: result, Task: task}, nil
+}
+
+// interpret parses an input in the user's timezone with the user's active domains
+func (s *quickAddService) interpret(userID uuid.UUID, input interfaces.QuickAddInput) (*quickadd.Result, string, error) {
+	if strings.TrimSpace(input.Text) == "" {
+		return nil, "", errors.New("text is required")
+	}
+
+	timezone, err := resolveUserTimezone(s.userRepo, userID, input.Timezone)
+	if err != nil {
+		return nil, "", err
+	}
+	loc, _ := time.LoadLocation(timezone)
+
+	domains, err := userDomains(s.domainRepo, userID, false)
+	if err != nil {
+		return nil, "", err
+	}
+
+	result, err := quickadd.Parse(input.Text, quickadd.Options{
+		Now:  time.Now().In(loc),
+		Type: input.Type,
+		Domain: func(name string) (string, bool) {
+			domain := findQuickAddDomain(domains, name)
+			if domain == nil {
+				return "", false
+			}
+			return domain.Name, true
+		},
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return result, timezone, nil
+}
+
+// findQuickAddDomain finds a domain by a name written without spaces ("#CodingTime", "@coding_time", "#coding-time")
+func findQuickAddDomain(domains []*entities.Domain, name string) *entities.Domain {
+	key := quickAddDomainKey(name)
+	for _, domain := range domains {
+		if quickAddDomainKey(domain.Name) == key {
+			return domain
+		}
+	}
+	return nil
+}
+
+// quickAddDomainKey lowercases a domain name and removes spaces, underscores and dashes
+func quickAddDomainKey(name string) string {
+	return strings.ToLower(strings.NewReplacer(" ", "", "_", "", "-", "").Replace(name))
+}
@@ -0,0 +1,344 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
+	"gorm.io/gorm"
+
+	"github.com/google/uuid"
+)
+
+const (
+	reminderTickInterval = time.Minute
+
+	// reminderLookahead creates deliveries shortly before they are due, so they are sent on time
+	reminderLookahead = 15 * time.Minute
+
+	// reminderCatchUp still sends reminders that were missed while the server was down
+	reminderCatchUp = 6 * time.Hour
+
+	// reminderLease postpones claimed deliveries, a crash during sending retries them after the lease
+	reminderLease = 15 * time.Minute
+
+	reminderBatchSize   = 20
+	reminderSendTimeout = 15 * time.Second
+	maxReminderAttempts = 8
+	maxReminderBackoff  = time.Hour
+)
+
+type reminderScheduler struct {
+	reminderRepo   interfaces.ReminderRepository
+	eventRepo      interfaces.EventRepository
+	taskRepo       interfaces.TaskRepository
+	routineRepo    interfaces.RoutineRepository
+	userRepo       interfaces.UserRepository
+	eventService   interfaces.EventService
+	routineService interfaces.RoutineService
+	channels       map[string]interfaces.NotificationChannel
+}
+
+// reminderOccurrence is one due time of a reminder rule's entity
+type reminderOccurrence struct {
+	dueAt time.Time
+	title string
+}
+
+// NewReminderScheduler creates a new reminder scheduler
+func NewReminderScheduler(
+	reminderRepo interfaces.ReminderRepository,
+	eventRepo interfaces.EventRepository,
+	taskRepo interfaces.TaskRepository,
+	routineRepo interfaces.RoutineRepository,
+	userRepo interfaces.UserRepository,
+	eventService interfaces.EventService,
+	routineService interfaces.RoutineService,
+	channels []interfaces.NotificationChannel,
+) interfaces.ReminderScheduler {
+	return &reminderScheduler{
+		reminderRepo:   reminderRepo,
+		eventRepo:      eventRepo,
+		taskRepo:       taskRepo,
+		routineRepo:    routineRepo,
+		userRepo:       userRepo,
+		eventService:   eventService,
+		routineService: routineService,
+		channels:       channelMap(channels),
+	}
+}
+
+// Start runs the scheduler every minute until the context is cancelled
+func (s *reminderScheduler) Start(ctx context.Context) {
+	ticker := time.NewTicker(reminderTickInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.RunOnce(ctx, time.Now()); err != nil {
+			log.Printf("Reminder scheduler: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce plans upcoming deliveries and sends the due ones.
+// Planning is idempotent (one delivery per rule and due time), so overlapping runs and restarts never duplicate reminders.
+func (s *reminderScheduler) RunOnce(ctx context.Context, now time.Time) error {
+	if err := s.planDeliveries(now); err != nil {
+		return err
+	}
+	return s.sendDueDeliveries(ctx, now)
+}
+
+// planDeliveries creates deliveries for all occurrences whose reminder time is within the planning window
+func (s *reminderScheduler) planDeliveries(now time.Time) error {
+	rules, err := s.reminderRepo.FindAllRules()
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		offset := time.Duration(rule.OffsetMinutes) * time.Minute
+
+		// Never remind about occurrences from before the rule existed
+		from := now.Add(-reminderCatchUp)
+		if rule.CreatedAt.After(from) {
+			from = rule.CreatedAt
+		}
+		to := now.Add(reminderLookahead)
+
+		occurrences, err := s.findOccurrences(rule, from.Add(offset), to.Add(offset))
+		if err == gorm.ErrRecordNotFound {
+			// Entities in the trash keep their reminders for restoring
+			if s.inTrash(rule) {
+				continue
+			}
+
+			// The entity was deleted, its reminders go with it
+			if err := s.reminderRepo.DeleteRule(rule.ID); err != nil {
+				log.Printf("Reminder scheduler: failed to delete orphaned rule %s: %v", rule.ID, err)
+			}
+			continue
+		}
+		if err != nil {
+			log.Printf("Reminder scheduler: failed to plan rule %s: %v", rule.ID, err)
+			continue
+		}
+
+		for _, occurrence := range occurrences {
+			remindAt := occurrence.dueAt.Add(-offset)
+			delivery := &entities.ReminderDelivery{
+				ID:            uuid.New(),
+				UserID:        rule.UserID,
+				RuleID:        rule.ID,
+				EntityType:    rule.EntityType,
+				EntityID:      rule.EntityID,
+				Channel:       rule.Channel,
+				Title:         occurrence.title,
+				DueAt:         occurrence.dueAt,
+				RemindAt:      remindAt,
+				Status:        entities.DeliveryStatusPending,
+				NextAttemptAt: remindAt,
+				CreatedAt:     now,
+			}
+			if err := s.reminderRepo.CreateDelivery(delivery); err != nil {
+				log.Printf("Reminder scheduler: failed to schedule rule %s: %v", rule.ID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// inTrash checks if the entity of a reminder rule was moved to the trash
+func (s *reminderScheduler) inTrash(rule *entities.ReminderRule) bool {
+	var err error
+	switch rule.EntityType {
+	case entities.ReminderEntityEvent:
+		_, err = s.eventRepo.FindDeletedEventByID(rule.EntityID)
+	case entities.ReminderEntityTask:
+		_, err = s.taskRepo.FindDeletedTaskByID(rule.EntityID)
+	case entities.ReminderEntityRoutine:
+		_, err = s.routineRepo.GetDeletedRoutineByID(rule.EntityID)
+	default:
+		return false
+	}
+	return err == nil
+}
+
+// findOccurrences returns the due times of a rule's entity within [from, to]
+func (s *reminderScheduler) findOccurrences(rule *entities.ReminderRule, from, to time.Time) ([]reminderOccurrence, error) {
+	inWindow := func(t time.Time) bool {
+		return !t.Before(from) && !t.After(to)
+	}
+
+	switch rule.EntityType {
+	case entities.ReminderEntityEvent:
+		event, err := s.eventRepo.FindEventByID(rule.EntityID)
+		if err != nil {
+			return nil, err
+		}
+
+		// Expanded occurrences (exceptions applied), all share the event's ID
+		events, err := s.eventService.GetUserEventsInRange(rule.UserID, from, to, "")
+		if err != nil {
+			return nil, err
+		}
+
+		var occurrences []reminderOccurrence
+		for _, occurrence := range events {
+			if occurrence.ID == event.ID && inWindow(occurrence.StartDate) {
+				occurrences = append(occurrences, reminderOccurrence{dueAt: occurrence.StartDate, title: occurrence.Title})
+			}
+		}
+		return occurrences, nil
+
+	case entities.ReminderEntityTask:
+		task, err := s.taskRepo.FindTaskByID(rule.EntityID)
+		if err != nil {
+			return nil, err
+		}
+		if !task.IsOpen() || task.Deadline == nil || !inWindow(*task.Deadline) {
+			return nil, nil
+		}
+		return []reminderOccurrence{{dueAt: *task.Deadline, title: task.Title}}, nil
+
+	case entities.ReminderEntityRoutine:
+		routine, err := s.routineRepo.GetRoutineByID(rule.EntityID)
+		if err != nil {
+			return nil, err
+		}
+		if routine.TimeType != "Specific" || routine.SpecificTime == nil {
+			return nil, nil
+		}
+
+		// Routine days end at the user's rollover hour, a reminder at 01:30 can belong to the day before
+		// (the days around the window cover every timezone)
+		routineOccurrences, err := s.routineService.GetRoutineOccurrences(rule.UserID, from.AddDate(0, 0, -2), to.AddDate(0, 0, 1))
+		if err != nil {
+			return nil, err
+		}
+
+		// Period-based routines are reminded of every day until they are done for the period,
+		// completed or skipped routines need no reminder
+		var occurrences []reminderOccurrence
+		for _, occurrence := range routineOccurrences {
+			if occurrence.Routine.ID != routine.ID || occurrence.Start == nil || occurrence.Status != "pending" {
+				continue
+			}
+			if inWindow(*occurrence.Start) {
+				occurrences = append(occurrences, reminderOccurrence{dueAt: *occurrence.Start, title: routine.Title})
+			}
+		}
+		return occurrences, nil
+	}
+
+	return nil, fmt.Errorf("unknown entity type %q", rule.EntityType)
+}
+
+// sendDueDeliveries claims and sends due deliveries
+func (s *reminderScheduler) sendDueDeliveries(ctx context.Context, now time.Time) error {
+	deliveries, err := s.reminderRepo.ClaimDueDeliveries(now, reminderLease, reminderBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, delivery := range deliveries {
+		if ctx.Err() != nil {
+			// Unsent deliveries are picked up again after the lease
+			return ctx.Err()
+		}
+		s.send(ctx, delivery, now)
+	}
+
+	return nil
+}
+
+// send delivers one reminder and records the outcome (retries with exponential backoff)
+func (s *reminderScheduler) send(ctx context.Context, delivery *entities.ReminderDelivery, now time.Time) {
+	err := s.deliver(ctx, delivery)
+	if err == nil {
+		if err := s.reminderRepo.MarkDeliverySent(delivery.ID, time.Now()); err != nil {
+			log.Printf("Reminder scheduler: failed to mark delivery %s as sent: %v", delivery.ID, err)
+		}
+		return
+	}
+
+	var nextAttemptAt *time.Time
+	if delivery.Attempts < maxReminderAttempts {
+		backoff := time.Minute << (delivery.Attempts - 1)
+		if backoff > maxReminderBackoff {
+			backoff = maxReminderBackoff
+		}
+		next := now.Add(backoff)
+		nextAttemptAt = &next
+	}
+
+	log.Printf("Reminder scheduler: delivery %s via %s failed (attempt %d): %v", delivery.ID, delivery.Channel, delivery.Attempts, err)
+	if err := s.reminderRepo.MarkDeliveryAttemptFailed(delivery.ID, err.Error(), nextAttemptAt); err != nil {
+		log.Printf("Reminder scheduler: failed to record delivery %s: %v", delivery.ID, err)
+	}
+}
+
+// deliver builds the notification and hands it to the delivery's channel
+func (s *reminderScheduler) deliver(ctx context.Context, delivery *entities.ReminderDelivery) error {
+	channel, ok := s.channels[delivery.Channel]
+	if !ok {
+		return fmt.Errorf("channel %q is not configured", delivery.Channel)
+	}
+
+	rule, err := s.reminderRepo.FindRuleByID(delivery.RuleID)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.FindUserByID(delivery.UserID)
+	if err != nil {
+		return err
+	}
+
+	timezone, err := resolveUserTimezone(s.userRepo, delivery.UserID, "")
+	if err != nil {
+		return err
+	}
+	loc, _ := time.LoadLocation(timezone)
+
+	notification := &interfaces.Notification{
+		DeliveryID: delivery.ID,
+		UserID:     delivery.UserID,
+		EntityType: delivery.EntityType,
+		EntityID:   delivery.EntityID,
+		Title:      "Reminder: " + delivery.Title,
+		Body:       reminderBody(delivery, loc),
+		DueAt:      delivery.DueAt,
+		Email:      user.Email,
+		Target:     rule.Target,
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, reminderSendTimeout)
+	defer cancel()
+
+	return channel.Send(sendCtx, notification)
+}
+
+// reminderBody describes when the reminded entity is due (in the user's timezone)
+func reminderBody(delivery *entities.ReminderDelivery, loc *time.Location) string {
+	due := delivery.DueAt.In(loc).Format("Mon, 02 Jan 2006 15:04")
+
+	switch delivery.EntityType {
+	case entities.ReminderEntityEvent:
+		return fmt.Sprintf("%s starts %s", delivery.Title, due)
+	case entities.ReminderEntityTask:
+		return fmt.Sprintf("%s is due %s", delivery.Title, due)
+	default:
+		return fmt.Sprintf("%s is scheduled for %s", delivery.Title, due)
+	}
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+func TestFindOccurrencesRoutineRollover(t *testing.T) {
+	berlin := mustLoadLocation(t, "Europe/Berlin")
+	userID := uuid.New()
+
+	// Saturday night routine at 01:30, which is still Saturday's routine day with a 04:00 rollover
+	saturday := int(time.Saturday)
+	at := "01:30"
+	routine := &entities.Routine{
+		ID:           uuid.New(),
+		UserID:       userID,
+		Title:        "Wind down",
+		Frequency:    "Weekly",
+		Weekday:      &saturday,
+		TimeType:     "Specific",
+		SpecificTime: &at,
+	}
+	rule := &entities.ReminderRule{ID: uuid.New(), UserID: userID, EntityType: entities.ReminderEntityRoutine, EntityID: routine.ID}
+
+	from := time.Date(2026, 10, 16, 0, 0, 0, 0, berlin)
+	to := time.Date(2026, 10, 20, 0, 0, 0, 0, berlin)
+
+	tests := []struct {
+		name        string
+		completions []*entities.RoutineCompletion
+		want        []string
+	}{
+		{
+			name: "due after midnight of the routine day",
+			want: []string{"2026-10-18 01:30 +0200"},
+		},
+		{
+			name: "completion of the routine day",
+			completions: []*entities.RoutineCompletion{
+				{RoutineID: routine.ID, CompletedAt: time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC), Status: entities.RoutineCompleted},
+			},
+			want: nil,
+		},
+		{
+			name: "completion of the calendar day is another routine day",
+			completions: []*entities.RoutineCompletion{
+				{RoutineID: routine.ID, CompletedAt: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC), Status: entities.RoutineCompleted},
+			},
+			want: []string{"2026-10-18 01:30 +0200"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			routineRepo := &fakeRoutineRepo{routines: []*entities.Routine{routine}, completions: tt.completions}
+			userRepo := &fakeUserRepo{user: &entities.User{ID: userID, Timezone: "Europe/Berlin", DayRolloverHour: 4}}
+			s := &reminderScheduler{
+				routineRepo:    routineRepo,
+				userRepo:       userRepo,
+				routineService: &routineService{routineRepo: routineRepo, userRepo: userRepo},
+			}
+
+			occurrences, err := s.findOccurrences(rule, from, to)
+			if err != nil {
+				t.Fatalf("findOccurrences: %v", err)
+			}
+			if len(occurrences) != len(tt.want) {
+				t.Fatalf("got %d occurrences %v, want %d", len(occurrences), occurrences, len(tt.want))
+			}
+			for i, occurrence := range occurrences {
+				if got := occurrence.dueAt.In(berlin).Format("2006-01-02 15:04 -0700"); got != tt.want[i] {
+					t.Errorf("occurrence %d = %s, want %s", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package service
+
+import (
+	"errors"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// maxReminderOffset limits how long before the due time a reminder may fire
+	maxReminderOffset = 30 * 24 * 60
+
+	defaultDeliveryLimit = 50
+	maxDeliveryLimit     = 200
+)
+
+type reminderService struct {
+	reminderRepo   interfaces.ReminderRepository
+	pushRepo       interfaces.PushSubscriptionRepository
+	eventRepo      interfaces.EventRepository
+	taskRepo       interfaces.TaskRepository
+	routineRepo    interfaces.RoutineRepository
+	channels       map[string]interfaces.NotificationChannel
+	vapidPublicKey string
+}
+
+// NewReminderService creates a new reminder service
+func NewReminderService(
+	reminderRepo interfaces.ReminderRepository,
+	pushRepo interfaces.PushSubscriptionRepository,
+	eventRepo interfaces.EventRepository,
+	taskRepo interfaces.TaskRepository,
+	routineRepo interfaces.RoutineRepository,
+	channels []interfaces.NotificationChannel,
+	vapidPublicKey string,
+) interfaces.ReminderService {
+	return &reminderService{
+		reminderRepo:   reminderRepo,
+		pushRepo:       pushRepo,
+		eventRepo:      eventRepo,
+		taskRepo:       taskRepo,
+		routineRepo:    routineRepo,
+		channels:       channelMap(channels),
+		vapidPublicKey: vapidPublicKey,
+	}
+}
+
+// CreateRule creates a reminder rule for an event, task or routine (ensures user owns the entity)
+func (s *reminderService) CreateRule(userID uuid.UUID, entityType string, entityID uuid.UUID, offsetMinutes int, channel string, target *string) (*entities.ReminderRule, error) {
+	if offsetMinutes < 0 {
+		return nil, errors.New("offset cannot be negative")
+	}
+	if offsetMinutes > maxReminderOffset {
+		return nil, errors.New("offset cannot exceed 30 days")
+	}
+
+	if err := s.validateEntity(userID, entityType, entityID); err != nil {
+		return nil, err
+	}
+
+	notificationChannel, ok := s.channels[channel]
+	if !ok {
+		return nil, errors.New("unsupported channel")
+	}
+	if target != nil && *target == "" {
+		target = nil
+	}
+	if err := notificationChannel.ValidateTarget(target); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	rule := &entities.ReminderRule{
+		ID:            uuid.New(),
+		UserID:        userID,
+		EntityType:    entityType,
+		EntityID:      entityID,
+		OffsetMinutes: offsetMinutes,
+		Channel:       channel,
+		Target:        target,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	if err := s.reminderRepo.CreateRule(rule); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// GetRules retrieves the reminder rules of a user, optionally for a single entity
+func (s *reminderService) GetRules(userID uuid.UUID, entityType *string, entityID *uuid.UUID) ([]*entities.ReminderRule, error) {
+	return s.reminderRepo.FindRulesByUserID(userID, entityType, entityID)
+}
+
+// DeleteRule deletes a reminder rule (ensures user owns it)
+func (s *reminderService) DeleteRule(ruleID, userID uuid.UUID) error {
+	rule, err := s.reminderRepo.FindRuleByID(ruleID)
+	if err != nil {
+		return err
+	}
+
+	// Verify ownership
+	if rule.UserID != userID {
+		return errors.New("unauthorized: reminder does not belong to user")
+	}
+
+	return s.reminderRepo.DeleteRule(ruleID)
+}
+
+// GetDeliveries retrieves the most recent deliveries of a user
+func (s *reminderService) GetDeliveries(userID uuid.UUID, limit int) ([]*entities.ReminderDelivery, error) {
+	if limit <= 0 {
+		limit = defaultDeliveryLimit
+	}
+	if limit > maxDeliveryLimit {
+		limit = maxDeliveryLimit
+	}
+	return s.reminderRepo.FindDeliveriesByUserID(userID, limit)
+}
+
+// GetChannels returns the names of the configured channels
+func (s *reminderService) GetChannels() []string {
+	names := make([]string, 0, len(s.channels))
+	for name := range s.channels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetVAPIDPublicKey returns the key browsers subscribe with (empty if Web Push is not configured)
+func (s *reminderService) GetVAPIDPublicKey() string {
+	return s.vapidPublicKey
+}
+
+// SubscribePush stores a Web Push subscription for a user
+func (s *reminderService) SubscribePush(userID uuid.UUID, endpoint, p256dh, auth string) (*entities.PushSubscription, error) {
+	if s.vapidPublicKey == "" {
+		return nil, errors.New("web push is not configured")
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return nil, errors.New("invalid push endpoint")
+	}
+	if p256dh == "" || auth == "" {
+		return nil, errors.New("subscription keys are required")
+	}
+
+	subscription := &entities.PushSubscription{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Endpoint:  endpoint,
+		P256dh:    p256dh,
+		Auth:      auth,
+		CreatedAt: time.Now(),
+	}
+
+	if err := s.pushRepo.SaveSubscription(subscription); err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+// UnsubscribePush removes a Web Push subscription of a user
+func (s *reminderService) UnsubscribePush(userID uuid.UUID, endpoint string) error {
+	if endpoint == "" {
+		return errors.New("endpoint is required")
+	}
+	return s.pushRepo.DeleteSubscriptionByEndpoint(userID, endpoint)
+}
+
+// validateEntity checks that the entity exists, belongs to the user and has a due time
+func (s *reminderService) validateEntity(userID uuid.UUID, entityType string, entityID uuid.UUID) error {
+	switch entityType {
+	case entities.ReminderEntityEvent:
+		event, err := s.eventRepo.FindEventByID(entityID)
+		if err != nil {
+			return errors.New("event not found")
+		}
+		if event.UserID != userID {
+			return errors.New("unauthorized: event does not belong to user")
+		}
+
+	case entities.ReminderEntityTask:
+		task, err := s.taskRepo.FindTaskByID(entityID)
+		if err != nil {
+			return errors.New("task not found")
+		}
+		if task.UserID != userID {
+			return errors.New("unauthorized: task does not belong to user")
+		}
+		if task.Deadline == nil {
+			return errors.New("task has no deadline")
+		}
+
+	case entities.ReminderEntityRoutine:
+		routine, err := s.routineRepo.GetRoutineByID(entityID)
+		if err != nil {
+			return errors.New("routine not found")
+		}
+		if routine.UserID != userID {
+			return errors.New("unauthorized: routine does not belong to user")
+		}
+		if routine.TimeType != "Specific" || routine.SpecificTime == nil {
+			return errors.New("routine has no specific time")
+		}
+
+	default:
+		return errors.New("invalid entity type")
+	}
+
+	return nil
+}
+
+// channelMap indexes notification channels by name
+func channelMap(channels []interfaces.NotificationChannel) map[string]interfaces.NotificationChannel {
+	m := make(map[string]interfaces.NotificationChannel, len(channels))
+	for _, channel := range channels {
+		m[channel.Name()] = channel
+	}
+	return m
+}
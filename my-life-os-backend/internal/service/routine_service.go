@@ -11,14 +11,22 @@ import (
 	"github.com/google/uuid"
 )
 
+// routineMissed is the status of a scheduled day without completion or skip (never stored)
+const routineMissed = "missed"
+
+// maxRoutineStatsDays limits the range of routine statistics
+const maxRoutineStatsDays = 3 * 366
+
 type routineService struct {
 	routineRepo interfaces.RoutineRepository
+	userRepo    interfaces.UserRepository
 }
 
 // NewRoutineService creates a new routine service
-func NewRoutineService(routineRepo interfaces.RoutineRepository) interfaces.RoutineService {
+func NewRoutineService(routineRepo interfaces.RoutineRepository, userRepo interfaces.UserRepository) interfaces.RoutineService {
 	return &routineService{
 		routineRepo: routineRepo,
+		userRepo:    userRepo,
 	}
 }
 
@@ -28,6 +36,7 @@ func (s *routineService) CreateRoutine(
 	title, frequency string,
 	weekday, dayOfMonth, quarterlyDay *int,
 	yearlyDate *entities.YearlyDate,
+	schedule entities.RoutineSchedule,
 	isSkippable, showStreak bool,
 	timeType string,
 	specificTime *string,
@@ -38,7 +47,7 @@ func (s *routineService) CreateRoutine(
 	}
 
 	// Validate frequency
-	validFrequencies := []string{"Daily", "Weekly", "Monthly", "Quarterly", "Yearly"}
+	validFrequencies := []string{"Daily", "Weekly", "Monthly", "Quarterly", "Yearly", "Interval", "Quota"}
 	isFrequencyValid := false
 	for _, f := range validFrequencies {
 		if frequency == f {
@@ -50,8 +59,14 @@ func (s *routineService) CreateRoutine(
 		return nil, errors.New("invalid frequency")
 	}
 
+	// Interval routines start on the day they are created by default
+	if frequency == "Interval" && schedule.StartDate == nil {
+		startDate := s.clock(userID).today().Format("2006-01-02")
+		schedule.StartDate = &startDate
+	}
+
 	// Validate frequency-specific fields
-	if err := s.validateFrequencyFields(frequency, weekday, dayOfMonth, quarterlyDay, yearlyDate); err != nil {
+	if err := s.validateFrequencyFields(frequency, weekday, dayOfMonth, quarterlyDay, yearlyDate, schedule); err != nil {
 		return nil, err
 	}
 
@@ -75,22 +90,23 @@ func (s *routineService) CreateRoutine(
 
 	// Create routine
 	routine := &entities.Routine{
-		ID:            uuid.New(),
-		UserID:        userID,
-		Title:         title,
-		Frequency:     frequency,
-		Weekday:       weekday,
-		DayOfMonth:    dayOfMonth,
-		QuarterlyDay:  quarterlyDay,
-		YearlyDate:    yearlyDate,
-		IsSkippable:   isSkippable,
-		ShowStreak:    showStreak,
-		TimeType:      timeType,
-		SpecificTime:  specificTime,
-		CurrentStreak: 0,
-		LongestStreak: 0,
-		CreatedAt:     time.Now(),
-		UpdatedAt:     time.Now(),
+		ID:              uuid.New(),
+		UserID:          userID,
+		Title:           title,
+		Frequency:       frequency,
+		Weekday:         weekday,
+		DayOfMonth:      dayOfMonth,
+		QuarterlyDay:    quarterlyDay,
+		YearlyDate:      yearlyDate,
+		RoutineSchedule: schedule,
+		IsSkippable:     isSkippable,
+		ShowStreak:      showStreak,
+		TimeType:        timeType,
+		SpecificTime:    specificTime,
+		CurrentStreak:   0,
+		LongestStreak:   0,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
 	}
 
 	err := s.routineRepo.CreateRoutine(routine)
@@ -127,6 +143,7 @@ func (s *routineService) UpdateRoutine(
 	title, frequency *string,
 	weekday, dayOfMonth, quarterlyDay *int,
 	yearlyDate *entities.YearlyDate,
+	schedule entities.RoutineSchedule,
 	isSkippable, showStreak *bool,
 	timeType, specificTime *string,
 ) (*entities.Routine, error) {
@@ -141,9 +158,10 @@ func (s *routineService) UpdateRoutine(
 		routine.Title = *title
 	}
 
+	frequencyChanged := false
 	if frequency != nil && *frequency != "" {
 		// Validate frequency
-		validFrequencies := []string{"Daily", "Weekly", "Monthly", "Quarterly", "Yearly"}
+		validFrequencies := []string{"Daily", "Weekly", "Monthly", "Quarterly", "Yearly", "Interval", "Quota"}
 		isValid := false
 		for _, f := range validFrequencies {
 			if *frequency == f {
@@ -154,6 +172,7 @@ func (s *routineService) UpdateRoutine(
 		if !isValid {
 			return nil, errors.New("invalid frequency")
 		}
+		frequencyChanged = *frequency != routine.Frequency
 		routine.Frequency = *frequency
 	}
 
@@ -170,9 +189,43 @@ func (s *routineService) UpdateRoutine(
 	if yearlyDate != nil {
 		routine.YearlyDate = yearlyDate
 	}
+	if schedule.Weekdays != nil {
+		routine.Weekdays = schedule.Weekdays
+	}
+	if schedule.IntervalDays != nil {
+		routine.IntervalDays = schedule.IntervalDays
+	}
+	if schedule.StartDate != nil {
+		routine.StartDate = schedule.StartDate
+	}
+	if schedule.MonthlyRule != nil {
+		routine.MonthlyRule = schedule.MonthlyRule
+	}
+	if schedule.WeekOfMonth != nil {
+		routine.WeekOfMonth = schedule.WeekOfMonth
+	}
+	if schedule.QuotaCount != nil {
+		routine.QuotaCount = schedule.QuotaCount
+	}
+	if schedule.QuotaPeriod != nil {
+		routine.QuotaPeriod = schedule.QuotaPeriod
+	}
+	if schedule.CompletionWindow != nil {
+		routine.CompletionWindow = schedule.CompletionWindow
+		if *schedule.CompletionWindow == "" {
+			routine.CompletionWindow = nil // Back to exact days
+		}
+	}
+	if frequencyChanged {
+		clearScheduleFields(routine)
+	}
+	if routine.Frequency == "Interval" && routine.StartDate == nil {
+		startDate := s.clock(userID).today().Format("2006-01-02")
+		routine.StartDate = &startDate
+	}
 
 	// Validate updated frequency fields
-	if err := s.validateFrequencyFields(routine.Frequency, routine.Weekday, routine.DayOfMonth, routine.QuarterlyDay, routine.YearlyDate); err != nil {
+	if err := s.validateFrequencyFields(routine.Frequency, routine.Weekday, routine.DayOfMonth, routine.QuarterlyDay, routine.YearlyDate, routine.RoutineSchedule); err != nil {
 		return nil, err
 	}
 
@@ -214,10 +267,15 @@ func (s *routineService) UpdateRoutine(
 		return nil, err
 	}
 
+	// The schedule decides which days count for the streaks
+	if err := s.recalculateStreaks(routine); err != nil {
+		return nil, err
+	}
+
 	return routine, nil
 }
 
-// DeleteRoutine deletes a routine
+// DeleteRoutine moves a routine to the trash
 func (s *routineService) DeleteRoutine(routineID, userID uuid.UUID) error {
 	// Verify ownership first
 	_, err := s.GetRoutine(routineID, userID)
@@ -228,104 +286,486 @@ func (s *routineService) DeleteRoutine(routineID, userID uuid.UUID) error {
 	return s.routineRepo.DeleteRoutine(routineID)
 }
 
-// CompleteRoutine marks a routine as completed for today and updates streak
+// CompleteRoutine marks a routine as completed for today (the user's current routine day) and updates streak
 func (s *routineService) CompleteRoutine(routineID, userID uuid.UUID) error {
+	_, _, err := s.recordToday(routineID, userID, entities.RoutineCompleted)
+	return err
+}
+
+// SkipRoutine marks a routine as skipped for today (preserves streak if isSkippable=true)
+func (s *routineService) SkipRoutine(routineID, userID uuid.UUID) error {
+	_, _, err := s.recordToday(routineID, userID, entities.RoutineSkipped)
+	return err
+}
+
+// recordToday records a completion/skip for today
+func (s *routineService) recordToday(routineID, userID uuid.UUID, status string) (*entities.RoutineCompletion, *entities.Routine, error) {
 	// Get routine and verify ownership
 	routine, err := s.GetRoutine(routineID, userID)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
-	today := time.Now()
-	todayDate := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	today := s.clock(userID).today()
 
 	// Check if already completed/skipped today
-	existingCompletion, err := s.routineRepo.GetCompletionForDate(routineID, todayDate)
+	existingCompletion, err := s.routineRepo.GetCompletionForDate(routineID, today)
 	if err != nil && err != gorm.ErrRecordNotFound {
-		return err
+		return nil, nil, err
+	}
+	if existingCompletion != nil {
+		return nil, nil, errors.New("routine already completed or skipped today")
+	}
+
+	return s.recordCompletion(routine, today, status)
+}
+
+// LogCompletion records a completion/skip for today or a past date (YYYY-MM-DD) and recomputes the streaks
+func (s *routineService) LogCompletion(routineID, userID uuid.UUID, date, status string) (*entities.RoutineCompletion, *entities.Routine, error) {
+	// Get routine and verify ownership
+	routine, err := s.GetRoutine(routineID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := validateCompletionStatus(status); err != nil {
+		return nil, nil, err
+	}
+	day, err := parseCompletionDate(date, s.clock(userID).today())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// One completion/skip per day
+	existingCompletion, err := s.routineRepo.GetCompletionForDate(routineID, day)
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return nil, nil, err
 	}
 	if existingCompletion != nil {
-		return errors.New("routine already completed or skipped today")
+		return nil, nil, errors.New("routine already completed or skipped on " + date)
 	}
 
-	// Record completion
+	return s.recordCompletion(routine, day, status)
+}
+
+// recordCompletion stores a completion/skip of a routine and recomputes the streaks
+func (s *routineService) recordCompletion(routine *entities.Routine, day time.Time, status string) (*entities.RoutineCompletion, *entities.Routine, error) {
 	completion := &entities.RoutineCompletion{
 		ID:          uuid.New(),
-		RoutineID:   routineID,
-		UserID:      userID,
-		CompletedAt: todayDate,
-		Status:      "completed",
+		RoutineID:   routine.ID,
+		UserID:      routine.UserID,
+		CompletedAt: day,
+		Status:      status,
 		CreatedAt:   time.Now(),
 	}
 
-	err = s.routineRepo.RecordCompletion(completion)
+	if err := s.routineRepo.RecordCompletion(completion); err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.recalculateStreaks(routine); err != nil {
+		return nil, nil, err
+	}
+
+	return completion, routine, nil
+}
+
+// UpdateCompletion changes the date (YYYY-MM-DD) or status of a completion/skip and recomputes the streaks
+func (s *routineService) UpdateCompletion(routineID, completionID, userID uuid.UUID, date, status *string) (*entities.RoutineCompletion, *entities.Routine, error) {
+	routine, completion, err := s.getCompletion(routineID, completionID, userID)
 	if err != nil {
-		return err
+		return nil, nil, err
+	}
+
+	if status != nil && *status != "" {
+		if err := validateCompletionStatus(*status); err != nil {
+			return nil, nil, err
+		}
+		completion.Status = *status
+	}
+
+	if date != nil && *date != "" {
+		day, err := parseCompletionDate(*date, s.clock(userID).today())
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// One completion/skip per day
+		existingCompletion, err := s.routineRepo.GetCompletionForDate(routineID, day)
+		if err != nil && err != gorm.ErrRecordNotFound {
+			return nil, nil, err
+		}
+		if existingCompletion != nil && existingCompletion.ID != completion.ID {
+			return nil, nil, errors.New("routine already completed or skipped on " + *date)
+		}
+		completion.CompletedAt = day
+	}
+
+	if err := s.routineRepo.UpdateCompletion(completion); err != nil {
+		return nil, nil, err
 	}
 
-	// Calculate and update streak
-	newStreak := s.calculateStreak(routine, todayDate)
-	longestStreak := routine.LongestStreak
-	if newStreak > longestStreak {
-		longestStreak = newStreak
+	if err := s.recalculateStreaks(routine); err != nil {
+		return nil, nil, err
 	}
 
-	err = s.routineRepo.UpdateStreak(routineID, newStreak, longestStreak)
+	return completion, routine, nil
+}
+
+// DeleteCompletion removes a completion/skip (e.g. a mistaken tap) and recomputes the streaks
+func (s *routineService) DeleteCompletion(routineID, completionID, userID uuid.UUID) (*entities.Routine, error) {
+	routine, completion, err := s.getCompletion(routineID, completionID, userID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	if err := s.routineRepo.DeleteCompletion(completion.ID); err != nil {
+		return nil, err
+	}
+
+	if err := s.recalculateStreaks(routine); err != nil {
+		return nil, err
+	}
+
+	return routine, nil
 }
 
-// SkipRoutine marks a routine as skipped for today (preserves streak if isSkippable=true)
-func (s *routineService) SkipRoutine(routineID, userID uuid.UUID) error {
-	// Get routine and verify ownership
+// GetCompletions retrieves the completions/skips of a routine between two dates
+// (YYYY-MM-DD, inclusive, oldest first, empty = last 30 days)
+func (s *routineService) GetCompletions(routineID, userID uuid.UUID, from, to string) ([]*entities.RoutineCompletion, error) {
+	// Verify ownership
+	if _, err := s.GetRoutine(routineID, userID); err != nil {
+		return nil, err
+	}
+
+	last := s.clock(userID).today()
+	if to != "" {
+		day, err := time.Parse("2006-01-02", to)
+		if err != nil {
+			return nil, errors.New("invalid to date (use YYYY-MM-DD)")
+		}
+		last = day
+	}
+	first := last.AddDate(0, 0, -29)
+	if from != "" {
+		day, err := time.Parse("2006-01-02", from)
+		if err != nil {
+			return nil, errors.New("invalid from date (use YYYY-MM-DD)")
+		}
+		first = day
+	}
+	if last.Before(first) {
+		return nil, errors.New("invalid date range")
+	}
+
+	return s.routineRepo.GetCompletionsInRange(routineID, first, last)
+}
+
+// getCompletion retrieves a completion/skip of a routine (ensures user owns the routine)
+func (s *routineService) getCompletion(routineID, completionID, userID uuid.UUID) (*entities.Routine, *entities.RoutineCompletion, error) {
 	routine, err := s.GetRoutine(routineID, userID)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
-	today := time.Now()
-	todayDate := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	completion, err := s.routineRepo.GetCompletionByID(completionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if completion.RoutineID != routine.ID {
+		return nil, nil, gorm.ErrRecordNotFound
+	}
 
-	// Check if already completed/skipped today
-	existingCompletion, err := s.routineRepo.GetCompletionForDate(routineID, todayDate)
-	if err != nil && err != gorm.ErrRecordNotFound {
-		return err
+	return routine, completion, nil
+}
+
+// validateCompletionStatus checks the status of a completion/skip
+func validateCompletionStatus(status string) error {
+	if status != entities.RoutineCompleted && status != entities.RoutineSkipped {
+		return errors.New("invalid status (use completed or skipped)")
 	}
-	if existingCompletion != nil {
-		return errors.New("routine already completed or skipped today")
+	return nil
+}
+
+// parseCompletionDate parses the date of a completion/skip, which must not be after the routine day today
+func parseCompletionDate(date string, today time.Time) (time.Time, error) {
+	day, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return time.Time{}, errors.New("invalid date (use YYYY-MM-DD)")
+	}
+	if day.After(today) {
+		return time.Time{}, errors.New("invalid date: completions cannot be in the future")
 	}
+	return day, nil
+}
 
-	// Record skip
-	completion := &entities.RoutineCompletion{
-		ID:          uuid.New(),
-		RoutineID:   routineID,
-		UserID:      userID,
-		CompletedAt: todayDate,
-		Status:      "skipped",
-		CreatedAt:   time.Now(),
+// routineDate returns the calendar date completions are stored under
+func routineDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// routineClock maps moments to the routine days of a user: calendar days in the user's timezone
+// that end at the rollover hour instead of midnight (for night owls)
+type routineClock struct {
+	loc          *time.Location
+	rolloverHour int
+}
+
+// clock returns the routine clock of a user (UTC days ending at midnight if the user is unknown)
+func (s *routineService) clock(userID uuid.UUID) routineClock {
+	clock := routineClock{loc: time.UTC}
+	user, err := s.userRepo.FindUserByID(userID)
+	if err != nil {
+		return clock
+	}
+	if loc, err := time.LoadLocation(user.Timezone); err == nil && user.Timezone != "" {
+		clock.loc = loc
+	}
+	clock.rolloverHour = user.DayRolloverHour
+	return clock
+}
+
+// day returns the routine day a moment belongs to (as stored in completions).
+// Comparing wall-clock hours instead of subtracting a duration keeps DST days right.
+func (c routineClock) day(t time.Time) time.Time {
+	local := t.In(c.loc)
+	day := local.Day()
+	if local.Hour() < c.rolloverHour {
+		day-- // Still the previous day (time.Date normalizes day 0)
+	}
+	return time.Date(local.Year(), local.Month(), day, 0, 0, 0, 0, time.UTC)
+}
+
+// today returns the current routine day
+func (c routineClock) today() time.Time {
+	return c.day(time.Now())
+}
+
+// start returns when a routine with a specific time takes place on a routine day (nil without one).
+// Times before the rollover hour fall into the night after the day.
+func (c routineClock) start(routine *entities.Routine, day time.Time) *time.Time {
+	if routine.TimeType != "Specific" || routine.SpecificTime == nil {
+		return nil
+	}
+	at, err := time.Parse("15:04", *routine.SpecificTime)
+	if err != nil {
+		return nil
 	}
 
-	err = s.routineRepo.RecordCompletion(completion)
+	date := day.Day()
+	if at.Hour() < c.rolloverHour {
+		date++ // time.Date normalizes the end of the month
+	}
+	start := time.Date(day.Year(), day.Month(), date, at.Hour(), at.Minute(), 0, 0, c.loc)
+	return &start
+}
+
+// GetRoutineStats retrieves the statistics of a routine (ensures user owns it)
+func (s *routineService) GetRoutineStats(routineID, userID uuid.UUID, from, to string) (*interfaces.RoutineStats, error) {
+	routine, err := s.GetRoutine(routineID, userID)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	clock := s.clock(userID)
+	first, last, err := routineStatsRange(from, to, clock.today())
+	if err != nil {
+		return nil, err
+	}
+
+	// The previous period is loaded too for the trend
+	completions, err := s.routineRepo.GetCompletionsInRange(routineID, previousPeriod(first, last), last)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := s.routineStats([]*entities.Routine{routine}, completions, first, last, clock)
+	stats.RoutineID = &routine.ID
+	return stats, nil
+}
+
+// GetAllRoutineStats retrieves the statistics of all routines of a user (counts of all routines per day)
+func (s *routineService) GetAllRoutineStats(userID uuid.UUID, from, to string) (*interfaces.RoutineStats, error) {
+	clock := s.clock(userID)
+	first, last, err := routineStatsRange(from, to, clock.today())
+	if err != nil {
+		return nil, err
+	}
+
+	routines, err := s.routineRepo.GetRoutinesByUserID(userID, nil)
+	if err != nil {
+		return nil, err
+	}
+	completions, err := s.routineRepo.GetCompletionsByUserIDInRange(userID, previousPeriod(first, last), last)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.routineStats(routines, completions, first, last, clock), nil
+}
+
+// routineStats evaluates routines day by day between two dates.
+// Missed days are derived from the frequency, days before a routine was created are not missed.
+func (s *routineService) routineStats(routines []*entities.Routine, completions []*entities.RoutineCompletion, first, last time.Time, clock routineClock) *interfaces.RoutineStats {
+	statuses := completionStatuses(routines, completions)
+
+	// evaluate counts the routines of a day and the ones breaking a streak
+	today := clock.today()
+	evaluate := func(day time.Time) (interfaces.RoutineDay, int) {
+		result := interfaces.RoutineDay{Date: day.Format("2006-01-02")}
+		breaks := 0
+		for _, routine := range routines {
+			switch s.routineDayStatus(routine, day, today, statuses[routine.ID]) {
+			case entities.RoutineCompleted:
+				result.Completed++
+			case entities.RoutineSkipped:
+				result.Skipped++
+				if !routine.IsSkippable {
+					breaks++
+				}
+			case routineMissed:
+				if !day.Before(clock.day(routine.CreatedAt)) {
+					result.Missed++
+					breaks++
+				}
+			}
+		}
+		return result, breaks
+	}
+
+	stats := &interfaces.RoutineStats{
+		From:         first.Format("2006-01-02"),
+		To:           last.Format("2006-01-02"),
+		Heatmap:      []interfaces.RoutineDay{},
+		Weeks:        []interfaces.RoutineRate{},
+		Months:       []interfaces.RoutineRate{},
+		Weekdays:     make([]interfaces.RoutineRate, 7),
+		BestWeekdays: []int{},
+	}
+	for i := range stats.Weekdays {
+		weekday := i
+		stats.Weekdays[i].Weekday = &weekday
 	}
 
-	// If not skippable, reset streak to 0
-	if !routine.IsSkippable {
-		err = s.routineRepo.UpdateStreak(routineID, 0, routine.LongestStreak)
+	// Previous period of the same length (for the trend)
+	previousDue, previousCompleted := 0, 0
+	for day := previousPeriod(first, last); day.Before(first); day = day.AddDate(0, 0, 1) {
+		result, _ := evaluate(day)
+		previousDue += result.Completed + result.Skipped + result.Missed
+		previousCompleted += result.Completed
+	}
+
+	run, runStart := 0, ""
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		result, breaks := evaluate(day)
+		stats.Heatmap = append(stats.Heatmap, result)
+
+		due := result.Completed + result.Skipped + result.Missed
+		stats.Due += due
+		stats.Completed += result.Completed
+		stats.Skipped += result.Skipped
+		stats.Missed += result.Missed
+
+		week := weekStart(day).Format("2006-01-02")
+		if len(stats.Weeks) == 0 || stats.Weeks[len(stats.Weeks)-1].Period != week {
+			stats.Weeks = append(stats.Weeks, interfaces.RoutineRate{Period: week})
+		}
+		addRoutineRate(&stats.Weeks[len(stats.Weeks)-1], due, result.Completed)
+
+		month := day.Format("2006-01")
+		if len(stats.Months) == 0 || stats.Months[len(stats.Months)-1].Period != month {
+			stats.Months = append(stats.Months, interfaces.RoutineRate{Period: month})
+		}
+		addRoutineRate(&stats.Months[len(stats.Months)-1], due, result.Completed)
+
+		addRoutineRate(&stats.Weekdays[day.Weekday()], due, result.Completed)
+
+		// Streaks follow the same rules as the routine streaks (days without anything due keep them)
+		if breaks > 0 {
+			run = 0
+		} else if result.Completed > 0 {
+			if run == 0 {
+				runStart = result.Date
+			}
+			run++
+			if run > stats.LongestStreak.Length {
+				stats.LongestStreak = interfaces.StreakWindow{Length: run, Start: runStart, End: result.Date}
+			}
+		}
+	}
+
+	// Best weekdays (all with the highest rate)
+	best := -1.0
+	for _, weekday := range stats.Weekdays {
+		if weekday.Due > 0 && weekday.Rate > best {
+			best = weekday.Rate
+		}
+	}
+	for i, weekday := range stats.Weekdays {
+		if weekday.Due > 0 && weekday.Rate == best {
+			stats.BestWeekdays = append(stats.BestWeekdays, i)
+		}
+	}
+
+	stats.Trend.Rate = completionRate(stats.Completed, stats.Due)
+	if previousDue > 0 {
+		previousRate := completionRate(previousCompleted, previousDue)
+		change := stats.Trend.Rate - previousRate
+		stats.Trend.PreviousRate = &previousRate
+		stats.Trend.Change = &change
+	}
+
+	return stats
+}
+
+// routineStatsRange parses a statistics range (YYYY-MM-DD, inclusive, default the last 365 days up to today)
+func routineStatsRange(from, to string, today time.Time) (time.Time, time.Time, error) {
+	last := today
+	if to != "" {
+		day, err := time.Parse("2006-01-02", to)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("invalid to date (use YYYY-MM-DD)")
+		}
+		last = day
+	}
+	first := last.AddDate(0, 0, -364)
+	if from != "" {
+		day, err := time.Parse("2006-01-02", from)
 		if err != nil {
-			return err
+			return time.Time{}, time.Time{}, errors.New("invalid from date (use YYYY-MM-DD)")
 		}
+		first = day
 	}
-	// If skippable, streak is preserved (no update needed)
 
-	return nil
+	if last.Before(first) || last.Sub(first) > maxRoutineStatsDays*24*time.Hour {
+		return time.Time{}, time.Time{}, errors.New("invalid date range")
+	}
+	return first, last, nil
+}
+
+// previousPeriod returns the first day of the period of the same length before a range
+func previousPeriod(first, last time.Time) time.Time {
+	days := int(last.Sub(first).Hours()/24) + 1
+	return first.AddDate(0, 0, -days)
+}
+
+// addRoutineRate adds the due and completed routines of a day to a rate
+func addRoutineRate(rate *interfaces.RoutineRate, due, completed int) {
+	rate.Due += due
+	rate.Completed += completed
+	rate.Rate = completionRate(rate.Completed, rate.Due)
+}
+
+// completionRate returns the share of due routines that were completed (0 without due routines)
+func completionRate(completed, due int) float64 {
+	if due == 0 {
+		return 0
+	}
+	return float64(completed) / float64(due)
 }
 
 // GetTodaysRoutines retrieves routines that are relevant for today based on frequency
+// (today is the routine day in the user's timezone, which ends at the rollover hour)
 func (s *routineService) GetTodaysRoutines(userID uuid.UUID) ([]*entities.Routine, error) {
 	// Get all user routines
 	allRoutines, err := s.routineRepo.GetRoutinesByUserID(userID, nil)
@@ -333,10 +773,22 @@ func (s *routineService) GetTodaysRoutines(userID uuid.UUID) ([]*entities.Routin
 		return nil, err
 	}
 
-	today := time.Now()
+	today := s.clock(userID).today()
 	var todaysRoutines []*entities.Routine
 
 	for _, routine := range allRoutines {
+		// Period-based routines stay until they are done for the period or the period ends
+		if periodBased(routine) {
+			open, err := s.periodOpen(routine, today)
+			if err != nil {
+				return nil, err
+			}
+			if open {
+				todaysRoutines = append(todaysRoutines, routine)
+			}
+			continue
+		}
+
 		if s.matchesFrequency(routine, today) {
 			todaysRoutines = append(todaysRoutines, routine)
 		}
@@ -345,20 +797,142 @@ func (s *routineService) GetTodaysRoutines(userID uuid.UUID) ([]*entities.Routin
 	return todaysRoutines, nil
 }
 
+// GetRoutineOccurrences lists the routines of each routine day between two dates with their state
+// (the completions of all routines are loaded at once)
+func (s *routineService) GetRoutineOccurrences(userID uuid.UUID, first, last time.Time) ([]*interfaces.RoutineOccurrence, error) {
+	first, last = routineDate(first), routineDate(last)
+
+	routines, err := s.routineRepo.GetRoutinesByUserID(userID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Period-based routines count the completions since the start of their period
+	from := first
+	for _, routine := range routines {
+		if !periodBased(routine) {
+			continue
+		}
+		if start, _ := routinePeriod(routine, first); start.Before(from) {
+			from = start
+		}
+	}
+	completions, err := s.routineRepo.GetCompletionsByUserIDInRange(userID, from, last)
+	if err != nil {
+		return nil, err
+	}
+	statuses := completionStatuses(routines, completions)
+
+	clock := s.clock(userID)
+	var occurrences []*interfaces.RoutineOccurrence
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		for _, routine := range routines {
+			status, done := statuses[routine.ID][day]
+			if periodBased(routine) {
+				// Listed while the period is open and on the days it was done
+				if !done && !s.periodOpenOn(routine, day, statuses[routine.ID]) {
+					continue
+				}
+			} else if !s.matchesFrequency(routine, day) {
+				continue
+			}
+			if !done {
+				status = "pending"
+			}
+
+			occurrences = append(occurrences, &interfaces.RoutineOccurrence{
+				Routine: routine,
+				Day:     day,
+				Start:   clock.start(routine, day),
+				Status:  status,
+			})
+		}
+	}
+
+	return occurrences, nil
+}
+
+// completionStatuses maps the completions to their routine and day (completions of trashed routines are ignored)
+func completionStatuses(routines []*entities.Routine, completions []*entities.RoutineCompletion) map[uuid.UUID]map[time.Time]string {
+	statuses := make(map[uuid.UUID]map[time.Time]string, len(routines))
+	for _, routine := range routines {
+		statuses[routine.ID] = make(map[time.Time]string)
+	}
+	for _, completion := range completions {
+		if days, ok := statuses[completion.RoutineID]; ok {
+			days[routineDate(completion.CompletedAt)] = completion.Status
+		}
+	}
+	return statuses
+}
+
+// clearScheduleFields resets the schedule fields that do not belong to a routine's frequency
+// (after a frequency change the old settings would fail validation or keep affecting the schedule and stats)
+func clearScheduleFields(routine *entities.Routine) {
+	frequency := routine.Frequency
+	if frequency != "Weekly" && frequency != "Monthly" {
+		routine.Weekday = nil // Monthly uses it for the NthWeekday rule
+	}
+	if frequency != "Weekly" {
+		routine.Weekdays = nil
+	}
+	if frequency != "Monthly" {
+		routine.DayOfMonth = nil
+		routine.MonthlyRule = nil
+		routine.WeekOfMonth = nil
+	}
+	if frequency != "Quarterly" {
+		routine.QuarterlyDay = nil
+	}
+	if frequency != "Yearly" {
+		routine.YearlyDate = nil
+	}
+	if frequency != "Interval" {
+		routine.IntervalDays = nil
+		routine.StartDate = nil
+	}
+	if frequency != "Quota" {
+		routine.QuotaCount = nil
+		routine.QuotaPeriod = nil
+	}
+	if frequency == "Daily" || frequency == "Quota" {
+		routine.CompletionWindow = nil
+	}
+}
+
 // validateFrequencyFields validates that required fields for each frequency are set
 func (s *routineService) validateFrequencyFields(
 	frequency string,
 	weekday, dayOfMonth, quarterlyDay *int,
 	yearlyDate *entities.YearlyDate,
+	schedule entities.RoutineSchedule,
 ) error {
+	if schedule.CompletionWindow != nil {
+		if frequency == "Daily" || frequency == "Quota" {
+			return errors.New("completionWindow is not supported for Daily and Quota frequency")
+		}
+		if err := validateRoutinePeriod(*schedule.CompletionWindow); err != nil {
+			return errors.New("invalid completionWindow")
+		}
+	}
+
 	switch frequency {
 	case "Daily":
 		// No additional fields required
 		return nil
 
 	case "Weekly":
+		// Several weekdays take precedence over a single one
+		if len(schedule.Weekdays) > 0 {
+			for _, day := range schedule.Weekdays {
+				if day < 0 || day > 6 {
+					return errors.New("weekdays must be between 0 (Sunday) and 6 (Saturday)")
+				}
+			}
+			return nil
+		}
 		if weekday == nil {
-			return errors.New("weekday is required for Weekly frequency")
+			return errors.New("weekday or weekdays is required for Weekly frequency")
 		}
 		if *weekday < 0 || *weekday > 6 {
 			return errors.New("weekday must be between 0 (Sunday) and 6 (Saturday)")
@@ -366,11 +940,33 @@ func (s *routineService) validateFrequencyFields(
 		return nil
 
 	case "Monthly":
-		if dayOfMonth == nil {
-			return errors.New("dayOfMonth is required for Monthly frequency")
+		rule := entities.MonthlyRuleDay
+		if schedule.MonthlyRule != nil {
+			rule = *schedule.MonthlyRule
 		}
-		if *dayOfMonth < 1 || *dayOfMonth > 31 {
-			return errors.New("dayOfMonth must be between 1 and 31")
+
+		switch rule {
+		case entities.MonthlyRuleDay:
+			if dayOfMonth == nil {
+				return errors.New("dayOfMonth is required for Monthly frequency")
+			}
+			if *dayOfMonth < 1 || *dayOfMonth > 31 {
+				return errors.New("dayOfMonth must be between 1 and 31")
+			}
+		case entities.MonthlyRuleLastDay:
+			// No additional fields required
+		case entities.MonthlyRuleNthWeekday:
+			if weekday == nil || schedule.WeekOfMonth == nil {
+				return errors.New("weekday and weekOfMonth are required for the NthWeekday rule")
+			}
+			if *weekday < 0 || *weekday > 6 {
+				return errors.New("weekday must be between 0 (Sunday) and 6 (Saturday)")
+			}
+			if *schedule.WeekOfMonth != -1 && (*schedule.WeekOfMonth < 1 || *schedule.WeekOfMonth > 5) {
+				return errors.New("weekOfMonth must be between 1 and 5 or -1 (last)")
+			}
+		default:
+			return errors.New("invalid monthlyRule")
 		}
 		return nil
 
@@ -395,6 +991,43 @@ func (s *routineService) validateFrequencyFields(
 		}
 		return nil
 
+	case "Interval":
+		if schedule.IntervalDays == nil {
+			return errors.New("intervalDays is required for Interval frequency")
+		}
+		if *schedule.IntervalDays < 1 || *schedule.IntervalDays > 365 {
+			return errors.New("intervalDays must be between 1 and 365")
+		}
+		if schedule.StartDate == nil {
+			return errors.New("startDate is required for Interval frequency")
+		}
+		if _, err := time.Parse("2006-01-02", *schedule.StartDate); err != nil {
+			return errors.New("invalid startDate (use YYYY-MM-DD)")
+		}
+		return nil
+
+	case "Quota":
+		if schedule.QuotaCount == nil || schedule.QuotaPeriod == nil {
+			return errors.New("quotaCount and quotaPeriod are required for Quota frequency")
+		}
+		switch *schedule.QuotaPeriod {
+		case entities.RoutinePeriodWeek:
+			if *schedule.QuotaCount < 1 || *schedule.QuotaCount > 7 {
+				return errors.New("quotaCount must be between 1 and 7 per week")
+			}
+		case entities.RoutinePeriodMonth:
+			if *schedule.QuotaCount < 1 || *schedule.QuotaCount > 28 {
+				return errors.New("quotaCount must be between 1 and 28 per month")
+			}
+		case entities.RoutinePeriodQuarter:
+			if *schedule.QuotaCount < 1 || *schedule.QuotaCount > 90 {
+				return errors.New("quotaCount must be between 1 and 90 per quarter")
+			}
+		default:
+			return errors.New("invalid quotaPeriod")
+		}
+		return nil
+
 	default:
 		return errors.New("invalid frequency")
 	}
@@ -407,16 +1040,42 @@ func (s *routineService) matchesFrequency(routine *entities.Routine, date time.T
 		return true
 
 	case "Weekly":
+		if len(routine.Weekdays) > 0 {
+			for _, day := range routine.Weekdays {
+				if int(date.Weekday()) == day {
+					return true
+				}
+			}
+			return false
+		}
 		if routine.Weekday == nil {
 			return false
 		}
 		return int(date.Weekday()) == *routine.Weekday
 
 	case "Monthly":
-		if routine.DayOfMonth == nil {
-			return false
+		rule := entities.MonthlyRuleDay
+		if routine.MonthlyRule != nil {
+			rule = *routine.MonthlyRule
+		}
+
+		switch rule {
+		case entities.MonthlyRuleLastDay:
+			return date.AddDate(0, 0, 1).Day() == 1
+		case entities.MonthlyRuleNthWeekday:
+			if routine.Weekday == nil || routine.WeekOfMonth == nil || int(date.Weekday()) != *routine.Weekday {
+				return false
+			}
+			if *routine.WeekOfMonth == -1 {
+				return date.AddDate(0, 0, 7).Month() != date.Month() // No such weekday left this month
+			}
+			return (date.Day()-1)/7+1 == *routine.WeekOfMonth
+		default:
+			if routine.DayOfMonth == nil {
+				return false
+			}
+			return date.Day() == *routine.DayOfMonth
 		}
-		return date.Day() == *routine.DayOfMonth
 
 	case "Quarterly":
 		if routine.QuarterlyDay == nil {
@@ -433,53 +1092,216 @@ func (s *routineService) matchesFrequency(routine *entities.Routine, date time.T
 		}
 		return int(date.Month()) == routine.YearlyDate.Month && date.Day() == routine.YearlyDate.Day
 
+	case "Interval":
+		if routine.IntervalDays == nil || *routine.IntervalDays < 1 || routine.StartDate == nil {
+			return false
+		}
+		start, err := time.Parse("2006-01-02", *routine.StartDate)
+		if err != nil {
+			return false
+		}
+		day := routineDate(date)
+		if day.Before(start) {
+			return false
+		}
+		days := int(day.Sub(start).Hours() / 24)
+		return days%*routine.IntervalDays == 0
+
+	case "Quota":
+		// Any day counts towards the quota of the period
+		return true
+
 	default:
 		return false
 	}
 }
 
-// calculateStreak calculates the current streak based on completion history
-func (s *routineService) calculateStreak(routine *entities.Routine, upToDate time.Time) int {
-	streak := 1 // Today's completion counts as 1
+// validateRoutinePeriod validates a quota period or completion window
+func validateRoutinePeriod(period string) error {
+	switch period {
+	case entities.RoutinePeriodWeek, entities.RoutinePeriodMonth, entities.RoutinePeriodQuarter:
+		return nil
+	default:
+		return errors.New("invalid period")
+	}
+}
+
+// periodBased checks if a routine is due per period (quota or completion window) instead of on exact days
+func periodBased(routine *entities.Routine) bool {
+	return routine.Frequency == "Quota" || routine.CompletionWindow != nil
+}
 
-	// Get expected dates going backwards from yesterday
-	checkDate := upToDate.AddDate(0, 0, -1) // Start from yesterday
+// routinePeriod returns the first and last day of the quota period or completion window containing a day
+func routinePeriod(routine *entities.Routine, day time.Time) (time.Time, time.Time) {
+	period := entities.RoutinePeriodWeek
+	if routine.Frequency == "Quota" && routine.QuotaPeriod != nil {
+		period = *routine.QuotaPeriod
+	} else if routine.Frequency != "Quota" && routine.CompletionWindow != nil {
+		period = *routine.CompletionWindow
+	}
 
-	for i := 0; i < 365; i++ { // Max 365 days lookback
-		// Check if this date should have been a routine day
-		if !s.matchesFrequency(routine, checkDate) {
-			checkDate = checkDate.AddDate(0, 0, -1)
-			continue
+	day = routineDate(day)
+	switch period {
+	case entities.RoutinePeriodMonth:
+		first := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return first, first.AddDate(0, 1, -1)
+	case entities.RoutinePeriodQuarter:
+		first := time.Date(day.Year(), day.Month()-(day.Month()-1)%3, 1, 0, 0, 0, 0, time.UTC)
+		return first, first.AddDate(0, 3, -1)
+	default:
+		first := weekStart(day)
+		return first, first.AddDate(0, 0, 6)
+	}
+}
+
+// periodDue returns how often a period-based routine is due between two days of a period
+// (the quota, or the scheduled days of a completion window)
+func (s *routineService) periodDue(routine *entities.Routine, first, last time.Time) int {
+	if routine.Frequency == "Quota" {
+		if routine.QuotaCount == nil {
+			return 1
 		}
+		return *routine.QuotaCount
+	}
 
-		// Check if routine was completed or skipped on this date
-		completion, err := s.routineRepo.GetCompletionForDate(routine.ID, checkDate)
+	due := 0
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		if s.matchesFrequency(routine, day) {
+			due++
+		}
+	}
+	return due
+}
 
-		// No completion found - streak breaks (unless it's today)
-		if err != nil || completion == nil {
-			break
+// periodCounts counts the completions and skips between two days
+func periodCounts(first, last time.Time, statuses map[time.Time]string) (int, int) {
+	completed, skipped := 0, 0
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		switch statuses[day] {
+		case entities.RoutineCompleted:
+			completed++
+		case entities.RoutineSkipped:
+			skipped++
 		}
+	}
+	return completed, skipped
+}
 
-		// Completed - streak continues
-		if completion.Status == "completed" {
-			streak++
-			checkDate = checkDate.AddDate(0, 0, -1)
-			continue
+// periodOpen checks if a period-based routine is still due on a day (see periodOpenOn)
+func (s *routineService) periodOpen(routine *entities.Routine, day time.Time) (bool, error) {
+	first, _ := routinePeriod(routine, day)
+	completions, err := s.routineRepo.GetCompletionsInRange(routine.ID, first, routineDate(day))
+	if err != nil {
+		return false, err
+	}
+
+	statuses := completionStatuses([]*entities.Routine{routine}, completions)
+	return s.periodOpenOn(routine, routineDate(day), statuses[routine.ID]), nil
+}
+
+// periodOpenOn checks if a period-based routine is still due on a routine day: its period asks for more than
+// has been completed or skipped by then (later completions do not count for past days). A completion window
+// is open from its first day, even before the scheduled day.
+func (s *routineService) periodOpenOn(routine *entities.Routine, day time.Time, statuses map[time.Time]string) bool {
+	first, last := routinePeriod(routine, day)
+	completed, skipped := periodCounts(first, day, statuses)
+	return completed+skipped < s.periodDue(routine, first, last)
+}
+
+// routineDayStatus returns how a routine went on a day: completed, skipped, missed or "" (nothing due).
+// Days with a completion/skip count even when not scheduled, today is never missed (it can still be completed).
+// Period-based routines are missed on the last day of a period in which they were not done often enough.
+func (s *routineService) routineDayStatus(routine *entities.Routine, day, today time.Time, statuses map[time.Time]string) string {
+	if status, ok := statuses[day]; ok {
+		return status
+	}
+	if periodBased(routine) {
+		first, last := routinePeriod(routine, day)
+		if day.Equal(last) && last.Before(today) {
+			completed, skipped := periodCounts(first, last, statuses)
+			if completed+skipped < s.periodDue(routine, first, last) {
+				return routineMissed
+			}
 		}
+		return ""
+	}
+	if day.Before(today) && s.matchesFrequency(routine, day) {
+		return routineMissed
+	}
+	return ""
+}
 
-		// Skipped and routine is skippable - streak continues
-		if completion.Status == "skipped" && routine.IsSkippable {
-			checkDate = checkDate.AddDate(0, 0, -1)
-			continue
+// recalculateStreaks recomputes the current and longest streak of a routine from its whole history.
+// Every scheduled day and every day with a completion/skip counts: completions extend the streak,
+// skips keep it for skippable routines and break it otherwise, and a scheduled day without
+// completion breaks it (except today, which can still be completed). Period-based routines count periods instead.
+func (s *routineService) recalculateStreaks(routine *entities.Routine) error {
+	completions, err := s.routineRepo.GetAllCompletions(routine.ID)
+	if err != nil {
+		return err
+	}
+
+	current, longest := 0, 0
+	if len(completions) > 0 {
+		statuses := make(map[time.Time]string, len(completions))
+		for _, completion := range completions {
+			statuses[routineDate(completion.CompletedAt)] = completion.Status
 		}
 
-		// Skipped but routine is NOT skippable - streak breaks
-		if completion.Status == "skipped" && !routine.IsSkippable {
-			break
+		today := s.clock(routine.UserID).today()
+		if periodBased(routine) {
+			current, longest = s.periodStreaks(routine, routineDate(completions[0].CompletedAt), today, statuses)
+		} else {
+			for day := routineDate(completions[0].CompletedAt); !day.After(today); day = day.AddDate(0, 0, 1) {
+				switch s.routineDayStatus(routine, day, today, statuses) {
+				case entities.RoutineCompleted:
+					current++
+					if current > longest {
+						longest = current
+					}
+				case entities.RoutineSkipped:
+					if !routine.IsSkippable {
+						current = 0
+					}
+				case routineMissed:
+					current = 0
+				}
+			}
+		}
+	}
+
+	routine.CurrentStreak = current
+	routine.LongestStreak = longest
+	return s.routineRepo.UpdateStreak(routine.ID, current, longest)
+}
+
+// periodStreaks computes the streaks of a period-based routine in periods: a period in which it was done
+// as often as due extends the streak, a past period in which it was not breaks it. Skips count as done for
+// skippable routines without extending the streak, the current period only counts once it is done, and
+// periods without anything due (e.g. a window without scheduled day) do not count.
+func (s *routineService) periodStreaks(routine *entities.Routine, first, today time.Time, statuses map[time.Time]string) (int, int) {
+	current, longest := 0, 0
+	for start, _ := routinePeriod(routine, first); !start.After(today); {
+		_, end := routinePeriod(routine, start)
+		due := s.periodDue(routine, start, end)
+		completed, skipped := periodCounts(start, end, statuses)
+
+		switch {
+		case due == 0:
+			// Nothing due in this period
+		case completed >= due:
+			current++
+			if current > longest {
+				longest = current
+			}
+		case routine.IsSkippable && completed+skipped >= due:
+			// Skipped period keeps the streak
+		case end.Before(today):
+			current = 0
 		}
 
-		checkDate = checkDate.AddDate(0, 0, -1)
+		start = end.AddDate(0, 0, 1)
 	}
 
-	return streak
+	return current, longest
 }
@@ -0,0 +1,265 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type fakeRoutineRepo struct {
+	interfaces.RoutineRepository
+	routines    []*entities.Routine
+	completions []*entities.RoutineCompletion
+}
+
+func (r *fakeRoutineRepo) GetRoutineByID(id uuid.UUID) (*entities.Routine, error) {
+	for _, routine := range r.routines {
+		if routine.ID == id {
+			return routine, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeRoutineRepo) GetRoutinesByUserID(userID uuid.UUID, frequency *string) ([]*entities.Routine, error) {
+	return r.routines, nil
+}
+
+func (r *fakeRoutineRepo) GetCompletionForDate(routineID uuid.UUID, date time.Time) (*entities.RoutineCompletion, error) {
+	for _, completion := range r.completions {
+		if completion.RoutineID == routineID && completion.CompletedAt.Equal(routineDate(date)) {
+			return completion, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeRoutineRepo) GetCompletionsInRange(routineID uuid.UUID, from, to time.Time) ([]*entities.RoutineCompletion, error) {
+	var completions []*entities.RoutineCompletion
+	for _, completion := range r.completions {
+		if completion.RoutineID == routineID && !completion.CompletedAt.Before(from) && !completion.CompletedAt.After(to) {
+			completions = append(completions, completion)
+		}
+	}
+	return completions, nil
+}
+
+func (r *fakeRoutineRepo) GetCompletionsByUserIDInRange(userID uuid.UUID, from, to time.Time) ([]*entities.RoutineCompletion, error) {
+	var completions []*entities.RoutineCompletion
+	for _, completion := range r.completions {
+		if !completion.CompletedAt.Before(from) && !completion.CompletedAt.After(to) {
+			completions = append(completions, completion)
+		}
+	}
+	return completions, nil
+}
+
+func (r *fakeRoutineRepo) UpdateRoutine(routine *entities.Routine) error {
+	return nil
+}
+
+func (r *fakeRoutineRepo) GetAllCompletions(routineID uuid.UUID) ([]*entities.RoutineCompletion, error) {
+	return r.GetCompletionsInRange(routineID, time.Time{}, time.Date(9999, 1, 1, 0, 0, 0, 0, time.UTC))
+}
+
+func (r *fakeRoutineRepo) UpdateStreak(routineID uuid.UUID, current, longest int) error {
+	return nil
+}
+
+func TestUpdateRoutineFrequencyChange(t *testing.T) {
+	userID := uuid.New()
+	intPtr := func(v int) *int { return &v }
+	strPtr := func(v string) *string { return &v }
+
+	weekly := func() *entities.Routine {
+		return &entities.Routine{
+			ID:        uuid.New(),
+			UserID:    userID,
+			Title:     "Review",
+			Frequency: "Weekly",
+			Weekday:   intPtr(int(time.Saturday)),
+			RoutineSchedule: entities.RoutineSchedule{
+				Weekdays:         []int{1, 3},
+				CompletionWindow: strPtr(entities.RoutinePeriodWeek),
+			},
+			TimeType: "AM",
+		}
+	}
+	quota := func() *entities.Routine {
+		return &entities.Routine{
+			ID:              uuid.New(),
+			UserID:          userID,
+			Title:           "Gym",
+			Frequency:       "Quota",
+			RoutineSchedule: entities.RoutineSchedule{QuotaCount: intPtr(3), QuotaPeriod: strPtr(entities.RoutinePeriodWeek)},
+			TimeType:        "AM",
+		}
+	}
+
+	tests := []struct {
+		name       string
+		routine    *entities.Routine
+		frequency  string
+		schedule   entities.RoutineSchedule
+		dayOfMonth *int
+		check      func(*entities.Routine) bool
+	}{
+		{
+			name:      "weekly with window to daily",
+			routine:   weekly(),
+			frequency: "Daily",
+			check: func(r *entities.Routine) bool {
+				return r.Weekday == nil && r.Weekdays == nil && r.CompletionWindow == nil
+			},
+		},
+		{
+			name:      "weekly with window to quota",
+			routine:   weekly(),
+			frequency: "Quota",
+			schedule:  entities.RoutineSchedule{QuotaCount: intPtr(2), QuotaPeriod: strPtr(entities.RoutinePeriodMonth)},
+			check: func(r *entities.Routine) bool {
+				return r.Weekday == nil && r.Weekdays == nil && r.CompletionWindow == nil && *r.QuotaCount == 2
+			},
+		},
+		{
+			name:       "weekly to monthly keeps the window",
+			routine:    weekly(),
+			frequency:  "Monthly",
+			dayOfMonth: intPtr(10),
+			check: func(r *entities.Routine) bool {
+				return r.Weekdays == nil && *r.DayOfMonth == 10 && r.CompletionWindow != nil
+			},
+		},
+		{
+			name:      "quota to interval",
+			routine:   quota(),
+			frequency: "Interval",
+			schedule:  entities.RoutineSchedule{IntervalDays: intPtr(3)},
+			check: func(r *entities.Routine) bool {
+				return r.QuotaCount == nil && r.QuotaPeriod == nil && *r.IntervalDays == 3 && r.StartDate != nil
+			},
+		},
+		{
+			name:      "same frequency keeps the fields",
+			routine:   quota(),
+			frequency: "Quota",
+			check: func(r *entities.Routine) bool {
+				return *r.QuotaCount == 3 && *r.QuotaPeriod == entities.RoutinePeriodWeek
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &routineService{
+				routineRepo: &fakeRoutineRepo{routines: []*entities.Routine{tt.routine}},
+				userRepo:    &fakeUserRepo{user: &entities.User{ID: userID}},
+			}
+
+			updated, err := s.UpdateRoutine(tt.routine.ID, userID, nil, &tt.frequency, nil, tt.dayOfMonth, nil, nil, tt.schedule, nil, nil, nil, nil)
+			if err != nil {
+				t.Fatalf("UpdateRoutine: %v", err)
+			}
+			if !tt.check(updated) {
+				t.Errorf("unexpected schedule %+v", updated.RoutineSchedule)
+			}
+		})
+	}
+}
+
+func TestRoutineClockDay(t *testing.T) {
+	berlin := mustLoadLocation(t, "Europe/Berlin")
+
+	tests := []struct {
+		name     string
+		loc      *time.Location
+		rollover int
+		at       time.Time
+		want     string
+	}{
+		{"midnight rollover just after midnight", berlin, 0, time.Date(2026, 10, 17, 0, 30, 0, 0, berlin), "2026-10-17"},
+		{"midnight rollover late evening", berlin, 0, time.Date(2026, 10, 17, 23, 59, 0, 0, berlin), "2026-10-17"},
+		{"before the rollover hour", berlin, 4, time.Date(2026, 10, 17, 1, 30, 0, 0, berlin), "2026-10-16"},
+		{"just before the rollover hour", berlin, 4, time.Date(2026, 10, 17, 3, 59, 0, 0, berlin), "2026-10-16"},
+		{"at the rollover hour", berlin, 4, time.Date(2026, 10, 17, 4, 0, 0, 0, berlin), "2026-10-17"},
+		{"noon rollover in the morning", berlin, 12, time.Date(2026, 10, 17, 11, 59, 0, 0, berlin), "2026-10-16"},
+		{"noon rollover at noon", berlin, 12, time.Date(2026, 10, 17, 12, 0, 0, 0, berlin), "2026-10-17"},
+		{"UTC moment on the next local day", berlin, 0, time.Date(2026, 10, 16, 22, 30, 0, 0, time.UTC), "2026-10-17"},
+		{"UTC clock", time.UTC, 0, time.Date(2026, 10, 16, 22, 30, 0, 0, time.UTC), "2026-10-16"},
+		{"previous month", berlin, 4, time.Date(2026, 11, 1, 1, 0, 0, 0, berlin), "2026-10-31"},
+		{"previous year", berlin, 4, time.Date(2027, 1, 1, 3, 0, 0, 0, berlin), "2026-12-31"},
+
+		// Fall-back night: 02:00-03:00 happens twice, the day still ends at 04:00 wall-clock time
+		{"fall-back first 02:30", berlin, 4, time.Date(2026, 10, 25, 0, 30, 0, 0, time.UTC), "2026-10-24"},
+		{"fall-back repeated 02:30", berlin, 4, time.Date(2026, 10, 25, 1, 30, 0, 0, time.UTC), "2026-10-24"},
+		{"fall-back 03:59", berlin, 4, time.Date(2026, 10, 25, 2, 59, 0, 0, time.UTC), "2026-10-24"},
+		{"fall-back 04:00", berlin, 4, time.Date(2026, 10, 25, 3, 0, 0, 0, time.UTC), "2026-10-25"},
+
+		// Spring-forward night: 02:00-03:00 is skipped
+		{"spring-forward 01:59", berlin, 3, time.Date(2026, 3, 29, 0, 59, 0, 0, time.UTC), "2026-03-28"},
+		{"spring-forward 03:00", berlin, 3, time.Date(2026, 3, 29, 1, 0, 0, 0, time.UTC), "2026-03-29"},
+		{"spring-forward rollover in the gap", berlin, 2, time.Date(2026, 3, 29, 1, 0, 0, 0, time.UTC), "2026-03-29"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := routineClock{loc: tt.loc, rolloverHour: tt.rollover}
+			got := clock.day(tt.at)
+			if got.Format("2006-01-02") != tt.want {
+				t.Errorf("day(%s) = %s, want %s", tt.at, got.Format("2006-01-02"), tt.want)
+			}
+			if got.Location() != time.UTC || got.Hour() != 0 || got.Minute() != 0 {
+				t.Errorf("day(%s) = %s, want UTC midnight", tt.at, got)
+			}
+		})
+	}
+}
+
+func TestRoutineClockToday(t *testing.T) {
+	tokyo := mustLoadLocation(t, "Asia/Tokyo")
+
+	for _, rollover := range []int{0, 4, 12} {
+		clock := routineClock{loc: tokyo, rolloverHour: rollover}
+
+		before := clock.day(time.Now())
+		got := clock.today()
+		after := clock.day(time.Now())
+
+		if !got.Equal(before) && !got.Equal(after) {
+			t.Errorf("rollover %d: today() = %s, want %s", rollover, got, before)
+		}
+		if got.Location() != time.UTC || got.Hour() != 0 {
+			t.Errorf("rollover %d: today() = %s, want UTC midnight", rollover, got)
+		}
+	}
+}
+
+func TestRoutineServiceClock(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name         string
+		user         *entities.User
+		wantZone     string
+		wantRollover int
+	}{
+		{"user settings", &entities.User{ID: userID, Timezone: "Europe/Berlin", DayRolloverHour: 4}, "Europe/Berlin", 4},
+		{"no timezone", &entities.User{ID: userID, DayRolloverHour: 12}, "UTC", 12},
+		{"invalid timezone", &entities.User{ID: userID, Timezone: "Mars/Olympus_Mons"}, "UTC", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &routineService{userRepo: &fakeUserRepo{user: tt.user}}
+			clock := s.clock(userID)
+			if clock.loc.String() != tt.wantZone || clock.rolloverHour != tt.wantRollover {
+				t.Errorf("clock = %s/%d, want %s/%d", clock.loc, clock.rolloverHour, tt.wantZone, tt.wantRollover)
+			}
+		})
+	}
+}
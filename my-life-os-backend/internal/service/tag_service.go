@@ -0,0 +1,219 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// maxTagLength limits the length of tag names
+const maxTagLength = 50
+
+type tagService struct {
+	tagRepo interfaces.TagRepository
+}
+
+// NewTagService creates a new tag service
+func NewTagService(tagRepo interfaces.TagRepository) interfaces.TagService {
+	return &tagService{
+		tagRepo: tagRepo,
+	}
+}
+
+// GetUserTags retrieves the tags of a user starting with prefix, most used first
+func (s *tagService) GetUserTags(userID uuid.UUID, prefix string, limit int) ([]*interfaces.TagUsage, error) {
+	prefix = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(prefix), "#"))
+	return s.tagRepo.FindTagUsage(userID, prefix, limit)
+}
+
+// getTag retrieves a single tag (ensures user owns it)
+func (s *tagService) getTag(tagID, userID uuid.UUID) (*entities.Tag, error) {
+	tag, err := s.tagRepo.FindTagByID(tagID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify ownership
+	if tag.UserID != userID {
+		return nil, errors.New("unauthorized: tag does not belong to user")
+	}
+
+	return tag, nil
+}
+
+// RenameTag renames a tag (tasks reference tags by ID, so they all follow)
+func (s *tagService) RenameTag(tagID, userID uuid.UUID, name string) (*entities.Tag, error) {
+	// Get tag and verify ownership
+	tag, err := s.getTag(tagID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err = normalizeTagName(name)
+	if err != nil {
+		return nil, err
+	}
+	if name == tag.Name {
+		return tag, nil
+	}
+
+	// Renaming onto another tag is a merge
+	_, err = s.tagRepo.FindTagByName(userID, name)
+	if err == nil {
+		return nil, errors.New("a tag with this name already exists, merge the tags instead")
+	}
+	if err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+
+	tag.Name = name
+	tag.UpdatedAt = time.Now()
+
+	err = s.tagRepo.RenameTag(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+// MergeTag moves all tasks of the source tag to the target tag and deletes the source
+func (s *tagService) MergeTag(sourceID, targetID, userID uuid.UUID) (*entities.Tag, error) {
+	if sourceID == targetID {
+		return nil, errors.New("cannot merge a tag into itself")
+	}
+
+	// Verify ownership of both tags
+	source, err := s.getTag(sourceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.getTag(targetID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.tagRepo.MergeTags(source, target)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.tagRepo.FindTagByID(target.ID)
+}
+
+// DeleteTag removes a tag from all tasks and deletes it
+func (s *tagService) DeleteTag(tagID, userID uuid.UUID) error {
+	// Verify ownership first
+	_, err := s.getTag(tagID, userID)
+	if err != nil {
+		return err
+	}
+
+	return s.tagRepo.DeleteTag(tagID)
+}
+
+// normalizeTagName trims and lowercases a tag name ("#Errand" -> "errand").
+// Names cannot contain characters used by tag filters.
+func normalizeTagName(name string) (string, error) {
+	name = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(name), "#"))
+
+	if name == "" {
+		return "", errors.New("tag name is required")
+	}
+	if len(name) > maxTagLength {
+		return "", errors.New("tag name is too long")
+	}
+	if strings.HasPrefix(name, "-") || strings.ContainsAny(name, ", \t\n:") {
+		return "", errors.New("invalid tag name: " + name)
+	}
+
+	return name, nil
+}
+
+// resolveTags returns the tags of a user with the given names, creating missing ones
+func resolveTags(tagRepo interfaces.TagRepository, userID uuid.UUID, names []string) ([]*entities.Tag, error) {
+	seen := make(map[string]bool)
+	var normalized []string
+	var missing []*entities.Tag
+
+	for _, name := range names {
+		name, err := normalizeTagName(name)
+		if err != nil {
+			return nil, err
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		normalized = append(normalized, name)
+
+		missing = append(missing, &entities.Tag{
+			ID:        uuid.New(),
+			UserID:    userID,
+			Name:      name,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		})
+	}
+
+	// Existing names are skipped
+	if err := tagRepo.CreateTagsIfNotExist(missing); err != nil {
+		return nil, err
+	}
+
+	return tagRepo.FindTagsByNames(userID, normalized)
+}
+
+// parseTagQuery parses a tag filter such as "tag:errand -tag:waiting OR tag:urgent,today".
+// Terms are combined with AND, "OR" separates alternatives, "-" negates a term
+// and a comma-separated list matches any of its tags.
+func parseTagQuery(query string) (*interfaces.TagQuery, error) {
+	parsed := &interfaces.TagQuery{}
+	var group []interfaces.TagCondition
+
+	for _, term := range strings.Fields(query) {
+		if strings.EqualFold(term, "OR") {
+			if len(group) == 0 {
+				return nil, errors.New("invalid tag filter: OR needs a term on both sides")
+			}
+			parsed.Groups = append(parsed.Groups, group)
+			group = nil
+			continue
+		}
+
+		condition := interfaces.TagCondition{}
+		if strings.HasPrefix(term, "-") || strings.HasPrefix(term, "!") {
+			condition.Negate = true
+			term = term[1:]
+		}
+
+		if len(term) < 4 || !strings.EqualFold(term[:4], "tag:") {
+			return nil, errors.New("invalid tag filter: expected tag:<name>, got " + term)
+		}
+		for _, name := range strings.Split(term[4:], ",") {
+			name, err := normalizeTagName(name)
+			if err != nil {
+				return nil, errors.New("invalid tag filter: " + err.Error())
+			}
+			condition.Tags = append(condition.Tags, name)
+		}
+
+		group = append(group, condition)
+	}
+
+	if len(group) == 0 {
+		if len(parsed.Groups) > 0 {
+			return nil, errors.New("invalid tag filter: OR needs a term on both sides")
+		}
+		return nil, nil
+	}
+	parsed.Groups = append(parsed.Groups, group)
+
+	return parsed, nil
+}
@@ -1,58 +1,104 @@
 package service
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
 	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/rrule"
 
 	"github.com/google/uuid"
 )
 
+// maxTaskPageSize limits the page size of task lists
+const maxTaskPageSize = 500
+
+// defaultTaskSort lists the closest deadline first (no deadline last), then the newest tasks
+var defaultTaskSort = []interfaces.TaskSort{
+	{Key: interfaces.TaskSortDeadline},
+	{Key: interfaces.TaskSortCreatedAt, Desc: true},
+}
+
 type taskService struct {
-	taskRepo interfaces.TaskRepository
+	taskRepo   interfaces.TaskRepository
+	domainRepo interfaces.DomainRepository
+	userRepo   interfaces.UserRepository
+	tagRepo    interfaces.TagRepository
 }
 
 // NewTaskService creates a new task service
-func NewTaskService(taskRepo interfaces.TaskRepository) interfaces.TaskService {
+func NewTaskService(
+	taskRepo interfaces.TaskRepository,
+	domainRepo interfaces.DomainRepository,
+	userRepo interfaces.UserRepository,
+	tagRepo interfaces.TagRepository,
+) interfaces.TaskService {
 	return &taskService{
-		taskRepo: taskRepo,
+		taskRepo:   taskRepo,
+		domainRepo: domainRepo,
+		userRepo:   userRepo,
+		tagRepo:    tagRepo,
 	}
 }
 
-// CreateTask creates a new task
-func (s *taskService) CreateTask(userID uuid.UUID, title, description, priority, domain string, deadline *string) (*entities.Task, error) {
+// CreateTask creates a new task (the first instance of a new series if recurrence is set)
+func (s *taskService) CreateTask(userID uuid.UUID, title, description, priority, domain string, deadline *string, parentTaskID *uuid.UUID, recurrence *interfaces.TaskRecurrence, tags []string) (*entities.Task, error) {
 	// Validate required fields
 	if title == "" {
 		return nil, errors.New("title is required")
 	}
+	if recurrence != nil && parentTaskID != nil {
+		return nil, errors.New("subtasks cannot be recurring")
+	}
+
+	// Subtasks inherit the domain of their parent and are added after its other subtasks
+	var parent *entities.Task
+	position := 0
+	if parentTaskID != nil {
+		var err error
+		parent, err = s.GetTask(*parentTaskID, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		depth, err := s.taskDepth(parent)
+		if err != nil {
+			return nil, err
+		}
+		if depth >= entities.MaxSubtaskDepth {
+			return nil, errors.New("subtasks cannot be nested any deeper")
+		}
+
+		if domain == "" {
+			domain = parent.Domain
+		}
+
+		siblings, err := s.taskRepo.FindSubtasks(parent.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, sibling := range siblings {
+			if sibling.Position >= position {
+				position = sibling.Position + 1
+			}
+		}
+	}
 
 	// Validate priority
 	if priority != entities.PriorityLow && priority != entities.PriorityMedium && priority != entities.PriorityHigh {
 		priority = entities.PriorityMedium // Default to Medium
 	}
 
-	// Validate domain
-	validDomains := []string{
-		entities.DomainWork,
-		entities.DomainUniversity,
-		entities.DomainCodingProject,
-		entities.DomainPersonalProject,
-		entities.DomainGoals,
-		entities.DomainFinances,
-		entities.DomainHousehold,
-		entities.DomainHealth,
-	}
-	isDomainValid := false
-	for _, d := range validDomains {
-		if domain == d {
-			isDomainValid = true
-			break
-		}
-	}
-	if !isDomainValid {
-		return nil, errors.New("invalid domain")
+	// Validate domain (one of the user's domains)
+	domain, err := resolveDomain(s.domainRepo, userID, domain)
+	if err != nil {
+		return nil, err
 	}
 
 	// Parse deadline if provided
@@ -65,25 +111,65 @@ func (s *taskService) CreateTask(userID uuid.UUID, title, description, priority,
 		deadlineTime = &parsedTime
 	}
 
+	// Tags are stored together with the task
+	var resolved []*entities.Tag
+	if len(tags) > 0 {
+		resolved, err = resolveTags(s.tagRepo, userID, tags)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Recurring tasks are linked to their series
+	var series *entities.TaskSeries
+	if recurrence != nil {
+		series = &entities.TaskSeries{
+			ID:        uuid.New(),
+			UserID:    userID,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+		if err := s.setRecurrence(series, recurrence, deadlineTime); err != nil {
+			return nil, err
+		}
+	}
+
 	// Create task
 	task := &entities.Task{
-		ID:          uuid.New(),
-		UserID:      userID,
-		Title:       title,
-		Description: description,
-		Priority:    priority,
-		Status:      entities.StatusTodo,
-		Domain:      domain,
-		Deadline:    deadlineTime,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		ID:           uuid.New(),
+		UserID:       userID,
+		Title:        title,
+		Description:  description,
+		Priority:     priority,
+		Status:       entities.StatusTodo,
+		Domain:       domain,
+		Deadline:     deadlineTime,
+		ParentTaskID: parentTaskID,
+		Position:     position,
+		Tags:         resolved,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+	}
+
+	if series != nil {
+		if err := s.taskRepo.CreateTaskSeries(series); err != nil {
+			return nil, err
+		}
+		task.SeriesID = &series.ID
 	}
 
-	err := s.taskRepo.CreateTask(task)
+	err = s.taskRepo.CreateTask(task)
 	if err != nil {
 		return nil, err
 	}
 
+	// A closed task is open again once it gets a new subtask
+	if parent != nil {
+		if err := s.reopenParents(task); err != nil {
+			return nil, err
+		}
+	}
+
 	return task, nil
 }
 
@@ -102,103 +188,242 @@ func (s *taskService) GetTask(taskID, userID uuid.UUID) (*entities.Task, error)
 	return task, nil
 }
 
+// GetTaskWithSubtasks retrieves a task with its nested subtasks (ensures user owns it)
+func (s *taskService) GetTaskWithSubtasks(taskID, userID uuid.UUID) (*entities.Task, error) {
+	task, err := s.taskRepo.FindTaskWithSubtasks(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify ownership
+	if task.UserID != userID {
+		return nil, errors.New("unauthorized: task does not belong to user")
+	}
+
+	return task, nil
+}
+
+// ReorderSubtasks sets the order of the subtasks of a task (every subtask must be listed once)
+func (s *taskService) ReorderSubtasks(taskID, userID uuid.UUID, subtaskIDs []uuid.UUID) ([]*entities.Task, error) {
+	// Verify ownership first
+	_, err := s.GetTask(taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	subtasks, err := s.taskRepo.FindSubtasks(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The new order must contain exactly the current subtasks
+	remaining := make(map[uuid.UUID]bool, len(subtasks))
+	for _, subtask := range subtasks {
+		remaining[subtask.ID] = true
+	}
+	for _, id := range subtaskIDs {
+		if !remaining[id] {
+			return nil, errors.New("subtask order does not match the subtasks of the task")
+		}
+		delete(remaining, id)
+	}
+	if len(remaining) > 0 {
+		return nil, errors.New("subtask order does not match the subtasks of the task")
+	}
+
+	err = s.taskRepo.UpdatePositions(subtaskIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.taskRepo.FindSubtasks(taskID)
+}
+
+// SetTaskTags replaces the tags of a task (unknown tags are created)
+func (s *taskService) SetTaskTags(taskID, userID uuid.UUID, tags []string) (*entities.Task, error) {
+	// Get task and verify ownership
+	task, err := s.GetTask(taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved, err := resolveTags(s.tagRepo, userID, tags)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.tagRepo.SetTaskTags(task.ID, tagIDs(resolved))
+	if err != nil {
+		return nil, err
+	}
+
+	task.Tags = resolved
+	return task, nil
+}
+
 // GetUserTasks retrieves all tasks for a user
 func (s *taskService) GetUserTasks(userID uuid.UUID) ([]*entities.Task, error) {
 	return s.taskRepo.FindTasksByUserID(userID)
 }
 
-// GetUserTasksWithFilters retrieves tasks with filters
-func (s *taskService) GetUserTasksWithFilters(userID uuid.UUID, domain, status, timeFilter string) ([]*entities.Task, error) {
-	// Get all user tasks first
-	allTasks, err := s.taskRepo.FindTasksByUserID(userID)
+// GetUserTasksWithFilters retrieves a sorted page of the tasks of a user matching the filters
+func (s *taskService) GetUserTasksWithFilters(userID uuid.UUID, query interfaces.TaskQuery) (*interfaces.TaskPage, error) {
+	filter, err := taskFilter(query, time.Now())
 	if err != nil {
 		return nil, err
 	}
 
-	// Apply filters
-	var filteredTasks []*entities.Task
+	sort, err := parseTaskSort(query.Sort)
+	if err != nil {
+		return nil, err
+	}
 
-	for _, task := range allTasks {
-		// Domain filter
-		if domain != "" && task.Domain != domain {
-			continue
-		}
+	cursor, err := decodeTaskCursor(query.Cursor)
+	if err != nil {
+		return nil, err
+	}
 
-		// Status filter
-		if status != "" && task.Status != status {
-			continue
-		}
+	if query.Limit < 0 || query.Limit > maxTaskPageSize {
+		return nil, errors.New("invalid limit")
+	}
 
-		// Time filter (Long Term, Morgen, Next Week, Next Month)
-		if timeFilter != "" {
-			if !s.matchesTimeFilter(task, timeFilter) {
-				continue
-			}
-		}
+	// One extra task tells whether there is a next page
+	fetch := 0
+	if query.Limit > 0 {
+		fetch = query.Limit + 1
+	}
+	tasks, err := s.taskRepo.FindTasksByUserIDAndFilters(userID, filter, sort, cursor, fetch)
+	if err != nil {
+		return nil, err
+	}
 
-		filteredTasks = append(filteredTasks, task)
+	page := &interfaces.TaskPage{Tasks: tasks}
+	if query.Limit > 0 && len(tasks) > query.Limit {
+		page.Tasks = tasks[:query.Limit]
+		page.NextCursor = encodeTaskCursor(page.Tasks[query.Limit-1])
 	}
 
-	return filteredTasks, nil
+	page.Counts, err = s.taskRepo.CountTasksByUserIDAndFilters(userID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return page, nil
 }
 
-// matchesTimeFilter checks if task matches time filter
-func (s *taskService) matchesTimeFilter(task *entities.Task, timeFilter string) bool {
-	now := time.Now()
+// taskFilter converts the filters of a task query into repository conditions.
+// Time filters are relative to now (unknown ones are ignored).
+func taskFilter(query interfaces.TaskQuery, now time.Time) (interfaces.TaskFilter, error) {
+	filter := interfaces.TaskFilter{
+		Domain: query.Domain,
+		Status: query.Status,
+	}
+	if query.Status != "" && !entities.IsValidTaskStatus(query.Status) {
+		return filter, errors.New("invalid status: " + query.Status)
+	}
+
+	tags, err := parseTagQuery(query.TagFilter)
+	if err != nil {
+		return filter, err
+	}
+	filter.Tags = tags
+
+	switch query.Dependency {
+	case "":
+	case interfaces.TaskDependencyBlocked, interfaces.TaskDependencyActionable:
+		blocked := query.Dependency == interfaces.TaskDependencyBlocked
+		filter.Blocked = &blocked
+	default:
+		return filter, errors.New("invalid dependency filter: " + query.Dependency)
+	}
+
 	todayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	tomorrowStart := todayStart.AddDate(0, 0, 1)
+	between := func(from, before time.Time) {
+		filter.DeadlineFrom = &from
+		filter.DeadlineBefore = &before
+	}
 
-	switch timeFilter {
+	switch query.TimeFilter {
 	case "long_term":
 		// Tasks with no deadline
-		return task.Deadline == nil
+		filter.NoDeadline = true
 
 	case "overdue":
-		// Tasks with deadline in the past and not completed
-		if task.Deadline == nil {
-			return false
-		}
-		if task.Status == entities.StatusDone {
-			return false
-		}
-		return task.Deadline.Before(todayStart)
+		// Tasks with deadline in the past and not done or cancelled
+		filter.DeadlineBefore = &todayStart
+		filter.ExcludeDone = true
 
 	case "today":
-		// Tasks due today (only those with deadline set to today)
-		if task.Deadline == nil {
-			return false
-		}
-		tomorrowStart := todayStart.Add(24 * time.Hour)
-		return !task.Deadline.Before(todayStart) && task.Deadline.Before(tomorrowStart)
+		// Tasks due today
+		between(todayStart, tomorrowStart)
 
 	case "tomorrow":
 		// Tasks due tomorrow
-		if task.Deadline == nil {
-			return false
-		}
-		tomorrow := now.AddDate(0, 0, 1)
-		tomorrowStart := time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 0, 0, 0, 0, tomorrow.Location())
-		tomorrowEnd := tomorrowStart.Add(24 * time.Hour)
-		return !task.Deadline.Before(tomorrowStart) && task.Deadline.Before(tomorrowEnd)
+		between(tomorrowStart, tomorrowStart.AddDate(0, 0, 1))
 
 	case "next_week":
-		// Tasks due in next 7 days (rolling window from today)
-		if task.Deadline == nil {
-			return false
-		}
-		nextWeekEnd := now.AddDate(0, 0, 7)
-		return task.Deadline.After(now) && task.Deadline.Before(nextWeekEnd)
+		// Tasks due in next 7 days (rolling window from now)
+		between(now, now.AddDate(0, 0, 7))
 
 	case "next_month":
-		// Tasks due in next 30 days (rolling window from today)
-		if task.Deadline == nil {
-			return false
+		// Tasks due in next 30 days (rolling window from now)
+		between(now, now.AddDate(0, 0, 30))
+	}
+
+	return filter, nil
+}
+
+// parseTaskSort parses sort keys such as "deadline,-priority" ("-" = descending)
+func parseTaskSort(value string) ([]interfaces.TaskSort, error) {
+	if strings.TrimSpace(value) == "" {
+		return defaultTaskSort, nil
+	}
+
+	var sort []interfaces.TaskSort
+	for _, key := range strings.Split(value, ",") {
+		key = strings.TrimSpace(key)
+		desc := strings.HasPrefix(key, "-")
+		key = strings.TrimPrefix(key, "-")
+
+		switch key {
+		case interfaces.TaskSortDeadline, interfaces.TaskSortPriority, interfaces.TaskSortCreatedAt:
+			sort = append(sort, interfaces.TaskSort{Key: key, Desc: desc})
+		default:
+			return nil, errors.New("invalid sort key: " + key)
 		}
-		nextMonthEnd := now.AddDate(0, 0, 30)
-		return task.Deadline.After(now) && task.Deadline.Before(nextMonthEnd)
+	}
+	return sort, nil
+}
 
-	default:
-		return true
+// encodeTaskCursor returns the opaque cursor of the page ending with task
+func encodeTaskCursor(task *entities.Task) string {
+	data, _ := json.Marshal(interfaces.TaskCursor{
+		Deadline:  task.Deadline,
+		Priority:  task.Priority,
+		CreatedAt: task.CreatedAt,
+		ID:        task.ID,
+	})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeTaskCursor parses a cursor returned by encodeTaskCursor (nil for the first page)
+func decodeTaskCursor(value string) (*interfaces.TaskCursor, error) {
+	if value == "" {
+		return nil, nil
 	}
+
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+
+	var cursor interfaces.TaskCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == uuid.Nil {
+		return nil, errors.New("invalid cursor")
+	}
+
+	return &cursor, nil
 }
 
 // UpdateTask updates a task
@@ -221,27 +446,13 @@ func (s *taskService) UpdateTask(taskID, userID uuid.UUID, title, description, p
 		}
 	}
 
-	if domain != "" {
-		validDomains := []string{
-			entities.DomainWork,
-			entities.DomainUniversity,
-			entities.DomainCodingProject,
-			entities.DomainPersonalProject,
-			entities.DomainGoals,
-			entities.DomainFinances,
-			entities.DomainHousehold,
-			entities.DomainHealth,
-		}
-		isDomainValid := false
-		for _, d := range validDomains {
-			if domain == d {
-				isDomainValid = true
-				break
-			}
-		}
-		if isDomainValid {
-			task.Domain = domain
+	// Tasks keep an archived domain until it is changed
+	if domain != "" && domain != task.Domain {
+		resolved, err := resolveDomain(s.domainRepo, userID, domain)
+		if err != nil {
+			return nil, err
 		}
+		task.Domain = resolved
 	}
 
 	// Update deadline
@@ -268,32 +479,158 @@ func (s *taskService) UpdateTask(taskID, userID uuid.UUID, title, description, p
 	return task, nil
 }
 
-// ToggleTaskStatus toggles task status between Todo and Done
-func (s *taskService) ToggleTaskStatus(taskID, userID uuid.UUID) (*entities.Task, error) {
+// ToggleTaskStatus toggles task status between open and Done (a done or cancelled task goes back to Todo).
+// A task with open subtasks can only be completed with force (which completes the subtasks too),
+// reopening a subtask reopens its completed parents.
+// A task with open blockers is completed anyway, the result warns about them.
+// Completing an instance of a recurring task creates the next instance (in the result, nil if none).
+func (s *taskService) ToggleTaskStatus(taskID, userID uuid.UUID, force bool) (*interfaces.TaskStatusResult, error) {
 	// Get task and verify ownership
-	task, err := s.GetTask(taskID, userID)
+	task, err := s.GetTaskWithSubtasks(taskID, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Toggle status
-	if task.Status == entities.StatusTodo {
-		task.Status = entities.StatusDone
-	} else {
-		task.Status = entities.StatusTodo
+	status := entities.StatusTodo
+	if task.IsOpen() {
+		status = entities.StatusDone
 	}
 
-	task.UpdatedAt = time.Now()
+	return s.changeTaskStatus(task, status, force)
+}
 
-	err = s.taskRepo.UpdateTask(task)
+// SetTaskStatus moves a task to another status of the workflow
+func (s *taskService) SetTaskStatus(taskID, userID uuid.UUID, status string, force bool) (*interfaces.TaskStatusResult, error) {
+	if !entities.IsValidTaskStatus(status) {
+		return nil, errors.New("invalid status: " + status)
+	}
+
+	// Get task and verify ownership
+	task, err := s.GetTaskWithSubtasks(taskID, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	return task, nil
+	// Nothing to record
+	if task.Status == status {
+		return &interfaces.TaskStatusResult{Task: task, Warnings: []string{}}, nil
+	}
+
+	return s.changeTaskStatus(task, status, force)
 }
 
-// DeleteTask deletes a task
+// changeTaskStatus moves a task (loaded with its subtasks) to a new status and records the transitions.
+// Closing a task closes its open subtasks (with force) and creates the next instance of a recurring task,
+// reopening a task reopens its closed parents.
+func (s *taskService) changeTaskStatus(task *entities.Task, status string, force bool) (*interfaces.TaskStatusResult, error) {
+	if !entities.CanTransitionTaskStatus(task.Status, status) {
+		return nil, errors.New("invalid status transition from " + task.Status + " to " + status)
+	}
+
+	now := time.Now()
+	wasOpen := task.IsOpen()
+	closing := wasOpen && entities.IsTerminalTaskStatus(status)
+	tasks := []*entities.Task{task}
+	var changes []*entities.TaskStatusChange
+	result := &interfaces.TaskStatusResult{Task: task, Warnings: []string{}}
+
+	if closing {
+		open := openSubtasks(task)
+		if !force && len(open) > 0 {
+			return nil, errors.New("task has open subtasks")
+		}
+
+		// Blockers that are not done yet do not stop the completion, but are reported
+		if status == entities.StatusDone {
+			blockers, err := s.openBlockers(task.ID)
+			if err != nil {
+				return nil, err
+			}
+			if len(blockers) > 0 {
+				result.Blockers = blockers
+				result.Warnings = append(result.Warnings, blockerWarning(blockers))
+			}
+		}
+
+		// Open subtasks are closed together with their parent
+		for _, subtask := range open {
+			changes = append(changes, entities.NewTaskStatusChange(subtask, status, now))
+			tasks = append(tasks, subtask)
+		}
+	}
+
+	changes = append(changes, entities.NewTaskStatusChange(task, status, now))
+
+	// Reopening a subtask reopens its closed parents
+	if !wasOpen && task.IsOpen() {
+		parents, err := s.closedParents(task)
+		if err != nil {
+			return nil, err
+		}
+		for _, parent := range parents {
+			changes = append(changes, entities.NewTaskStatusChange(parent, entities.StatusTodo, now))
+			tasks = append(tasks, parent)
+		}
+	}
+
+	if err := s.taskRepo.UpdateTaskStatuses(tasks, changes); err != nil {
+		return nil, err
+	}
+
+	// Closed instances of a recurring task are followed by the next one
+	if closing && task.SeriesID != nil {
+		next, err := s.createNextInstance(task)
+		if err != nil {
+			return nil, err
+		}
+		result.NextTask = next
+	}
+
+	return result, nil
+}
+
+// blockerWarning describes the open blockers of a completed task
+func blockerWarning(blockers []*entities.Task) string {
+	titles := make([]string, len(blockers))
+	for i, blocker := range blockers {
+		titles[i] = strconv.Quote(blocker.Title)
+	}
+	if len(blockers) == 1 {
+		return "completed while blocked by open task " + titles[0]
+	}
+	return fmt.Sprintf("completed while blocked by %d open tasks: %s", len(blockers), strings.Join(titles, ", "))
+}
+
+// GetTaskHistory retrieves the status history of a task (ensures user owns it)
+func (s *taskService) GetTaskHistory(taskID, userID uuid.UUID) (*interfaces.TaskHistory, error) {
+	task, err := s.GetTask(taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := s.taskRepo.FindTaskStatusChanges(task.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	history := &interfaces.TaskHistory{
+		Changes:     changes,
+		StartedAt:   task.StartedAt,
+		CompletedAt: task.CompletedAt,
+	}
+	if lead := task.LeadTime(); lead != nil {
+		seconds := int64(lead.Seconds())
+		history.LeadTimeSeconds = &seconds
+	}
+	if cycle := task.CycleTime(); cycle != nil {
+		seconds := int64(cycle.Seconds())
+		history.CycleTimeSeconds = &seconds
+	}
+
+	return history, nil
+}
+
+// DeleteTask moves a task and its subtasks to the trash
 func (s *taskService) DeleteTask(taskID, userID uuid.UUID) error {
 	// Verify ownership first
 	_, err := s.GetTask(taskID, userID)
@@ -303,3 +640,464 @@ func (s *taskService) DeleteTask(taskID, userID uuid.UUID) error {
 
 	return s.taskRepo.DeleteTask(taskID)
 }
+
+// GetTaskSeries retrieves a recurring task series with all of its instances (ensures user owns it)
+func (s *taskService) GetTaskSeries(seriesID, userID uuid.UUID) (*entities.TaskSeries, error) {
+	series, err := s.taskRepo.FindTaskSeriesWithTasks(seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify ownership
+	if series.UserID != userID {
+		return nil, errors.New("unauthorized: task series does not belong to user")
+	}
+
+	return series, nil
+}
+
+// UpdateTaskSeries updates the open instances of a series, future instances are copied from them.
+// A changed recurrence starts from the deadline of the next open instance.
+func (s *taskService) UpdateTaskSeries(seriesID, userID uuid.UUID, title string, description *string, priority, domain string, recurrence *interfaces.TaskRecurrence) (*entities.TaskSeries, error) {
+	// Verify ownership first
+	series, err := s.GetTaskSeries(seriesID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	open, err := s.taskRepo.FindOpenSeriesTasks(seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	if recurrence != nil {
+		start := &series.StartDate
+		if len(open) > 0 && open[0].Deadline != nil {
+			start = open[0].Deadline
+		}
+		if err := s.setRecurrence(series, recurrence, start); err != nil {
+			return nil, err
+		}
+		series.UpdatedAt = time.Now()
+
+		if err := s.taskRepo.UpdateTaskSeries(series); err != nil {
+			return nil, err
+		}
+	}
+
+	// Tasks keep an archived domain until it is changed
+	resolvedDomain := ""
+	if domain != "" {
+		resolvedDomain, err = resolveDomain(s.domainRepo, userID, domain)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, task := range open {
+		if title != "" {
+			task.Title = title
+		}
+		if description != nil {
+			task.Description = *description
+		}
+
+		if priority == entities.PriorityLow || priority == entities.PriorityMedium || priority == entities.PriorityHigh {
+			task.Priority = priority
+		}
+		if resolvedDomain != "" {
+			task.Domain = resolvedDomain
+		}
+		task.UpdatedAt = time.Now()
+
+		if err := s.taskRepo.UpdateTask(task); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.taskRepo.FindTaskSeriesWithTasks(seriesID)
+}
+
+// StopTaskSeries stops creating new instances of a series (deleteOpen also removes its open instances)
+func (s *taskService) StopTaskSeries(seriesID, userID uuid.UUID, deleteOpen bool) (*entities.TaskSeries, error) {
+	// Verify ownership first
+	series, err := s.GetTaskSeries(seriesID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if series.StoppedAt == nil {
+		now := time.Now()
+		series.StoppedAt = &now
+		series.UpdatedAt = now
+
+		if err := s.taskRepo.UpdateTaskSeries(series); err != nil {
+			return nil, err
+		}
+	}
+
+	if deleteOpen {
+		open, err := s.taskRepo.FindOpenSeriesTasks(seriesID)
+		if err != nil {
+			return nil, err
+		}
+		for _, task := range open {
+			if err := s.taskRepo.DeleteTask(task.ID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return s.taskRepo.FindTaskSeriesWithTasks(seriesID)
+}
+
+// AddTaskDependency marks a task as blocked by another task (ensures user owns both, rejects cycles)
+func (s *taskService) AddTaskDependency(taskID, blockedByID, userID uuid.UUID) (*entities.TaskDependency, error) {
+	if taskID == blockedByID {
+		return nil, errors.New("a task cannot depend on itself")
+	}
+
+	// Verify ownership of both tasks
+	if _, err := s.GetTask(taskID, userID); err != nil {
+		return nil, err
+	}
+	if _, err := s.GetTask(blockedByID, userID); err != nil {
+		return nil, err
+	}
+
+	dependency := &entities.TaskDependency{
+		TaskID:      taskID,
+		BlockedByID: blockedByID,
+		CreatedAt:   time.Now(),
+	}
+
+	// The new dependency closes a cycle if the blocker already depends on the task
+	// (checked in the same transaction that stores it)
+	err := s.taskRepo.CreateTaskDependency(userID, dependency, func(dependencies []*entities.TaskDependency) error {
+		if dependsOn(dependencies, blockedByID, taskID) {
+			return errors.New("dependency would create a cycle")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dependency, nil
+}
+
+// RemoveTaskDependency removes a dependency between two tasks (ensures user owns the blocked task)
+func (s *taskService) RemoveTaskDependency(taskID, blockedByID, userID uuid.UUID) error {
+	// Verify ownership
+	if _, err := s.GetTask(taskID, userID); err != nil {
+		return err
+	}
+
+	return s.taskRepo.DeleteTaskDependency(taskID, blockedByID)
+}
+
+// GetTaskDependencies retrieves the tasks a task is blocked by and the tasks it blocks (ensures user owns it)
+func (s *taskService) GetTaskDependencies(taskID, userID uuid.UUID) (*interfaces.TaskDependencies, error) {
+	// Verify ownership
+	if _, err := s.GetTask(taskID, userID); err != nil {
+		return nil, err
+	}
+
+	blockedBy, err := s.taskRepo.FindBlockers(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	blocking, err := s.taskRepo.FindBlockedTasks(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &interfaces.TaskDependencies{BlockedBy: blockedBy, Blocking: blocking}, nil
+}
+
+// setRecurrence validates a recurrence and stores it on a series (start is the deadline of its first instance)
+func (s *taskService) setRecurrence(series *entities.TaskSeries, recurrence *interfaces.TaskRecurrence, start *time.Time) error {
+	if start == nil {
+		return errors.New("recurring tasks need a deadline")
+	}
+
+	timezone, err := resolveUserTimezone(s.userRepo, series.UserID, recurrence.Timezone)
+	if err != nil {
+		return err
+	}
+
+	switch recurrence.Type {
+	case entities.RecurrenceSchedule:
+		rule, err := rrule.Parse(recurrence.RecurrenceRule)
+		if err != nil {
+			return errors.New("invalid recurrence rule: " + err.Error())
+		}
+		value := rule.String()
+		series.RecurrenceRule = &value
+		series.IntervalDays = 0
+
+	case entities.RecurrenceAfterCompletion:
+		if recurrence.IntervalDays < 1 {
+			return errors.New("interval days must be at least 1")
+		}
+		series.RecurrenceRule = nil
+		series.IntervalDays = recurrence.IntervalDays
+
+	default:
+		return errors.New("invalid recurrence type")
+	}
+
+	series.RecurrenceType = recurrence.Type
+	series.StartDate = *start
+	series.Timezone = timezone
+
+	return nil
+}
+
+// createNextInstance creates the instance following a completed one, unless the series
+// was stopped or still has an open instance (e.g. when a task is reopened and completed again)
+func (s *taskService) createNextInstance(completed *entities.Task) (*entities.Task, error) {
+	series, err := s.taskRepo.FindTaskSeriesByID(*completed.SeriesID)
+	if err != nil {
+		return nil, err
+	}
+	if series.StoppedAt != nil {
+		return nil, nil
+	}
+
+	open, err := s.taskRepo.FindOpenSeriesTasks(series.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(open) > 0 {
+		return nil, nil
+	}
+
+	deadline, err := nextDeadline(series, completed, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	// The schedule has no further occurrences
+	if deadline == nil {
+		now := time.Now()
+		series.StoppedAt = &now
+		series.UpdatedAt = now
+		return nil, s.taskRepo.UpdateTaskSeries(series)
+	}
+
+	next := &entities.Task{
+		ID:          uuid.New(),
+		UserID:      completed.UserID,
+		Title:       completed.Title,
+		Description: completed.Description,
+		Priority:    completed.Priority,
+		Status:      entities.StatusTodo,
+		Domain:      completed.Domain,
+		Deadline:    deadline,
+		SeriesID:    &series.ID,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	err = s.taskRepo.CreateTask(next)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tags are linked after the task exists
+	if len(completed.Tags) > 0 {
+		if err := s.tagRepo.SetTaskTags(next.ID, tagIDs(completed.Tags)); err != nil {
+			return nil, err
+		}
+		next.Tags = completed.Tags
+	}
+
+	// Checklists are repeated with every instance
+	if err := s.copySubtasks(completed.Subtasks, next); err != nil {
+		return nil, err
+	}
+
+	return next, nil
+}
+
+// copySubtasks creates open copies of a subtask tree below parent
+func (s *taskService) copySubtasks(subtasks []*entities.Task, parent *entities.Task) error {
+	for _, subtask := range subtasks {
+		copied := &entities.Task{
+			ID:           uuid.New(),
+			UserID:       parent.UserID,
+			Title:        subtask.Title,
+			Description:  subtask.Description,
+			Priority:     subtask.Priority,
+			Status:       entities.StatusTodo,
+			Domain:       parent.Domain,
+			ParentTaskID: &parent.ID,
+			Position:     subtask.Position,
+			CreatedAt:    time.Now(),
+			UpdatedAt:    time.Now(),
+		}
+		if err := s.taskRepo.CreateTask(copied); err != nil {
+			return err
+		}
+		parent.Subtasks = append(parent.Subtasks, copied)
+
+		if err := s.copySubtasks(subtask.Subtasks, copied); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nextDeadline returns the deadline of the instance following a completed one (nil when a schedule has ended).
+// Fixed schedules continue after the previous deadline (so missed instances are caught up one by one),
+// "after completion" series count the days from the completion date and keep the time of the previous deadline.
+func nextDeadline(series *entities.TaskSeries, completed *entities.Task, completedAt time.Time) (*time.Time, error) {
+	loc, err := time.LoadLocation(series.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	previous := series.StartDate
+	if completed.Deadline != nil {
+		previous = *completed.Deadline
+	}
+	previous = previous.In(loc)
+
+	switch series.RecurrenceType {
+	case entities.RecurrenceSchedule:
+		if series.RecurrenceRule == nil {
+			return nil, errors.New("task series has no recurrence rule")
+		}
+		rule, err := rrule.Parse(*series.RecurrenceRule)
+		if err != nil {
+			return nil, err
+		}
+
+		// Without a deadline the schedule continues after the completion
+		after := previous
+		if completed.Deadline == nil {
+			after = completedAt
+		}
+		return rule.After(series.StartDate.In(loc), after), nil
+
+	case entities.RecurrenceAfterCompletion:
+		done := completedAt.In(loc)
+		next := time.Date(done.Year(), done.Month(), done.Day()+series.IntervalDays,
+			previous.Hour(), previous.Minute(), previous.Second(), 0, loc)
+		return &next, nil
+
+	default:
+		return nil, errors.New("invalid recurrence type")
+	}
+}
+
+// taskDepth returns how many parents a task has (0 = top-level task)
+func (s *taskService) taskDepth(task *entities.Task) (int, error) {
+	depth := 0
+	for task.ParentTaskID != nil {
+		parent, err := s.taskRepo.FindTaskByID(*task.ParentTaskID)
+		if err != nil {
+			return 0, err
+		}
+		task = parent
+		depth++
+	}
+	return depth, nil
+}
+
+// reopenParents sets the closed parents of an open task back to Todo
+func (s *taskService) reopenParents(task *entities.Task) error {
+	parents, err := s.closedParents(task)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	changes := make([]*entities.TaskStatusChange, len(parents))
+	for i, parent := range parents {
+		changes[i] = entities.NewTaskStatusChange(parent, entities.StatusTodo, now)
+	}
+	return s.taskRepo.UpdateTaskStatuses(parents, changes)
+}
+
+// closedParents returns the parents of a task that are done or cancelled
+func (s *taskService) closedParents(task *entities.Task) ([]*entities.Task, error) {
+	var closed []*entities.Task
+	for task.ParentTaskID != nil {
+		parent, err := s.taskRepo.FindTaskByID(*task.ParentTaskID)
+		if err != nil {
+			return nil, err
+		}
+		if !parent.IsOpen() {
+			closed = append(closed, parent)
+		}
+		task = parent
+	}
+
+	return closed, nil
+}
+
+// tagIDs returns the IDs of tags
+func tagIDs(tags []*entities.Tag) []uuid.UUID {
+	ids := make([]uuid.UUID, len(tags))
+	for i, tag := range tags {
+		ids[i] = tag.ID
+	}
+	return ids
+}
+
+// openSubtasks returns all loaded subtasks (at any depth) that are not done or cancelled yet
+func openSubtasks(task *entities.Task) []*entities.Task {
+	var open []*entities.Task
+	for _, subtask := range task.Subtasks {
+		if subtask.IsOpen() {
+			open = append(open, subtask)
+		}
+		open = append(open, openSubtasks(subtask)...)
+	}
+	return open
+}
+
+// openBlockers returns the tasks blocking a task that are not done or cancelled yet
+func (s *taskService) openBlockers(taskID uuid.UUID) ([]*entities.Task, error) {
+	blockers, err := s.taskRepo.FindBlockers(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	var open []*entities.Task
+	for _, blocker := range blockers {
+		if blocker.IsOpen() {
+			open = append(open, blocker)
+		}
+	}
+	return open, nil
+}
+
+// dependsOn checks if a task is (transitively) blocked by another task
+func dependsOn(dependencies []*entities.TaskDependency, taskID, blockedByID uuid.UUID) bool {
+	blockers := make(map[uuid.UUID][]uuid.UUID)
+	for _, dependency := range dependencies {
+		blockers[dependency.TaskID] = append(blockers[dependency.TaskID], dependency.BlockedByID)
+	}
+
+	visited := map[uuid.UUID]bool{taskID: true}
+	stack := []uuid.UUID{taskID}
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		for _, blocker := range blockers[current] {
+			if blocker == blockedByID {
+				return true
+			}
+			if !visited[blocker] {
+				visited[blocker] = true
+				stack = append(stack, blocker)
+			}
+		}
+	}
+	return false
+}
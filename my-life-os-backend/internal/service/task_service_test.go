@@ -0,0 +1,137 @@
+package service
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+func (r *fakeTaskRepo) FindTaskSeriesWithTasks(seriesID uuid.UUID) (*entities.TaskSeries, error) {
+	return r.series, nil
+}
+
+func (r *fakeTaskRepo) FindOpenSeriesTasks(seriesID uuid.UUID) ([]*entities.Task, error) {
+	var open []*entities.Task
+	for _, task := range r.tasks {
+		if task.SeriesID != nil && *task.SeriesID == seriesID && task.IsOpen() {
+			open = append(open, task)
+		}
+	}
+	return open, nil
+}
+
+func (r *fakeTaskRepo) UpdateTask(task *entities.Task) error {
+	return nil
+}
+
+func (r *fakeTaskRepo) FindTaskWithSubtasks(taskID uuid.UUID) (*entities.Task, error) {
+	for _, task := range r.tasks {
+		if task.ID == taskID {
+			return task, nil
+		}
+	}
+	return nil, errors.New("task not found")
+}
+
+func (r *fakeTaskRepo) FindBlockers(taskID uuid.UUID) ([]*entities.Task, error) {
+	return r.blockers, nil
+}
+
+func (r *fakeTaskRepo) UpdateTaskStatuses(tasks []*entities.Task, changes []*entities.TaskStatusChange) error {
+	return nil
+}
+
+func TestUpdateTaskSeriesDescription(t *testing.T) {
+	userID := uuid.New()
+	seriesID := uuid.New()
+	strPtr := func(v string) *string { return &v }
+
+	tests := []struct {
+		name        string
+		title       string
+		description *string
+		want        []string
+	}{
+		{"title only keeps each description", "Water the plants", nil, []string{"Balcony", "Kitchen"}},
+		{"description is set on every instance", "", strPtr("All plants"), []string{"All plants", "All plants"}},
+		{"empty description clears it", "", strPtr(""), []string{"", ""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks := []*entities.Task{
+				{ID: uuid.New(), UserID: userID, Title: "Plants", Description: "Balcony", Status: entities.StatusTodo, SeriesID: &seriesID},
+				{ID: uuid.New(), UserID: userID, Title: "Plants", Description: "Kitchen", Status: entities.StatusTodo, SeriesID: &seriesID},
+			}
+			repo := &fakeTaskRepo{tasks: tasks, series: &entities.TaskSeries{ID: seriesID, UserID: userID}}
+			s := &taskService{taskRepo: repo}
+
+			if _, err := s.UpdateTaskSeries(seriesID, userID, tt.title, tt.description, "", "", nil); err != nil {
+				t.Fatalf("UpdateTaskSeries: %v", err)
+			}
+			for i, task := range tasks {
+				if task.Description != tt.want[i] {
+					t.Errorf("instance %d description = %q, want %q", i, task.Description, tt.want[i])
+				}
+				if tt.title != "" && task.Title != tt.title {
+					t.Errorf("instance %d title = %q, want %q", i, task.Title, tt.title)
+				}
+			}
+		})
+	}
+}
+
+func TestToggleTaskStatusWithOpenBlockers(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name         string
+		blockers     []*entities.Task
+		wantBlockers int
+		wantWarnings []string
+	}{
+		{"no blockers", nil, 0, []string{}},
+		{"closed blockers are ignored", []*entities.Task{{Title: "Buy paint", Status: entities.StatusDone}}, 0, []string{}},
+		{
+			"one open blocker",
+			[]*entities.Task{{Title: "Buy paint", Status: entities.StatusTodo}},
+			1,
+			[]string{`completed while blocked by open task "Buy paint"`},
+		},
+		{
+			"several open blockers",
+			[]*entities.Task{
+				{Title: "Buy paint", Status: entities.StatusTodo},
+				{Title: "Cover floor", Status: entities.StatusInProgress},
+				{Title: "Tape edges", Status: entities.StatusCancelled},
+			},
+			2,
+			[]string{`completed while blocked by 2 open tasks: "Buy paint", "Cover floor"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &entities.Task{ID: uuid.New(), UserID: userID, Title: "Paint the wall", Status: entities.StatusTodo}
+			s := &taskService{taskRepo: &fakeTaskRepo{tasks: []*entities.Task{task}, blockers: tt.blockers}}
+
+			result, err := s.ToggleTaskStatus(task.ID, userID, false)
+			if err != nil {
+				t.Fatalf("ToggleTaskStatus: %v", err)
+			}
+			if result.Task.Status != entities.StatusDone {
+				t.Errorf("status = %q, want %q", result.Task.Status, entities.StatusDone)
+			}
+			if len(result.Blockers) != tt.wantBlockers {
+				t.Errorf("blockers = %d, want %d", len(result.Blockers), tt.wantBlockers)
+			}
+			if !reflect.DeepEqual(result.Warnings, tt.wantWarnings) {
+				t.Errorf("warnings = %q, want %q", result.Warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
@@ -0,0 +1,574 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/entities"
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
+)
+
+// maxTimeRangeDays limits the date range of time entry lists, reports and exports
+const maxTimeRangeDays = 366
+
+type timeEntryService struct {
+	timeEntryRepo interfaces.TimeEntryRepository
+	taskRepo      interfaces.TaskRepository
+	projectRepo   interfaces.ProjectRepository
+	userRepo      interfaces.UserRepository
+}
+
+// NewTimeEntryService creates a new time entry service
+func NewTimeEntryService(timeEntryRepo interfaces.TimeEntryRepository, taskRepo interfaces.TaskRepository, projectRepo interfaces.ProjectRepository, userRepo interfaces.UserRepository) interfaces.TimeEntryService {
+	return &timeEntryService{
+		timeEntryRepo: timeEntryRepo,
+		taskRepo:      taskRepo,
+		projectRepo:   projectRepo,
+		userRepo:      userRepo,
+	}
+}
+
+// StartTimer starts a timer on a task (stops the running timer, restarting the running task keeps it)
+func (s *timeEntryService) StartTimer(userID, taskID uuid.UUID, note string) (*entities.TimeEntry, error) {
+	task, err := s.getTask(taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// A second device starting the same task gets the running timer
+	running, err := s.GetRunningTimer(userID)
+	if err != nil {
+		return nil, err
+	}
+	if running != nil && running.TaskID == taskID {
+		return running, nil
+	}
+
+	now := time.Now()
+	entry := &entities.TimeEntry{
+		ID:        uuid.New(),
+		UserID:    userID,
+		TaskID:    taskID,
+		StartedAt: now,
+		Note:      strings.TrimSpace(note),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.timeEntryRepo.StartTimer(entry); err != nil {
+		return nil, err
+	}
+
+	entry.Task = task
+	return entry, nil
+}
+
+// StopTimer stops the running timer of a user
+func (s *timeEntryService) StopTimer(userID uuid.UUID) (*entities.TimeEntry, error) {
+	entry, err := s.timeEntryRepo.StopRunningTimer(userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, errors.New("no timer is running")
+	}
+
+	return s.timeEntryRepo.FindTimeEntryByID(entry.ID)
+}
+
+// GetRunningTimer retrieves the running timer of a user (nil if none is running)
+func (s *timeEntryService) GetRunningTimer(userID uuid.UUID) (*entities.TimeEntry, error) {
+	entry, err := s.timeEntryRepo.FindRunningTimeEntry(userID)
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// CreateTimeEntry adds a manual time entry
+func (s *timeEntryService) CreateTimeEntry(userID, taskID uuid.UUID, startedAt, endedAt time.Time, note string) (*entities.TimeEntry, error) {
+	task, err := s.getTask(taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.validateTimeEntry(userID, uuid.Nil, startedAt, &endedAt); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	entry := &entities.TimeEntry{
+		ID:        uuid.New(),
+		UserID:    userID,
+		TaskID:    taskID,
+		StartedAt: startedAt,
+		EndedAt:   &endedAt,
+		Note:      strings.TrimSpace(note),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.timeEntryRepo.CreateTimeEntry(entry); err != nil {
+		return nil, err
+	}
+
+	entry.Task = task
+	return entry, nil
+}
+
+// UpdateTimeEntry edits a time entry (ensures user owns it and the task)
+func (s *timeEntryService) UpdateTimeEntry(entryID, userID, taskID uuid.UUID, startedAt time.Time, endedAt *time.Time, note string) (*entities.TimeEntry, error) {
+	entry, err := s.getTimeEntry(entryID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only a running timer has no end (timers are stopped with StopTimer)
+	if endedAt == nil && !entry.IsRunning() {
+		return nil, errors.New("end time is required")
+	}
+	if endedAt != nil && entry.IsRunning() {
+		return nil, errors.New("stop the timer to set its end time")
+	}
+
+	if taskID != entry.TaskID {
+		task, err := s.getTask(taskID, userID)
+		if err != nil {
+			return nil, err
+		}
+		entry.TaskID = taskID
+		entry.Task = task
+	}
+
+	if err := s.validateTimeEntry(userID, entry.ID, startedAt, endedAt); err != nil {
+		return nil, err
+	}
+
+	entry.StartedAt = startedAt
+	entry.EndedAt = endedAt
+	entry.Note = strings.TrimSpace(note)
+	entry.UpdatedAt = time.Now()
+
+	if err := s.timeEntryRepo.UpdateTimeEntry(entry); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// DeleteTimeEntry removes a time entry
+func (s *timeEntryService) DeleteTimeEntry(entryID, userID uuid.UUID) error {
+	// Verify ownership first
+	if _, err := s.getTimeEntry(entryID, userID); err != nil {
+		return err
+	}
+
+	return s.timeEntryRepo.DeleteTimeEntry(entryID)
+}
+
+// GetTimeEntries retrieves the entries of a user in a date range
+func (s *timeEntryService) GetTimeEntries(userID uuid.UUID, from, to, timezone string, taskID *uuid.UUID) ([]*entities.TimeEntry, error) {
+	if taskID != nil {
+		if _, err := s.getTask(*taskID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	_, rangeStart, rangeEnd, err := s.dateRange(userID, from, to, timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.timeEntryRepo.FindTimeEntriesByUserID(userID, rangeStart, rangeEnd, taskID)
+}
+
+// GetTimeReport sums up the time of a user in a date range
+func (s *timeEntryService) GetTimeReport(userID uuid.UUID, from, to, timezone string) (*interfaces.TimeReport, error) {
+	timezone, rangeStart, rangeEnd, err := s.dateRange(userID, from, to, timezone)
+	if err != nil {
+		return nil, err
+	}
+	loc := rangeStart.Location()
+
+	entries, err := s.timeEntryRepo.FindTimeEntriesByUserID(userID, rangeStart, rangeEnd, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	rollup, err := s.newTimeRollup(userID, entries)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &interfaces.TimeReport{
+		From:     rangeStart.Format("2006-01-02"),
+		To:       rangeEnd.AddDate(0, 0, -1).Format("2006-01-02"),
+		Timezone: timezone,
+	}
+
+	// Every day and week of the range, also without entries
+	for day := rangeStart; day.Before(rangeEnd); day = time.Date(day.Year(), day.Month(), day.Day()+1, 0, 0, 0, 0, loc) {
+		key := day.Format("2006-01-02")
+		report.Days = append(report.Days, interfaces.TimeTotal{Key: key, Label: key})
+
+		week := weekStart(day).Format("2006-01-02")
+		if len(report.Weeks) == 0 || report.Weeks[len(report.Weeks)-1].Key != week {
+			report.Weeks = append(report.Weeks, interfaces.TimeTotal{Key: week, Label: week})
+		}
+	}
+	days := make(map[string]*interfaces.TimeTotal)
+	for i := range report.Days {
+		days[report.Days[i].Key] = &report.Days[i]
+	}
+	weeks := make(map[string]*interfaces.TimeTotal)
+	for i := range report.Weeks {
+		weeks[report.Weeks[i].Key] = &report.Weeks[i]
+	}
+
+	projects := newTimeTotals()
+	domains := newTimeTotals()
+	tasks := newTimeTotals()
+
+	now := time.Now()
+	for _, entry := range entries {
+		// Clip to the range and split at local midnight
+		start := maxTime(entry.StartedAt, rangeStart).In(loc)
+		end := minTime(entry.End(now), rangeEnd).In(loc)
+		for start.Before(end) {
+			next := time.Date(start.Year(), start.Month(), start.Day()+1, 0, 0, 0, 0, loc)
+			seconds := int64(minTime(end, next).Sub(start).Seconds())
+
+			days[start.Format("2006-01-02")].Seconds += seconds
+			weeks[weekStart(start).Format("2006-01-02")].Seconds += seconds
+			start = next
+		}
+
+		seconds := int64(minTime(entry.End(now), rangeEnd).Sub(maxTime(entry.StartedAt, rangeStart)).Seconds())
+		report.TotalSeconds += seconds
+
+		root := rollup.root(entry.TaskID)
+		tasks.add(root.ID.String(), root.Title, seconds)
+		domain := rollup.domain(entry.TaskID)
+		domains.add(domain, domain, seconds)
+
+		entryProjects := rollup.projects(entry.TaskID)
+		if len(entryProjects) == 0 {
+			projects.add("", "No project", seconds)
+		}
+		for _, project := range entryProjects {
+			projects.add(project.ID.String(), project.Title, seconds)
+		}
+	}
+
+	report.Projects = projects.sorted()
+	report.Domains = domains.sorted()
+	report.Tasks = tasks.sorted()
+
+	return report, nil
+}
+
+// ExportTimeEntries exports the entries of a user in a date range as CSV (times in the user's timezone)
+func (s *timeEntryService) ExportTimeEntries(userID uuid.UUID, from, to, timezone string) ([]byte, error) {
+	_, rangeStart, rangeEnd, err := s.dateRange(userID, from, to, timezone)
+	if err != nil {
+		return nil, err
+	}
+	loc := rangeStart.Location()
+
+	entries, err := s.timeEntryRepo.FindTimeEntriesByUserID(userID, rangeStart, rangeEnd, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	rollup, err := s.newTimeRollup(userID, entries)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write([]string{"Date", "Start", "End", "Hours", "Task", "Top-level task", "Domain", "Projects", "Note"}); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, entry := range entries {
+		start := entry.StartedAt.In(loc)
+		end := ""
+		if entry.EndedAt != nil {
+			end = entry.EndedAt.In(loc).Format("2006-01-02 15:04")
+		}
+
+		title := ""
+		if task := rollup.tasks[entry.TaskID]; task != nil {
+			title = task.Title
+		}
+
+		var projects []string
+		for _, project := range rollup.projects(entry.TaskID) {
+			projects = append(projects, project.Title)
+		}
+
+		err := w.Write([]string{
+			start.Format("2006-01-02"),
+			start.Format("2006-01-02 15:04"),
+			end,
+			strconv.FormatFloat(entry.Duration(now).Hours(), 'f', 2, 64),
+			title,
+			rollup.root(entry.TaskID).Title,
+			rollup.domain(entry.TaskID),
+			strings.Join(projects, "; "),
+			entry.Note,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// getTask retrieves a task and verifies the user owns it
+func (s *timeEntryService) getTask(taskID, userID uuid.UUID) (*entities.Task, error) {
+	task, err := s.taskRepo.FindTaskByID(taskID)
+	if err != nil {
+		return nil, err
+	}
+	if task.UserID != userID {
+		return nil, errors.New("unauthorized: task does not belong to user")
+	}
+	return task, nil
+}
+
+// getTimeEntry retrieves a time entry and verifies the user owns it
+func (s *timeEntryService) getTimeEntry(entryID, userID uuid.UUID) (*entities.TimeEntry, error) {
+	entry, err := s.timeEntryRepo.FindTimeEntryByID(entryID)
+	if err != nil {
+		return nil, err
+	}
+	if entry.UserID != userID {
+		return nil, errors.New("unauthorized: time entry does not belong to user")
+	}
+	return entry, nil
+}
+
+// validateTimeEntry checks that an entry is in the past and does not overlap other entries of the user
+// (a nil end is a running timer)
+func (s *timeEntryService) validateTimeEntry(userID, entryID uuid.UUID, startedAt time.Time, endedAt *time.Time) error {
+	now := time.Now()
+	if startedAt.After(now) {
+		return errors.New("time entries cannot start in the future")
+	}
+
+	end := now
+	if endedAt != nil {
+		if !endedAt.After(startedAt) {
+			return errors.New("end time must be after start time")
+		}
+		if endedAt.After(now) {
+			return errors.New("time entries cannot end in the future")
+		}
+		end = *endedAt
+	}
+
+	overlapping, err := s.timeEntryRepo.FindOverlappingTimeEntries(userID, startedAt, end, entryID)
+	if err != nil {
+		return err
+	}
+	if len(overlapping) > 0 {
+		return errors.New("time entry overlaps an existing entry")
+	}
+	return nil
+}
+
+// dateRange resolves a date range (YYYY-MM-DD, inclusive, empty = current week) to [start, end) in the timezone
+func (s *timeEntryService) dateRange(userID uuid.UUID, from, to, timezone string) (string, time.Time, time.Time, error) {
+	timezone, err := resolveUserTimezone(s.userRepo, userID, timezone)
+	if err != nil {
+		return "", time.Time{}, time.Time{}, err
+	}
+	loc, _ := time.LoadLocation(timezone)
+
+	now := time.Now().In(loc)
+	start := weekStart(time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc))
+	last := start.AddDate(0, 0, 6)
+
+	if from != "" {
+		start, err = time.ParseInLocation("2006-01-02", from, loc)
+		if err != nil {
+			return "", time.Time{}, time.Time{}, errors.New("invalid from date (use YYYY-MM-DD)")
+		}
+	}
+	if to != "" {
+		last, err = time.ParseInLocation("2006-01-02", to, loc)
+		if err != nil {
+			return "", time.Time{}, time.Time{}, errors.New("invalid to date (use YYYY-MM-DD)")
+		}
+	} else if from != "" {
+		last = start.AddDate(0, 0, 6)
+	}
+
+	if last.Before(start) || last.Sub(start) > maxTimeRangeDays*24*time.Hour {
+		return "", time.Time{}, time.Time{}, errors.New("invalid date range")
+	}
+
+	end := time.Date(last.Year(), last.Month(), last.Day()+1, 0, 0, 0, 0, loc)
+	return timezone, start, end, nil
+}
+
+// weekStart returns the Monday of the week of a day
+func weekStart(day time.Time) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day()-(int(day.Weekday())+6)%7, 0, 0, 0, 0, day.Location())
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+// timeRollup resolves the top-level task, domain and projects time on a task counts for
+type timeRollup struct {
+	tasks        map[uuid.UUID]*entities.Task
+	taskProjects map[uuid.UUID][]*entities.Project
+}
+
+// newTimeRollup loads the tasks of a user and the projects of the tasks of the entries and their parents
+func (s *timeEntryService) newTimeRollup(userID uuid.UUID, entries []*entities.TimeEntry) (*timeRollup, error) {
+	tasks, err := s.taskRepo.FindTasksByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rollup := &timeRollup{
+		tasks:        make(map[uuid.UUID]*entities.Task),
+		taskProjects: make(map[uuid.UUID][]*entities.Project),
+	}
+	for _, task := range tasks {
+		rollup.tasks[task.ID] = task
+	}
+
+	// Projects can be assigned to the task of an entry or any of its parents
+	seen := make(map[uuid.UUID]bool)
+	var ids []uuid.UUID
+	for _, entry := range entries {
+		for _, task := range rollup.ancestors(entry.TaskID) {
+			if !seen[task.ID] {
+				seen[task.ID] = true
+				ids = append(ids, task.ID)
+			}
+		}
+	}
+
+	projectTasks, err := s.projectRepo.FindProjectTasksByTaskIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, pt := range projectTasks {
+		project := pt.Project
+		rollup.taskProjects[pt.TaskID] = append(rollup.taskProjects[pt.TaskID], &project)
+	}
+
+	return rollup, nil
+}
+
+// ancestors returns a task followed by its parents (empty for unknown tasks)
+func (r *timeRollup) ancestors(taskID uuid.UUID) []*entities.Task {
+	var chain []*entities.Task
+	task := r.tasks[taskID]
+	for task != nil && len(chain) <= entities.MaxSubtaskDepth {
+		chain = append(chain, task)
+		if task.ParentTaskID == nil {
+			break
+		}
+		task = r.tasks[*task.ParentTaskID]
+	}
+	return chain
+}
+
+// root returns the top-level task of a task
+func (r *timeRollup) root(taskID uuid.UUID) *entities.Task {
+	chain := r.ancestors(taskID)
+	if len(chain) == 0 {
+		return &entities.Task{ID: taskID}
+	}
+	return chain[len(chain)-1]
+}
+
+// domain returns the domain of a task
+func (r *timeRollup) domain(taskID uuid.UUID) string {
+	if task := r.tasks[taskID]; task != nil {
+		return task.Domain
+	}
+	return ""
+}
+
+// projects returns the projects of a task and its parents (each once)
+func (r *timeRollup) projects(taskID uuid.UUID) []*entities.Project {
+	var projects []*entities.Project
+	seen := make(map[uuid.UUID]bool)
+	for _, task := range r.ancestors(taskID) {
+		for _, project := range r.taskProjects[task.ID] {
+			if !seen[project.ID] {
+				seen[project.ID] = true
+				projects = append(projects, project)
+			}
+		}
+	}
+	return projects
+}
+
+// timeTotals sums up seconds per key
+type timeTotals struct {
+	totals map[string]*interfaces.TimeTotal
+}
+
+func newTimeTotals() *timeTotals {
+	return &timeTotals{totals: make(map[string]*interfaces.TimeTotal)}
+}
+
+// add adds seconds to a group
+func (t *timeTotals) add(key, label string, seconds int64) {
+	total, ok := t.totals[key]
+	if !ok {
+		total = &interfaces.TimeTotal{Key: key, Label: label}
+		t.totals[key] = total
+	}
+	total.Seconds += seconds
+}
+
+// sorted returns the groups with the most time first (ties by label)
+func (t *timeTotals) sorted() []interfaces.TimeTotal {
+	result := make([]interfaces.TimeTotal, 0, len(t.totals))
+	for _, total := range t.totals {
+		result = append(result, *total)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Seconds != result[j].Seconds {
+			return result[i].Seconds > result[j].Seconds
+		}
+		return result[i].Label < result[j].Label
+	})
+	return result
+}
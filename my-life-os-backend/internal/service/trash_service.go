@@ -0,0 +1,273 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/J0kerul/my-life-os-v1.5/my-life-os-backend/internal/domain/interfaces"
+	"gorm.io/gorm"
+
+	"github.com/google/uuid"
+)
+
+// trashPurgeInterval is how often expired trash is purged
+const trashPurgeInterval = time.Hour
+
+type trashService struct {
+	taskRepo      interfaces.TaskRepository
+	routineRepo   interfaces.RoutineRepository
+	projectRepo   interfaces.ProjectRepository
+	eventRepo     interfaces.EventRepository
+	retentionDays int
+}
+
+// NewTrashService creates a new trash service (trashed items are purged after retentionDays)
+func NewTrashService(
+	taskRepo interfaces.TaskRepository,
+	routineRepo interfaces.RoutineRepository,
+	projectRepo interfaces.ProjectRepository,
+	eventRepo interfaces.EventRepository,
+	retentionDays int,
+) interfaces.TrashService {
+	return &trashService{
+		taskRepo:      taskRepo,
+		routineRepo:   routineRepo,
+		projectRepo:   projectRepo,
+		eventRepo:     eventRepo,
+		retentionDays: retentionDays,
+	}
+}
+
+// GetTrash retrieves the trashed items of a user (all types if itemType is empty)
+func (s *trashService) GetTrash(userID uuid.UUID, itemType string) (*interfaces.Trash, error) {
+	types := interfaces.TrashTypes
+	if itemType != "" {
+		if !isTrashType(itemType) {
+			return nil, errors.New("invalid trash type: " + itemType)
+		}
+		types = []string{itemType}
+	}
+
+	trash := &interfaces.Trash{RetentionDays: s.retentionDays}
+	var err error
+	for _, t := range types {
+		switch t {
+		case interfaces.TrashTypeTask:
+			trash.Tasks, err = s.taskRepo.FindDeletedTasksByUserID(userID)
+		case interfaces.TrashTypeRoutine:
+			trash.Routines, err = s.routineRepo.GetDeletedRoutinesByUserID(userID)
+		case interfaces.TrashTypeProject:
+			trash.Projects, err = s.projectRepo.FindDeletedProjectsByUserID(userID)
+		case interfaces.TrashTypeEvent:
+			trash.Events, err = s.eventRepo.FindDeletedEventsByUserID(userID)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return trash, nil
+}
+
+// RestoreItem takes a trashed item out of the trash (ensures user owns it).
+// Tasks come back with the subtasks deleted together with them, a subtask needs its parent restored first.
+// Links to other items (tags, projects, dependencies, completions, exceptions) were kept while trashed.
+func (s *trashService) RestoreItem(userID uuid.UUID, itemType string, itemID uuid.UUID) error {
+	switch itemType {
+	case interfaces.TrashTypeTask:
+		task, err := s.taskRepo.FindDeletedTaskByID(itemID)
+		if err != nil {
+			return err
+		}
+		if task.UserID != userID {
+			return errors.New("unauthorized: task does not belong to user")
+		}
+
+		// Subtasks cannot be restored below a trashed parent
+		if task.ParentTaskID != nil {
+			if _, err := s.taskRepo.FindTaskByID(*task.ParentTaskID); err != nil {
+				if err == gorm.ErrRecordNotFound {
+					return errors.New("restore the parent task first")
+				}
+				return err
+			}
+		}
+
+		return s.taskRepo.RestoreTask(task)
+
+	case interfaces.TrashTypeRoutine:
+		routine, err := s.routineRepo.GetDeletedRoutineByID(itemID)
+		if err != nil {
+			return err
+		}
+		if routine.UserID != userID {
+			return errors.New("unauthorized: routine does not belong to user")
+		}
+		return s.routineRepo.RestoreRoutine(routine.ID)
+
+	case interfaces.TrashTypeProject:
+		project, err := s.projectRepo.FindDeletedProjectByID(itemID)
+		if err != nil {
+			return err
+		}
+		if project.UserID != userID {
+			return errors.New("unauthorized: project does not belong to user")
+		}
+		return s.projectRepo.RestoreProject(project.ID)
+
+	case interfaces.TrashTypeEvent:
+		event, err := s.eventRepo.FindDeletedEventByID(itemID)
+		if err != nil {
+			return err
+		}
+		if event.UserID != userID {
+			return errors.New("unauthorized: event does not belong to user")
+		}
+		return s.eventRepo.RestoreEvent(event.ID)
+
+	default:
+		return errors.New("invalid trash type: " + itemType)
+	}
+}
+
+// DeleteItem permanently deletes a trashed item (ensures user owns it)
+func (s *trashService) DeleteItem(userID uuid.UUID, itemType string, itemID uuid.UUID) error {
+	switch itemType {
+	case interfaces.TrashTypeTask:
+		task, err := s.taskRepo.FindDeletedTaskByID(itemID)
+		if err != nil {
+			return err
+		}
+		if task.UserID != userID {
+			return errors.New("unauthorized: task does not belong to user")
+		}
+		return s.taskRepo.PurgeTask(task.ID)
+
+	case interfaces.TrashTypeRoutine:
+		routine, err := s.routineRepo.GetDeletedRoutineByID(itemID)
+		if err != nil {
+			return err
+		}
+		if routine.UserID != userID {
+			return errors.New("unauthorized: routine does not belong to user")
+		}
+		return s.routineRepo.PurgeRoutine(routine.ID)
+
+	case interfaces.TrashTypeProject:
+		project, err := s.projectRepo.FindDeletedProjectByID(itemID)
+		if err != nil {
+			return err
+		}
+		if project.UserID != userID {
+			return errors.New("unauthorized: project does not belong to user")
+		}
+		return s.projectRepo.PurgeProject(project.ID)
+
+	case interfaces.TrashTypeEvent:
+		event, err := s.eventRepo.FindDeletedEventByID(itemID)
+		if err != nil {
+			return err
+		}
+		if event.UserID != userID {
+			return errors.New("unauthorized: event does not belong to user")
+		}
+		return s.eventRepo.PurgeEvent(event.ID)
+
+	default:
+		return errors.New("invalid trash type: " + itemType)
+	}
+}
+
+// EmptyTrash permanently deletes all trashed items of a user
+func (s *trashService) EmptyTrash(userID uuid.UUID) error {
+	trash, err := s.GetTrash(userID, "")
+	if err != nil {
+		return err
+	}
+
+	for _, task := range trash.Tasks {
+		if err := s.taskRepo.PurgeTask(task.ID); err != nil {
+			return err
+		}
+	}
+	for _, routine := range trash.Routines {
+		if err := s.routineRepo.PurgeRoutine(routine.ID); err != nil {
+			return err
+		}
+	}
+	for _, project := range trash.Projects {
+		if err := s.projectRepo.PurgeProject(project.ID); err != nil {
+			return err
+		}
+	}
+	for _, event := range trash.Events {
+		if err := s.eventRepo.PurgeEvent(event.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PurgeExpired permanently deletes everything trashed longer than the retention period
+func (s *trashService) PurgeExpired(now time.Time) (int64, error) {
+	before := now.AddDate(0, 0, -s.retentionDays)
+	purges := []func(time.Time) (int64, error){
+		s.taskRepo.PurgeDeletedTasks,
+		s.routineRepo.PurgeDeletedRoutines,
+		s.projectRepo.PurgeDeletedProjects,
+		s.eventRepo.PurgeDeletedEvents,
+	}
+
+	var total int64
+	for _, purge := range purges {
+		purged, err := purge(before)
+		if err != nil {
+			return total, err
+		}
+		total += purged
+	}
+	return total, nil
+}
+
+// isTrashType checks if items of a type can be in the trash
+func isTrashType(itemType string) bool {
+	for _, t := range interfaces.TrashTypes {
+		if t == itemType {
+			return true
+		}
+	}
+	return false
+}
+
+type trashPurger struct {
+	trashService interfaces.TrashService
+}
+
+// NewTrashPurger creates a background job purging expired trash
+func NewTrashPurger(trashService interfaces.TrashService) interfaces.TrashPurger {
+	return &trashPurger{trashService: trashService}
+}
+
+// Start purges expired trash every hour until the context is cancelled
+func (p *trashPurger) Start(ctx context.Context) {
+	ticker := time.NewTicker(trashPurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := p.trashService.PurgeExpired(time.Now())
+		if err != nil {
+			log.Printf("Trash purger: %v", err)
+		} else if purged > 0 {
+			log.Printf("Trash purger: purged %d items", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
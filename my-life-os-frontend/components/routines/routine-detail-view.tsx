@@ -54,6 +54,8 @@ const FREQUENCY_COLORS = {
   Monthly: { text: "text-purple-500", bg: "bg-purple-500/10" },
   Quarterly: { text: "text-orange-500", bg: "bg-orange-500/10" },
   Yearly: { text: "text-pink-500", bg: "bg-pink-500/10" },
+  Interval: { text: "text-teal-500", bg: "bg-teal-500/10" },
+  Quota: { text: "text-amber-500", bg: "bg-amber-500/10" },
 };
 
 const TIME_TYPE_LABELS = {
@@ -10,6 +10,8 @@ const FREQUENCY_FILTERS: { value: RoutineFrequency | null; label: string }[] = [
   { value: "Monthly", label: "Monthly" },
   { value: "Quarterly", label: "Quarterly" },
   { value: "Yearly", label: "Yearly" },
+  { value: "Interval", label: "Every N Days" },
+  { value: "Quota", label: "Quota" },
 ];
 
 export function RoutineFilterSidebar() {
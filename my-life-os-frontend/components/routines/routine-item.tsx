@@ -41,6 +41,16 @@ export function RoutineItem({ routine }: RoutineItemProps) {
           color: "text-pink-500",
           bg: "bg-pink-500/10",
         };
+      case "Interval":
+        return {
+          color: "text-teal-500",
+          bg: "bg-teal-500/10",
+        };
+      case "Quota":
+        return {
+          color: "text-amber-500",
+          bg: "bg-amber-500/10",
+        };
     }
   };
 
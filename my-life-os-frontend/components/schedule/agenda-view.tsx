@@ -3,6 +3,7 @@
 import { useEventStore } from "@/lib/store/event-store";
 import { format, isSameDay, parseISO, startOfDay } from "date-fns";
 import { Calendar } from "lucide-react";
+import { formatRecurrenceRule } from "@/lib/utils";
 
 export function AgendaView() {
   const { events, selectEvent, currentDate } = useEventStore();
@@ -114,8 +115,8 @@ export function AgendaView() {
                         {event.isRecurring && (
                           <>
                             <span>•</span>
-                            <span className="capitalize">
-                              🔁 {event.recurrenceType}
+                            <span>
+                              🔁 {formatRecurrenceRule(event.recurrenceRule)}
                             </span>
                           </>
                         )}
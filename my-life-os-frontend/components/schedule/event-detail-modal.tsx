@@ -4,6 +4,7 @@ import { useEffect, useState } from "react";
 import { X, Calendar, Tag, Clock } from "lucide-react";
 import { useEventStore } from "@/lib/store/event-store";
 import { format } from "date-fns";
+import { formatRecurrenceRule } from "@/lib/utils";
 
 interface EventDetailModalProps {
   eventId: string | null;
@@ -100,8 +101,8 @@ export function EventDetailModal({
               <label className="text-xs font-medium text-muted-foreground mb-2 block">
                 Recurrence
               </label>
-              <div className="text-sm capitalize">
-                🔁 {event.recurrenceType}
+              <div className="text-sm">
+                🔁 {formatRecurrenceRule(event.recurrenceRule)}
                 {event.recurrenceEnd && (
                   <span className="text-muted-foreground">
                     {" "}
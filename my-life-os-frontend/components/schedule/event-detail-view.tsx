@@ -4,6 +4,7 @@ import { useState } from "react";
 import { useEventStore } from "@/lib/store/event-store";
 import { Calendar, X, Trash2, Edit, Tag, Clock } from "lucide-react";
 import { format } from "date-fns";
+import { formatRecurrenceRule } from "@/lib/utils";
 import type { EditScope, DeleteScope } from "@/types";
 
 export function EventDetailView() {
@@ -410,8 +411,8 @@ export function EventDetailView() {
             <label className="text-xs font-medium text-muted-foreground mb-2 block">
               Recurrence
             </label>
-            <div className="text-sm capitalize">
-              🔁 {selectedEvent.recurrenceType}
+            <div className="text-sm">
+              🔁 {formatRecurrenceRule(selectedEvent.recurrenceRule)}
               {selectedEvent.recurrenceEnd && (
                 <span className="text-muted-foreground">
                   {" "}
@@ -3,6 +3,7 @@
 import { useState, useEffect } from "react";
 import { X } from "lucide-react";
 import { useEventStore } from "@/lib/store/event-store";
+import { EventConflictError } from "@/lib/api/events";
 import type { CreateEventRequest, EventDomain, RecurrenceType } from "@/types";
 
 interface QuickAddEventDialogProps {
@@ -86,6 +87,7 @@ export function QuickAddEventDialog({
         startDate: startDateTime,
         endDate: endDateTime,
         allDay: formData.allDay,
+        timezone: Intl.DateTimeFormat().resolvedOptions().timeZone,
         domain: formData.domain,
         isRecurring: formData.isRecurring,
         recurrenceType: formData.isRecurring ? formData.recurrenceType : null,
@@ -99,7 +101,28 @@ export function QuickAddEventDialog({
         hideFromAgenda: formData.hideFromAgenda,
       };
 
-      await createEvent(eventData);
+      try {
+        await createEvent(eventData);
+      } catch (error) {
+        if (!(error instanceof EventConflictError)) throw error;
+
+        // List the colliding events and let the user save anyway
+        const list = error.conflicts
+          .slice(0, 5)
+          .map(
+            (c) =>
+              `- ${c.title} (${new Date(c.occurrenceDate).toLocaleString()}, ${c.overlapMinutes} min overlap)`
+          )
+          .join("\n");
+        const more =
+          error.conflicts.length > 5
+            ? `\n...and ${error.conflicts.length - 5} more`
+            : "";
+        if (!window.confirm(`This event overlaps:\n${list}${more}\n\nCreate it anyway?`)) {
+          return;
+        }
+        await createEvent({ ...eventData, allowConflict: true });
+      }
       resetForm();
       onClose();
     } catch (error) {
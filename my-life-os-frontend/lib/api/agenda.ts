@@ -0,0 +1,24 @@
+import { Agenda, AgendaView } from "@/types";
+
+// Get agenda of a day or week (date as YYYY-MM-DD, defaults to today)
+export async function getAgenda(view: AgendaView = "day", date?: string): Promise<{ agenda: Agenda }> {
+  const params = new URLSearchParams({ view });
+  if (date) {
+    params.append("date", date);
+  }
+
+  const response = await fetch(`/api/agenda?${params.toString()}`, {
+    method: "GET",
+    headers: {
+      "Content-Type": "application/json",
+    },
+    credentials: "include",
+  });
+
+  if (!response.ok) {
+    const error = await response.json();
+    throw new Error(error.error || "Failed to fetch agenda");
+  }
+
+  return response.json();
+}
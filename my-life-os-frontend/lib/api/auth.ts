@@ -3,6 +3,7 @@ import type {
   SetupRequest,
   LoginRequest,
   AuthResponse,
+  UpdateSettingsRequest,
   User,
   ApiError,
 } from "@/types";
@@ -75,6 +76,16 @@ export async function logoutUser(): Promise<{ message: string }> {
   });
 }
 
+// Update timezone and routine day rollover hour
+export async function updateSettings(
+  settings: UpdateSettingsRequest
+): Promise<AuthResponse> {
+  return apiCall<AuthResponse>("/auth/settings", {
+    method: "PUT",
+    body: JSON.stringify(settings),
+  });
+}
+
 // Get current user (with auto-retry on 401)
 export async function getCurrentUser(): Promise<{ id: string }> {
   try {
@@ -0,0 +1,80 @@
+import { MoveTaskRequest, MoveTaskResult, ProjectBoard, Task, TaskListType, TaskStatus } from "@/types";
+
+// Get the tasks of a list in manual order
+export async function getOrderedTasks(list: TaskListType, key: string): Promise<Task[]> {
+  const response = await fetch(`/api/tasks/lists/${list}?key=${encodeURIComponent(key)}`, {
+    method: "GET",
+    headers: {
+      "Content-Type": "application/json",
+    },
+    credentials: "include",
+  });
+
+  if (!response.ok) {
+    const error = await response.json();
+    throw new Error(error.error || "Failed to fetch tasks");
+  }
+
+  const data = await response.json();
+  return data.tasks;
+}
+
+// Move a task in a list (force closes open subtasks when moving into a closed status)
+export async function moveTask(id: string, data: MoveTaskRequest, force = false): Promise<MoveTaskResult> {
+  const query = force ? "?force=true" : "";
+  const response = await fetch(`/api/tasks/${id}/move${query}`, {
+    method: "POST",
+    headers: {
+      "Content-Type": "application/json",
+    },
+    credentials: "include",
+    body: JSON.stringify(data),
+  });
+
+  if (!response.ok) {
+    const error = await response.json();
+    throw new Error(error.error || "Failed to move task");
+  }
+
+  return response.json();
+}
+
+// Get the Kanban board of a project
+export async function getProjectBoard(projectId: string): Promise<ProjectBoard> {
+  const response = await fetch(`/api/projects/${projectId}/board`, {
+    method: "GET",
+    headers: {
+      "Content-Type": "application/json",
+    },
+    credentials: "include",
+  });
+
+  if (!response.ok) {
+    const error = await response.json();
+    throw new Error(error.error || "Failed to fetch board");
+  }
+
+  return response.json();
+}
+
+// Replace the WIP limits of a project (0 removes a limit)
+export async function setProjectWIPLimits(
+  projectId: string,
+  limits: Partial<Record<TaskStatus, number>>
+): Promise<ProjectBoard> {
+  const response = await fetch(`/api/projects/${projectId}/wip-limits`, {
+    method: "PUT",
+    headers: {
+      "Content-Type": "application/json",
+    },
+    credentials: "include",
+    body: JSON.stringify({ limits }),
+  });
+
+  if (!response.ok) {
+    const error = await response.json();
+    throw new Error(error.error || "Failed to update WIP limits");
+  }
+
+  return response.json();
+}
@@ -0,0 +1,94 @@
+import { Domain, CreateDomainRequest, UpdateDomainRequest } from "@/types";
+
+// Get all domains
+export async function getDomains(includeArchived = false): Promise<{ domains: Domain[] }> {
+  const response = await fetch(`/api/domains?includeArchived=${includeArchived}`, {
+    method: "GET",
+    headers: {
+      "Content-Type": "application/json",
+    },
+    credentials: "include",
+  });
+
+  if (!response.ok) {
+    const error = await response.json();
+    throw new Error(error.error || "Failed to fetch domains");
+  }
+
+  return response.json();
+}
+
+// Create domain
+export async function createDomain(data: CreateDomainRequest): Promise<{ message: string; domain: Domain }> {
+  const response = await fetch("/api/domains", {
+    method: "POST",
+    headers: {
+      "Content-Type": "application/json",
+    },
+    credentials: "include",
+    body: JSON.stringify(data),
+  });
+
+  if (!response.ok) {
+    const error = await response.json();
+    throw new Error(error.error || "Failed to create domain");
+  }
+
+  return response.json();
+}
+
+// Update domain (renaming also renames it on all tasks and events)
+export async function updateDomain(id: string, data: UpdateDomainRequest): Promise<{ message: string; domain: Domain }> {
+  const response = await fetch(`/api/domains/${id}`, {
+    method: "PUT",
+    headers: {
+      "Content-Type": "application/json",
+    },
+    credentials: "include",
+    body: JSON.stringify(data),
+  });
+
+  if (!response.ok) {
+    const error = await response.json();
+    throw new Error(error.error || "Failed to update domain");
+  }
+
+  return response.json();
+}
+
+// Merge domain into another one (moves all tasks and events, deletes the source)
+export async function mergeDomain(id: string, targetId: string): Promise<{ message: string; domain: Domain }> {
+  const response = await fetch(`/api/domains/${id}/merge`, {
+    method: "POST",
+    headers: {
+      "Content-Type": "application/json",
+    },
+    credentials: "include",
+    body: JSON.stringify({ targetId }),
+  });
+
+  if (!response.ok) {
+    const error = await response.json();
+    throw new Error(error.error || "Failed to merge domains");
+  }
+
+  return response.json();
+}
+
+// Delete domain
+export async function deleteDomain(id: string): Promise<{ message: string }> {
+  const response = await fetch(`/api/domains/${id}`, {
+    method: "DELETE",
+    headers: {
+      "Content-Type": "application/json",
+    },
+    credentials: "include",
+  });
+
+  if (!response.ok) {
+    const error = await response.json();
+    throw new Error(error.error || "Failed to delete domain");
+  }
+
+  return response.json();
+}
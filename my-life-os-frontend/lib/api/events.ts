@@ -5,10 +5,23 @@ import type {
   DeleteEventRequest,
   EventsResponse,
   EventResponse,
+  EventConflict,
+  EventException,
 } from "@/types";
 
 const API_BASE = "/api";
 
+// Thrown when an event overlaps existing events (retry with allowConflict: true to save anyway)
+export class EventConflictError extends Error {
+  conflicts: EventConflict[];
+
+  constructor(message: string, conflicts: EventConflict[]) {
+    super(message);
+    this.name = "EventConflictError";
+    this.conflicts = conflicts;
+  }
+}
+
 // Helper: Refresh token if needed
 async function refreshTokenIfNeeded(): Promise<void> {
   try {
@@ -56,6 +69,8 @@ export async function getEvents(
   const params = new URLSearchParams();
   params.append("start", start);
   params.append("end", end);
+  // Show times in the browser's timezone (e.g. while traveling)
+  params.append("timezone", Intl.DateTimeFormat().resolvedOptions().timeZone);
 
   const url = `${API_BASE}/events?${params.toString()}`;
 
@@ -107,6 +122,9 @@ export async function createEvent(
 
   if (!response.ok) {
     const error = await response.json();
+    if (response.status === 409) {
+      throw new EventConflictError(error.error, error.conflicts || []);
+    }
     throw new Error(error.error || "Failed to create event");
   }
 
@@ -129,6 +147,9 @@ export async function updateEvent(
 
   if (!response.ok) {
     const error = await response.json();
+    if (response.status === 409) {
+      throw new EventConflictError(error.error, error.conflicts || []);
+    }
     throw new Error(error.error || "Failed to update event");
   }
 
@@ -153,4 +174,69 @@ export async function deleteEvent(
     const error = await response.json();
     throw new Error(error.error || "Failed to delete event");
   }
-}
\ No newline at end of file
+}
+// Get deleted and modified occurrences of a recurring event
+export async function getEventExceptions(eventId: string): Promise<EventException[]> {
+  const response = await fetchWithAuth(`${API_BASE}/events/${eventId}/exceptions`, {
+    method: "GET",
+    headers: {
+      "Content-Type": "application/json",
+    },
+  });
+
+  if (!response.ok) {
+    const error = await response.json();
+    throw new Error(error.error || "Failed to fetch event exceptions");
+  }
+
+  const data: { exceptions: EventException[] } = await response.json();
+  return data.exceptions;
+}
+
+// Restore a deleted occurrence
+export async function restoreOccurrence(eventId: string, exceptionId: string): Promise<void> {
+  const response = await fetchWithAuth(`${API_BASE}/events/${eventId}/exceptions/${exceptionId}/restore`, {
+    method: "POST",
+    headers: {
+      "Content-Type": "application/json",
+    },
+  });
+
+  if (!response.ok) {
+    const error = await response.json();
+    throw new Error(error.error || "Failed to restore occurrence");
+  }
+}
+
+// Reset a modified occurrence to the series values
+export async function revertOccurrence(eventId: string, exceptionId: string): Promise<void> {
+  const response = await fetchWithAuth(`${API_BASE}/events/${eventId}/exceptions/${exceptionId}/revert`, {
+    method: "POST",
+    headers: {
+      "Content-Type": "application/json",
+    },
+  });
+
+  if (!response.ok) {
+    const error = await response.json();
+    throw new Error(error.error || "Failed to revert occurrence");
+  }
+}
+
+// Merge a split series back into the series it was split from
+export async function mergeEventSplit(eventId: string): Promise<Event> {
+  const response = await fetchWithAuth(`${API_BASE}/events/${eventId}/merge`, {
+    method: "POST",
+    headers: {
+      "Content-Type": "application/json",
+    },
+  });
+
+  if (!response.ok) {
+    const error = await response.json();
+    throw new Error(error.error || "Failed to merge events");
+  }
+
+  const data: EventResponse = await response.json();
+  return data.event;
+}
@@ -3,6 +3,7 @@ import type {
   UpdateProjectRequest,
   AssignTaskRequest,
   ProjectStatus,
+  ProjectTaskGraph,
 } from "@/types";
 
 const API_BASE = "/api";
@@ -160,8 +161,8 @@ export async function unassignTaskFromProject(projectId: string, taskId: string)
   return response.json();
 }
 
-// Get all tasks assigned to a project
-export async function getProjectTasks(projectId: string) {
+// Get all tasks assigned to a project with their dependency graph
+export async function getProjectTasks(projectId: string): Promise<ProjectTaskGraph> {
   const response = await fetchWithAuth(`${API_BASE}/projects/${projectId}/tasks`, {
     method: "GET",
     headers: {
@@ -175,4 +176,22 @@ export async function getProjectTasks(projectId: string) {
   }
 
   return response.json();
-}
\ No newline at end of file
+}
+
+export async function getProjectProgress(projectId: string, includeSubtasks = false): Promise<number> {
+  const query = includeSubtasks ? "?includeSubtasks=true" : "";
+  const response = await fetchWithAuth(`${API_BASE}/projects/${projectId}/progress${query}`, {
+    method: "GET",
+    headers: {
+      "Content-Type": "application/json",
+    },
+  });
+
+  if (!response.ok) {
+    const error = await response.json();
+    throw new Error(error.error || "Failed to fetch project progress");
+  }
+
+  const data: { progress: number } = await response.json();
+  return data.progress;
+}
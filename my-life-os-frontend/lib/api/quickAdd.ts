@@ -0,0 +1,40 @@
+import { QuickAddInterpretation, QuickAddRequest, QuickAddResponse } from "@/types";
+
+// Interpret a quick-add text without creating anything (for confirmation)
+export async function previewQuickAdd(request: QuickAddRequest): Promise<QuickAddInterpretation> {
+  const response = await fetch("/api/quick-add/preview", {
+    method: "POST",
+    headers: {
+      "Content-Type": "application/json",
+    },
+    credentials: "include",
+    body: JSON.stringify(request),
+  });
+
+  if (!response.ok) {
+    const error = await response.json();
+    throw new Error(error.error || "Failed to interpret text");
+  }
+
+  const data = await response.json();
+  return data.interpretation;
+}
+
+// Create a task or event from a quick-add text
+export async function quickAdd(request: QuickAddRequest): Promise<QuickAddResponse> {
+  const response = await fetch("/api/quick-add", {
+    method: "POST",
+    headers: {
+      "Content-Type": "application/json",
+    },
+    credentials: "include",
+    body: JSON.stringify(request),
+  });
+
+  if (!response.ok) {
+    const error = await response.json();
+    throw new Error(error.error || "Failed to quick-add");
+  }
+
+  return response.json();
+}
@@ -5,6 +5,10 @@ import type {
   RoutinesResponse,
   RoutineResponse,
   RoutineFrequency,
+  RoutineCompletion,
+  RoutineCompletionRequest,
+  RoutineCompletionResponse,
+  RoutineStats,
 } from "@/types";
 
 const API_BASE = "/api";
@@ -196,4 +200,114 @@ export async function deleteRoutine(routineId: string): Promise<void> {
     const error = await response.json();
     throw new Error(error.error || "Failed to delete routine");
   }
-}
\ No newline at end of file
+}
+// Get completions/skips of a routine (YYYY-MM-DD, inclusive, default last 30 days)
+export async function getRoutineCompletions(
+  routineId: string,
+  from?: string,
+  to?: string
+): Promise<RoutineCompletion[]> {
+  const params = new URLSearchParams();
+  if (from) params.append("from", from);
+  if (to) params.append("to", to);
+  const query = params.toString() ? `?${params.toString()}` : "";
+
+  const response = await fetchWithAuth(`${API_BASE}/routines/${routineId}/completions${query}`, {
+    method: "GET",
+    headers: {
+      "Content-Type": "application/json",
+    },
+  });
+
+  if (!response.ok) {
+    const error = await response.json();
+    throw new Error(error.error || "Failed to fetch completions");
+  }
+
+  const data = await response.json();
+  return data.completions;
+}
+
+// Log a completion/skip for today or a past date
+export async function logRoutineCompletion(
+  routineId: string,
+  data: Required<RoutineCompletionRequest>
+): Promise<RoutineCompletionResponse> {
+  const response = await fetchWithAuth(`${API_BASE}/routines/${routineId}/completions`, {
+    method: "POST",
+    headers: {
+      "Content-Type": "application/json",
+    },
+    body: JSON.stringify(data),
+  });
+
+  if (!response.ok) {
+    const error = await response.json();
+    throw new Error(error.error || "Failed to log completion");
+  }
+
+  return response.json();
+}
+
+// Change the date or status of a completion/skip
+export async function updateRoutineCompletion(
+  routineId: string,
+  completionId: string,
+  data: RoutineCompletionRequest
+): Promise<RoutineCompletionResponse> {
+  const response = await fetchWithAuth(`${API_BASE}/routines/${routineId}/completions/${completionId}`, {
+    method: "PUT",
+    headers: {
+      "Content-Type": "application/json",
+    },
+    body: JSON.stringify(data),
+  });
+
+  if (!response.ok) {
+    const error = await response.json();
+    throw new Error(error.error || "Failed to update completion");
+  }
+
+  return response.json();
+}
+
+// Undo a completion/skip (returns the routine with recomputed streaks)
+export async function deleteRoutineCompletion(routineId: string, completionId: string): Promise<Routine> {
+  const response = await fetchWithAuth(`${API_BASE}/routines/${routineId}/completions/${completionId}`, {
+    method: "DELETE",
+    headers: {
+      "Content-Type": "application/json",
+    },
+  });
+
+  if (!response.ok) {
+    const error = await response.json();
+    throw new Error(error.error || "Failed to delete completion");
+  }
+
+  const data = await response.json();
+  return data.routine;
+}
+
+// Get routine statistics (one routine or all routines, YYYY-MM-DD, default last year)
+export async function getRoutineStats(routineId?: string, from?: string, to?: string): Promise<RoutineStats> {
+  const params = new URLSearchParams();
+  if (from) params.append("from", from);
+  if (to) params.append("to", to);
+  const query = params.toString() ? `?${params.toString()}` : "";
+  const path = routineId ? `routines/${routineId}/stats` : "routines/stats";
+
+  const response = await fetchWithAuth(`${API_BASE}/${path}${query}`, {
+    method: "GET",
+    headers: {
+      "Content-Type": "application/json",
+    },
+  });
+
+  if (!response.ok) {
+    const error = await response.json();
+    throw new Error(error.error || "Failed to fetch routine statistics");
+  }
+
+  return response.json();
+}
@@ -0,0 +1,80 @@
+import { Tag, TagUsage } from "@/types";
+
+// Get tags with usage counts, most used first (prefix for autocomplete)
+export async function getTags(prefix = "", limit = 0): Promise<{ tags: TagUsage[] }> {
+  const params = new URLSearchParams();
+  if (prefix) params.append("q", prefix);
+  if (limit > 0) params.append("limit", String(limit));
+
+  const queryString = params.toString();
+  const response = await fetch(`/api/tags${queryString ? `?${queryString}` : ""}`, {
+    method: "GET",
+    headers: {
+      "Content-Type": "application/json",
+    },
+    credentials: "include",
+  });
+
+  if (!response.ok) {
+    const error = await response.json();
+    throw new Error(error.error || "Failed to fetch tags");
+  }
+
+  return response.json();
+}
+
+// Rename tag (applies to all tasks using it)
+export async function renameTag(id: string, name: string): Promise<{ message: string; tag: Tag }> {
+  const response = await fetch(`/api/tags/${id}`, {
+    method: "PUT",
+    headers: {
+      "Content-Type": "application/json",
+    },
+    credentials: "include",
+    body: JSON.stringify({ name }),
+  });
+
+  if (!response.ok) {
+    const error = await response.json();
+    throw new Error(error.error || "Failed to rename tag");
+  }
+
+  return response.json();
+}
+
+// Merge tag into another one (moves all tasks, deletes the source)
+export async function mergeTag(id: string, targetId: string): Promise<{ message: string; tag: Tag }> {
+  const response = await fetch(`/api/tags/${id}/merge`, {
+    method: "POST",
+    headers: {
+      "Content-Type": "application/json",
+    },
+    credentials: "include",
+    body: JSON.stringify({ targetId }),
+  });
+
+  if (!response.ok) {
+    const error = await response.json();
+    throw new Error(error.error || "Failed to merge tags");
+  }
+
+  return response.json();
+}
+
+// Delete tag (removes it from all tasks)
+export async function deleteTag(id: string): Promise<{ message: string }> {
+  const response = await fetch(`/api/tags/${id}`, {
+    method: "DELETE",
+    headers: {
+      "Content-Type": "application/json",
+    },
+    credentials: "include",
+  });
+
+  if (!response.ok) {
+    const error = await response.json();
+    throw new Error(error.error || "Failed to delete tag");
+  }
+
+  return response.json();
+}
@@ -6,7 +6,13 @@ import type {
   TaskResponse,
   TaskDomain,
   TaskStatus,
+  TaskHistory,
   TimeFilter,
+  TaskSeries,
+  UpdateTaskSeriesRequest,
+  TaskQuery,
+  TaskDependency,
+  TaskDependencies,
 } from "@/types";
 
 const API_BASE = "/api";
@@ -51,15 +57,28 @@ async function fetchWithAuth(url: string, options: RequestInit): Promise<Respons
 }
 
 // Get all tasks with optional filters
+// tagFilter combines tags with AND/OR/NOT, e.g. "tag:errand -tag:waiting OR tag:urgent"
 export async function getTasks(
   domain?: TaskDomain,
   status?: TaskStatus,
-  timeFilter?: TimeFilter | null
+  timeFilter?: TimeFilter | null,
+  tagFilter?: string
 ): Promise<Task[]> {
+  const data = await getTaskPage({ domain, status, timeFilter, tagFilter });
+  return data.tasks || [];
+}
+
+// Get a sorted page of tasks with the counts per domain and status
+export async function getTaskPage(query: TaskQuery = {}): Promise<TasksResponse> {
   const params = new URLSearchParams();
-  if (domain) params.append("domain", domain);
-  if (status) params.append("status", status);
-  if (timeFilter) params.append("time_filter", timeFilter);
+  if (query.domain) params.append("domain", query.domain);
+  if (query.status) params.append("status", query.status);
+  if (query.timeFilter) params.append("time_filter", query.timeFilter);
+  if (query.dependency) params.append("dependency", query.dependency);
+  if (query.tagFilter) params.append("tags", query.tagFilter);
+  if (query.sort && query.sort.length > 0) params.append("sort", query.sort.join(","));
+  if (query.cursor) params.append("cursor", query.cursor);
+  if (query.limit) params.append("limit", String(query.limit));
 
   const queryString = params.toString();
   const url = `${API_BASE}/tasks${queryString ? `?${queryString}` : ""}`;
@@ -76,8 +95,7 @@ export async function getTasks(
     throw new Error(error.error || "Failed to fetch tasks");
   }
 
-  const data: TasksResponse = await response.json();
-  return data.tasks || [];
+  return response.json();
 }
 
 // Get single task
@@ -141,9 +159,10 @@ export async function updateTask(
   return data.task;
 }
 
-// Toggle task status (Todo <-> Done)
-export async function toggleTaskStatus(taskId: string): Promise<Task> {
-  const response = await fetchWithAuth(`${API_BASE}/tasks/${taskId}/status`, {
+// Toggle task status (open -> Done, Done/Cancelled -> Todo)
+export async function toggleTaskStatus(taskId: string, force = false): Promise<Task> {
+  const query = force ? "?force=true" : "";
+  const response = await fetchWithAuth(`${API_BASE}/tasks/${taskId}/status${query}`, {
     method: "PATCH",
     headers: {
       "Content-Type": "application/json",
@@ -159,6 +178,132 @@ export async function toggleTaskStatus(taskId: string): Promise<Task> {
   return data.task;
 }
 
+// Move a task to another status of the workflow
+export async function setTaskStatus(taskId: string, status: TaskStatus, force = false): Promise<Task> {
+  const query = force ? "?force=true" : "";
+  const response = await fetchWithAuth(`${API_BASE}/tasks/${taskId}/status${query}`, {
+    method: "PUT",
+    headers: {
+      "Content-Type": "application/json",
+    },
+    body: JSON.stringify({ status }),
+  });
+
+  if (!response.ok) {
+    const error = await response.json();
+    throw new Error(error.error || "Failed to update task status");
+  }
+
+  const data: TaskResponse = await response.json();
+  return data.task;
+}
+
+// Get the status history of a task with lead and cycle time
+export async function getTaskHistory(taskId: string): Promise<TaskHistory> {
+  const response = await fetchWithAuth(`${API_BASE}/tasks/${taskId}/history`, {
+    method: "GET",
+    headers: {
+      "Content-Type": "application/json",
+    },
+  });
+
+  if (!response.ok) {
+    const error = await response.json();
+    throw new Error(error.error || "Failed to fetch task history");
+  }
+
+  return response.json();
+}
+
+// Replace the tags of a task
+export async function setTaskTags(taskId: string, tags: string[]): Promise<Task> {
+  const response = await fetchWithAuth(`${API_BASE}/tasks/${taskId}/tags`, {
+    method: "PUT",
+    headers: {
+      "Content-Type": "application/json",
+    },
+    body: JSON.stringify({ tags }),
+  });
+
+  if (!response.ok) {
+    const error = await response.json();
+    throw new Error(error.error || "Failed to update task tags");
+  }
+
+  const data: TaskResponse = await response.json();
+  return data.task;
+}
+
+// Get the tasks a task is blocked by and the tasks it blocks
+export async function getTaskDependencies(taskId: string): Promise<TaskDependencies> {
+  const response = await fetchWithAuth(`${API_BASE}/tasks/${taskId}/dependencies`, {
+    method: "GET",
+    headers: {
+      "Content-Type": "application/json",
+    },
+  });
+
+  if (!response.ok) {
+    const error = await response.json();
+    throw new Error(error.error || "Failed to fetch task dependencies");
+  }
+
+  return response.json();
+}
+
+// Mark a task as blocked by another task (cycles are rejected)
+export async function addTaskDependency(taskId: string, blockedById: string): Promise<TaskDependency> {
+  const response = await fetchWithAuth(`${API_BASE}/tasks/${taskId}/dependencies`, {
+    method: "POST",
+    headers: {
+      "Content-Type": "application/json",
+    },
+    body: JSON.stringify({ blockedById }),
+  });
+
+  if (!response.ok) {
+    const error = await response.json();
+    throw new Error(error.error || "Failed to add task dependency");
+  }
+
+  const data: { dependency: TaskDependency } = await response.json();
+  return data.dependency;
+}
+
+// Remove a dependency between two tasks
+export async function removeTaskDependency(taskId: string, blockedById: string): Promise<void> {
+  const response = await fetchWithAuth(`${API_BASE}/tasks/${taskId}/dependencies/${blockedById}`, {
+    method: "DELETE",
+    headers: {
+      "Content-Type": "application/json",
+    },
+  });
+
+  if (!response.ok) {
+    const error = await response.json();
+    throw new Error(error.error || "Failed to remove task dependency");
+  }
+}
+
+// Reorder the subtasks of a task
+export async function reorderSubtasks(taskId: string, subtaskIds: string[]): Promise<Task[]> {
+  const response = await fetchWithAuth(`${API_BASE}/tasks/${taskId}/subtasks/order`, {
+    method: "PUT",
+    headers: {
+      "Content-Type": "application/json",
+    },
+    body: JSON.stringify({ subtaskIds }),
+  });
+
+  if (!response.ok) {
+    const error = await response.json();
+    throw new Error(error.error || "Failed to reorder subtasks");
+  }
+
+  const data: { subtasks: Task[] } = await response.json();
+  return data.subtasks;
+}
+
 // Delete task
 export async function deleteTask(taskId: string): Promise<void> {
   const response = await fetchWithAuth(`${API_BASE}/tasks/${taskId}`, {
@@ -172,4 +317,63 @@ export async function deleteTask(taskId: string): Promise<void> {
     const error = await response.json();
     throw new Error(error.error || "Failed to delete task");
   }
-}
\ No newline at end of file
+}
+
+// Get a recurring task series with its instances
+export async function getTaskSeries(seriesId: string): Promise<TaskSeries> {
+  const response = await fetchWithAuth(`${API_BASE}/tasks/series/${seriesId}`, {
+    method: "GET",
+    headers: {
+      "Content-Type": "application/json",
+    },
+  });
+
+  if (!response.ok) {
+    const error = await response.json();
+    throw new Error(error.error || "Failed to fetch task series");
+  }
+
+  const data: { series: TaskSeries } = await response.json();
+  return data.series;
+}
+
+// Update the open and future instances of a recurring task
+export async function updateTaskSeries(
+  seriesId: string,
+  updates: UpdateTaskSeriesRequest
+): Promise<TaskSeries> {
+  const response = await fetchWithAuth(`${API_BASE}/tasks/series/${seriesId}`, {
+    method: "PUT",
+    headers: {
+      "Content-Type": "application/json",
+    },
+    body: JSON.stringify(updates),
+  });
+
+  if (!response.ok) {
+    const error = await response.json();
+    throw new Error(error.error || "Failed to update task series");
+  }
+
+  const data: { series: TaskSeries } = await response.json();
+  return data.series;
+}
+
+// Stop a recurring task (deleteOpen also deletes its open instances)
+export async function stopTaskSeries(seriesId: string, deleteOpen = false): Promise<TaskSeries> {
+  const query = deleteOpen ? "?deleteOpen=true" : "";
+  const response = await fetchWithAuth(`${API_BASE}/tasks/series/${seriesId}/stop${query}`, {
+    method: "POST",
+    headers: {
+      "Content-Type": "application/json",
+    },
+  });
+
+  if (!response.ok) {
+    const error = await response.json();
+    throw new Error(error.error || "Failed to stop task series");
+  }
+
+  const data: { series: TaskSeries } = await response.json();
+  return data.series;
+}
@@ -0,0 +1,132 @@
+import { StartTimerRequest, TimeEntry, TimeEntryRequest, TimeRange, TimeReport } from "@/types";
+
+// Build the query string of a date range
+function rangeParams(range: TimeRange, extra: Record<string, string | undefined> = {}): string {
+  const params = new URLSearchParams();
+  if (range.from) params.append("from", range.from);
+  if (range.to) params.append("to", range.to);
+  if (range.timezone) params.append("timezone", range.timezone);
+  for (const [key, value] of Object.entries(extra)) {
+    if (value) params.append(key, value);
+  }
+
+  const queryString = params.toString();
+  return queryString ? `?${queryString}` : "";
+}
+
+// Send a request and return the time entry of the response
+async function entryRequest(url: string, method: string, fallbackError: string, body?: unknown): Promise<TimeEntry> {
+  const response = await fetch(url, {
+    method,
+    headers: {
+      "Content-Type": "application/json",
+    },
+    credentials: "include",
+    body: body === undefined ? undefined : JSON.stringify(body),
+  });
+
+  if (!response.ok) {
+    const error = await response.json();
+    throw new Error(error.error || fallbackError);
+  }
+
+  const data: { entry: TimeEntry } = await response.json();
+  return data.entry;
+}
+
+// Get the running timer (null if none is running)
+export async function getRunningTimer(): Promise<TimeEntry | null> {
+  const response = await fetch("/api/time/running", {
+    method: "GET",
+    headers: {
+      "Content-Type": "application/json",
+    },
+    credentials: "include",
+  });
+
+  if (!response.ok) {
+    const error = await response.json();
+    throw new Error(error.error || "Failed to fetch running timer");
+  }
+
+  const data: { entry: TimeEntry | null } = await response.json();
+  return data.entry;
+}
+
+// Start a timer on a task (stops the running timer)
+export async function startTimer(request: StartTimerRequest): Promise<TimeEntry> {
+  return entryRequest("/api/time/start", "POST", "Failed to start timer", request);
+}
+
+// Stop the running timer
+export async function stopTimer(): Promise<TimeEntry> {
+  return entryRequest("/api/time/stop", "POST", "Failed to stop timer");
+}
+
+// Get time entries in a date range (optionally of one task)
+export async function getTimeEntries(range: TimeRange = {}, taskId?: string): Promise<TimeEntry[]> {
+  const response = await fetch(`/api/time/entries${rangeParams(range, { taskId })}`, {
+    method: "GET",
+    headers: {
+      "Content-Type": "application/json",
+    },
+    credentials: "include",
+  });
+
+  if (!response.ok) {
+    const error = await response.json();
+    throw new Error(error.error || "Failed to fetch time entries");
+  }
+
+  const data: { entries: TimeEntry[] } = await response.json();
+  return data.entries || [];
+}
+
+// Add a manual time entry
+export async function createTimeEntry(request: TimeEntryRequest): Promise<TimeEntry> {
+  return entryRequest("/api/time/entries", "POST", "Failed to create time entry", request);
+}
+
+// Edit a time entry
+export async function updateTimeEntry(id: string, request: TimeEntryRequest): Promise<TimeEntry> {
+  return entryRequest(`/api/time/entries/${id}`, "PUT", "Failed to update time entry", request);
+}
+
+// Delete a time entry
+export async function deleteTimeEntry(id: string): Promise<void> {
+  const response = await fetch(`/api/time/entries/${id}`, {
+    method: "DELETE",
+    headers: {
+      "Content-Type": "application/json",
+    },
+    credentials: "include",
+  });
+
+  if (!response.ok) {
+    const error = await response.json();
+    throw new Error(error.error || "Failed to delete time entry");
+  }
+}
+
+// Get the time report of a date range
+export async function getTimeReport(range: TimeRange = {}): Promise<TimeReport> {
+  const response = await fetch(`/api/time/report${rangeParams(range)}`, {
+    method: "GET",
+    headers: {
+      "Content-Type": "application/json",
+    },
+    credentials: "include",
+  });
+
+  if (!response.ok) {
+    const error = await response.json();
+    throw new Error(error.error || "Failed to fetch time report");
+  }
+
+  return response.json();
+}
+
+// URL of the CSV export of a date range (for download links)
+export function getTimeExportUrl(range: TimeRange = {}): string {
+  return `/api/time/export${rangeParams(range)}`;
+}
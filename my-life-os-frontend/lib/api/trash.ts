@@ -0,0 +1,74 @@
+import { Trash, TrashType } from "@/types";
+
+// Get trashed items (all types without a type)
+export async function getTrash(type?: TrashType): Promise<Trash> {
+  const query = type ? `?type=${type}` : "";
+  const response = await fetch(`/api/trash${query}`, {
+    method: "GET",
+    headers: {
+      "Content-Type": "application/json",
+    },
+    credentials: "include",
+  });
+
+  if (!response.ok) {
+    const error = await response.json();
+    throw new Error(error.error || "Failed to fetch trash");
+  }
+
+  return response.json();
+}
+
+// Restore a trashed item with everything deleted together with it
+export async function restoreTrashItem(type: TrashType, id: string): Promise<{ message: string }> {
+  const response = await fetch(`/api/trash/${type}/${id}/restore`, {
+    method: "POST",
+    headers: {
+      "Content-Type": "application/json",
+    },
+    credentials: "include",
+  });
+
+  if (!response.ok) {
+    const error = await response.json();
+    throw new Error(error.error || "Failed to restore item");
+  }
+
+  return response.json();
+}
+
+// Delete a trashed item permanently
+export async function deleteTrashItem(type: TrashType, id: string): Promise<{ message: string }> {
+  const response = await fetch(`/api/trash/${type}/${id}`, {
+    method: "DELETE",
+    headers: {
+      "Content-Type": "application/json",
+    },
+    credentials: "include",
+  });
+
+  if (!response.ok) {
+    const error = await response.json();
+    throw new Error(error.error || "Failed to delete item");
+  }
+
+  return response.json();
+}
+
+// Delete all trashed items permanently
+export async function emptyTrash(): Promise<{ message: string }> {
+  const response = await fetch("/api/trash", {
+    method: "DELETE",
+    headers: {
+      "Content-Type": "application/json",
+    },
+    credentials: "include",
+  });
+
+  if (!response.ok) {
+    const error = await response.json();
+    throw new Error(error.error || "Failed to empty trash");
+  }
+
+  return response.json();
+}
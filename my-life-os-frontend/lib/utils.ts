@@ -49,4 +49,29 @@ export function formatDeadline(deadline: string): { text: string; isRed: boolean
     });
     return { text: dateText, isRed: false, showOverdue: false };
   }
-}
\ No newline at end of file
+}
+const RECURRENCE_UNITS: Record<string, string> = {
+  DAILY: "day",
+  WEEKLY: "week",
+  MONTHLY: "month",
+  YEARLY: "year",
+};
+
+// Formats an RRULE (e.g. "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH") as "Every 2 weeks on TU, TH"
+export function formatRecurrenceRule(rule: string | null): string {
+  if (!rule) return "";
+
+  const parts = Object.fromEntries(
+    rule.split(";").map((part) => part.split("=") as [string, string])
+  );
+
+  const unit = RECURRENCE_UNITS[parts.FREQ] ?? parts.FREQ?.toLowerCase();
+  const interval = Number(parts.INTERVAL ?? "1");
+  let text = interval > 1 ? `Every ${interval} ${unit}s` : `Every ${unit}`;
+
+  if (parts.BYDAY) text += ` on ${parts.BYDAY.split(",").join(", ")}`;
+  if (parts.BYMONTHDAY) text += ` on day ${parts.BYMONTHDAY}`;
+  if (parts.COUNT) text += `, ${parts.COUNT} times`;
+
+  return text;
+}
@@ -7,6 +7,7 @@ export interface User {
   email: string;
   name: string;
   timezone?: string;
+  dayRolloverHour?: number; // Routine days end at this hour (0-12)
 }
 
 export interface SetupRequest {
@@ -26,6 +27,11 @@ export interface AuthResponse {
   // Note: Tokens are in HttpOnly cookies, not in response body!
 }
 
+export interface UpdateSettingsRequest {
+  timezone?: string; // IANA name, e.g. "Europe/Berlin"
+  dayRolloverHour?: number;
+}
+
 export interface StatusResponse {
   needsSetup: boolean;
   version: string;
@@ -46,16 +52,48 @@ export interface ApiError {
 // ============================================
 
 export type TaskPriority = "Low" | "Medium" | "High";
-export type TaskStatus = "Todo" | "Done";
-export type TaskDomain = 
-  | "Work"
-  | "University"
-  | "Coding Project"
-  | "Personal Project"
-  | "Goals"
-  | "Finances"
-  | "Household"
-  | "Health";
+// Done and Cancelled are terminal (the task needs no more work)
+export type TaskStatus = "Todo" | "In Progress" | "Waiting" | "Blocked" | "Done" | "Cancelled";
+// Name of one of the user's domains (see Domain)
+export type TaskDomain = string;
+
+// User-defined domain shared by tasks and events
+export interface Domain {
+  id: string;
+  userId: string;
+  name: string;
+  color: string; // Hex color (#RRGGBB)
+  icon: string; // Lucide icon name
+  archived: boolean;
+  createdAt: string;
+  updatedAt: string;
+}
+
+export interface CreateDomainRequest {
+  name: string;
+  color?: string;
+  icon?: string;
+}
+
+export interface UpdateDomainRequest {
+  name: string;
+  color?: string;
+  icon?: string;
+  archived: boolean;
+}
+
+// Free-form task label (lowercase, unique per user)
+export interface Tag {
+  id: string;
+  userId: string;
+  name: string;
+  createdAt: string;
+  updatedAt: string;
+}
+
+export interface TagUsage extends Tag {
+  usageCount: number; // Number of tasks using the tag
+}
 
 // Time filters for task filtering
 export type TimeFilter = "long_term" | "today" | "tomorrow" | "next_week" | "next_month" | "overdue";
@@ -69,16 +107,46 @@ export interface Task {
   status: TaskStatus;
   domain: TaskDomain;
   deadline: string | null; // ISO 8601 format
+  startedAt: string | null; // First time the task was In Progress
+  completedAt: string | null; // Set while the task is Done
+  parentTaskId: string | null;
+  position: number;
+  subtasks?: Task[];
+  seriesId: string | null; // Recurring task series (null = not recurring)
+  tags?: Tag[];
   createdAt: string;
   updatedAt: string;
+  deletedAt?: string | null; // Set while the item is in the trash
+}
+
+// One transition of a task in the status workflow
+export interface TaskStatusChange {
+  id: string;
+  taskId: string;
+  userId: string;
+  fromStatus: TaskStatus;
+  toStatus: TaskStatus;
+  changedAt: string;
+}
+
+// Status history of a task with its workflow metrics
+export interface TaskHistory {
+  changes: TaskStatusChange[]; // Oldest first
+  startedAt: string | null;
+  completedAt: string | null;
+  leadTimeSeconds: number | null; // Created to completed (null while not Done)
+  cycleTimeSeconds: number | null; // Started to completed (null while not Done or never started)
 }
 
 export interface CreateTaskRequest {
   title: string;
   description?: string;
   priority: TaskPriority;
-  domain: TaskDomain;
+  domain?: TaskDomain; // Subtasks inherit the domain of their parent
   deadline?: string | null;
+  parentTaskId?: string;
+  recurrence?: TaskRecurrence; // Requires a deadline
+  tags?: string[]; // Unknown tags are created
 }
 
 export interface UpdateTaskRequest {
@@ -87,35 +155,121 @@ export interface UpdateTaskRequest {
   priority?: TaskPriority;
   domain?: TaskDomain;
   deadline?: string | null;
+  tags?: string[]; // Replaces all tags (omit to keep them)
+}
+
+export interface TaskCounts {
+  total: number;
+  domains: Record<string, number>; // Ignores the domain filter
+  statuses: Record<string, number>; // Ignores the status filter
 }
 
 export interface TasksResponse {
   tasks: Task[];
+  nextCursor?: string; // Missing on the last page
+  counts: TaskCounts;
+}
+
+// Task sort key, "-" prefix for descending
+export type TaskSortKey = "deadline" | "-deadline" | "priority" | "-priority" | "createdAt" | "-createdAt";
+
+// Open tasks with (blocked) or without (actionable) open blockers
+export type TaskDependencyFilter = "blocked" | "actionable";
+
+export interface TaskQuery {
+  domain?: TaskDomain;
+  status?: TaskStatus;
+  timeFilter?: TimeFilter | null;
+  dependency?: TaskDependencyFilter;
+  tagFilter?: string; // e.g. "tag:errand -tag:waiting OR tag:urgent"
+  sort?: TaskSortKey[]; // Default: deadline, -createdAt
+  cursor?: string; // nextCursor of the previous page
+  limit?: number; // Page size (omit for all tasks)
+}
+
+// Task "taskId" is blocked by task "blockedById" until that one is done
+export interface TaskDependency {
+  taskId: string;
+  blockedById: string;
+  createdAt: string;
+}
+
+export interface TaskDependencies {
+  blockedBy: Task[];
+  blocking: Task[];
 }
 
 export interface TaskResponse {
   task: Task;
+  nextTask?: Task; // Next instance created when a recurring task is completed
+  blockers?: Task[]; // Open blockers of a task that was completed anyway
+  warnings?: string[];
   message?: string;
 }
 
+export type TaskRecurrenceType = "schedule" | "after_completion";
+
+export interface TaskRecurrence {
+  type: TaskRecurrenceType;
+  recurrenceRule?: string; // RRULE, schedule only (e.g. "FREQ=MONTHLY;BYMONTHDAY=1")
+  intervalDays?: number; // after_completion only
+  timezone?: string; // Defaults to the user's timezone
+}
+
+export interface TaskSeries {
+  id: string;
+  userId: string;
+  recurrenceType: TaskRecurrenceType;
+  recurrenceRule: string | null;
+  intervalDays: number;
+  startDate: string;
+  timezone: string;
+  stoppedAt: string | null;
+  tasks?: Task[];
+  createdAt: string;
+  updatedAt: string;
+}
+
+export interface UpdateTaskSeriesRequest {
+  title?: string;
+  description?: string; // Omit to keep each instance's description
+  priority?: TaskPriority;
+  domain?: TaskDomain;
+  recurrence?: TaskRecurrence; // Omit to keep the current schedule
+}
+
 // ============================================
 // ROUTINE TYPES (Sprint 4)
 // ============================================
 
-export type RoutineFrequency = "Daily" | "Weekly" | "Monthly" | "Quarterly" | "Yearly";
+export type RoutineFrequency = "Daily" | "Weekly" | "Monthly" | "Quarterly" | "Yearly" | "Interval" | "Quota";
 export type RoutineTimeType = "AM" | "PM" | "AllDay" | "Specific";
+export type RoutineMonthlyRule = "Day" | "LastDay" | "NthWeekday";
+export type RoutinePeriod = "Week" | "Month" | "Quarter";
 
 export interface YearlyDate {
   month: number; // 1-12
   day: number;   // 1-31
 }
 
-export interface Routine {
+// Extended schedule of a routine
+export interface RoutineSchedule {
+  weekdays?: number[];       // 0-6 for Weekly on several days (instead of weekday)
+  intervalDays?: number;     // N for Interval (every N days)
+  startDate?: string;        // YYYY-MM-DD anchor for Interval (default: created day)
+  monthlyRule?: RoutineMonthlyRule; // Monthly: dayOfMonth (Day), last day, nth weekday
+  weekOfMonth?: number;      // 1-5 or -1 (last) for NthWeekday, with weekday
+  quotaCount?: number;       // N for Quota (N times per period, any days)
+  quotaPeriod?: RoutinePeriod;
+  completionWindow?: RoutinePeriod | ""; // Non-daily: done on any day of the period ("" = exact days)
+}
+
+export interface Routine extends RoutineSchedule {
   id: string;
   userId: string;
   title: string;
   frequency: RoutineFrequency;
-  weekday?: number;          // 0-6 for Weekly (0=Sunday)
+  weekday?: number;          // 0-6 for Weekly and NthWeekday (0=Sunday)
   dayOfMonth?: number;       // 1-31 for Monthly
   quarterlyDay?: number;     // 1-31 for Quarterly
   yearlyDate?: YearlyDate;   // {month, day} for Yearly
@@ -127,9 +281,10 @@ export interface Routine {
   longestStreak: number;
   createdAt: string;
   updatedAt: string;
+  deletedAt?: string | null; // Set while the item is in the trash
 }
 
-export interface CreateRoutineRequest {
+export interface CreateRoutineRequest extends RoutineSchedule {
   title: string;
   frequency: RoutineFrequency;
   weekday?: number;
@@ -142,7 +297,7 @@ export interface CreateRoutineRequest {
   specificTime?: string;
 }
 
-export interface UpdateRoutineRequest {
+export interface UpdateRoutineRequest extends RoutineSchedule {
   title?: string;
   frequency?: RoutineFrequency;
   weekday?: number;
@@ -169,27 +324,75 @@ export interface RoutineCompletion {
   routineId: string;
   userId: string;
   completedAt: string;      // Date only (YYYY-MM-DD)
-  status: "completed" | "skipped";
+  status: RoutineCompletionStatus;
   createdAt: string;
 }
 
+export type RoutineCompletionStatus = "completed" | "skipped";
+
+// Log a completion/skip for today or a past date (all fields required when creating)
+export interface RoutineCompletionRequest {
+  date?: string; // YYYY-MM-DD
+  status?: RoutineCompletionStatus;
+}
+
+// Changed completion with the routine's recomputed streaks
+export interface RoutineCompletionResponse {
+  completion: RoutineCompletion;
+  routine: Routine;
+}
+
+// Heatmap cell: how the routines due on a day went
+export interface RoutineDay {
+  date: string; // YYYY-MM-DD
+  completed: number;
+  skipped: number;
+  missed: number; // Scheduled but neither completed nor skipped
+}
+
+// Completion rate of a week, month or weekday (skipped days count as due)
+export interface RoutineRate {
+  period?: string; // Week start (YYYY-MM-DD, Monday) or month (YYYY-MM)
+  weekday?: number; // 0-6 (0=Sunday)
+  due: number;
+  completed: number;
+  rate: number; // 0-1
+}
+
+export interface StreakWindow {
+  length: number;
+  start?: string; // YYYY-MM-DD
+  end?: string; // YYYY-MM-DD
+}
+
+// Statistics of one routine or of all routines over a range
+export interface RoutineStats {
+  routineId?: string; // Missing for all routines
+  from: string;
+  to: string;
+  heatmap: RoutineDay[];
+  due: number;
+  completed: number;
+  skipped: number;
+  missed: number;
+  weeks: RoutineRate[];
+  months: RoutineRate[];
+  weekdays: RoutineRate[]; // Sunday to Saturday
+  bestWeekdays: number[];
+  longestStreak: StreakWindow;
+  trend: {
+    rate: number;
+    previousRate: number | null; // Period of the same length before the range
+    change: number | null;
+  };
+}
+
 // ============================================
 // SCHEDULE/EVENT TYPES (Sprint 5)
 // ============================================
 
-export type EventDomain = 
-  | "Work"
-  | "University"
-  | "Personal"
-  | "Coding Time"
-  | "Study"
-  | "Health"
-  | "Social"
-  | "Holidays"
-  | "Travel"
-  | "Maintenance"
-  | "Entertainment"
-  | "Family";
+// Name of one of the user's domains (see Domain)
+export type EventDomain = string;
 
 export type RecurrenceType = "daily" | "weekly" | "monthly" | "yearly";
 
@@ -206,14 +409,16 @@ export interface Event {
   startDate: string;        // ISO 8601 format
   endDate: string | null;   // ISO 8601 format, null = All Day
   allDay: boolean;
+  timezone: string;         // IANA timezone the event is planned in
   domain: EventDomain;
   isRecurring: boolean;
-  recurrenceType: RecurrenceType | null;
-  recurrenceEnd: string | null;  // ISO 8601 format, null = never ends
-  recurrenceDays: string | null; // JSON array: ["monday","wednesday"]
+  recurrenceRule: string | null; // RFC 5545 RRULE: "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH"
+  recurrenceEnd: string | null;  // ISO 8601 format (last occurrence), null = never ends
   hideFromAgenda: boolean;
+  parentEventId: string | null; // Series this event was split from ("following" edit)
   createdAt: string;
   updatedAt: string;
+  deletedAt?: string | null; // Set while the item is in the trash
 }
 
 export interface EventException {
@@ -235,12 +440,15 @@ export interface CreateEventRequest {
   startDate: string;        // ISO 8601 format
   endDate?: string | null;  // ISO 8601 format
   allDay?: boolean;
+  timezone?: string;        // IANA timezone, defaults to the user's timezone
   domain: EventDomain;
   isRecurring?: boolean;
+  recurrenceRule?: string | null; // Takes precedence over recurrenceType/recurrenceDays/recurrenceEnd
   recurrenceType?: RecurrenceType | null;
   recurrenceEnd?: string | null;
   recurrenceDays?: string | null; // JSON array: ["monday","wednesday"]
   hideFromAgenda?: boolean;
+  allowConflict?: boolean;  // Create even if it overlaps existing events
 }
 
 export interface UpdateEventRequest {
@@ -251,10 +459,23 @@ export interface UpdateEventRequest {
   endDate?: string | null;
   allDay?: boolean;
   domain: EventDomain;
+  recurrenceRule?: string | null;
   recurrenceType?: RecurrenceType | null;
   recurrenceEnd?: string | null;
   recurrenceDays?: string | null;
   hideFromAgenda?: boolean;
+  allowConflict?: boolean;
+}
+
+// Existing occurrence that overlaps a planned event (409 response of create/update)
+export interface EventConflict {
+  eventId: string;
+  title: string;
+  occurrenceDate: string;
+  endDate: string;
+  isRecurring: boolean;
+  plannedStart: string;   // Occurrence of the planned event that collides
+  overlapMinutes: number;
 }
 
 export interface DeleteEventRequest {
@@ -271,6 +492,210 @@ export interface EventResponse {
   message?: string;
 }
 
+// ============================================
+// AGENDA TYPES
+// ============================================
+
+export type AgendaView = "day" | "week";
+
+export type AgendaItemType = "event" | "routine" | "task";
+
+export interface AgendaItem {
+  type: AgendaItemType;
+  id: string;
+  title: string;
+  domain?: string; // Events and tasks
+  start: string | null; // Event start, routine time or task deadline
+  end: string | null; // Events only
+  allDay: boolean;
+  isRecurring?: boolean; // Events
+  timeType?: RoutineTimeType; // Routines
+  priority?: TaskPriority; // Tasks
+  overdue?: boolean; // Tasks
+  status?: string; // Routines: pending/completed/skipped - Tasks: see TaskStatus
+}
+
+export interface AgendaLoad {
+  busyMinutes: number;
+  eventCount: number;
+  routineCount: number;
+  routinesDone: number;
+  taskCount: number;
+  openTaskCount: number;
+  overdueCount: number;
+  highPriorityDue: number;
+}
+
+export interface AgendaDay {
+  date: string; // YYYY-MM-DD
+  start: string;
+  end: string;
+  items: AgendaItem[];
+  load: AgendaLoad;
+}
+
+export interface Agenda {
+  view: AgendaView;
+  timezone: string;
+  days: AgendaDay[];
+}
+
+// ============================================
+// QUICK-ADD TYPES
+// ============================================
+
+export type QuickAddType = "task" | "event";
+
+export type QuickAddTokenKind = "priority" | "domain" | "tag" | "recurrence" | "time" | "date";
+
+// Recognized part of the quick-add text
+export interface QuickAddToken {
+  text: string;
+  kind: QuickAddTokenKind;
+}
+
+// Interpretation of a quick-add text (shown for confirmation)
+export interface QuickAddInterpretation {
+  type: QuickAddType;
+  title: string;
+  priority?: TaskPriority;
+  domain?: string;
+  tags?: string[]; // Tasks only
+  deadline?: string; // Tasks
+  start?: string; // Events
+  end?: string; // Events with a time
+  allDay: boolean;
+  recurrenceRule?: string; // RFC 5545 RRULE
+  tokens: QuickAddToken[];
+}
+
+export interface QuickAddRequest {
+  text: string; // e.g. "Submit thesis draft fri 17:00 !high #University"
+  type?: QuickAddType; // Detected from the text if omitted (time ranges are events)
+  timezone?: string; // Defaults to the user's timezone
+  domain?: string; // Fallback if the text names no domain
+  allowConflict?: boolean; // Events only
+}
+
+export interface QuickAddResponse {
+  message: string;
+  interpretation: QuickAddInterpretation;
+  task?: Task;
+  event?: Event;
+}
+
+// ============================================
+// TIME TRACKING TYPES
+// ============================================
+
+// Time spent on a task (timers use server timestamps)
+export interface TimeEntry {
+  id: string;
+  userId: string;
+  taskId: string;
+  startedAt: string;
+  endedAt: string | null; // null = timer is running
+  note: string;
+  task?: Task;
+  createdAt: string;
+  updatedAt: string;
+}
+
+export interface StartTimerRequest {
+  taskId: string;
+  note?: string;
+}
+
+export interface TimeEntryRequest {
+  taskId: string;
+  startedAt: string; // ISO 8601
+  endedAt?: string; // ISO 8601, omitted for a running timer
+  note?: string;
+}
+
+export interface TimeTotal {
+  key: string; // Day or week start (YYYY-MM-DD), project or task ID ("" = no project), domain name
+  label: string;
+  seconds: number;
+}
+
+// Time per day, week, project, domain and top-level task (subtasks roll up to their parents)
+export interface TimeReport {
+  from: string; // YYYY-MM-DD
+  to: string; // YYYY-MM-DD (inclusive)
+  timezone: string;
+  totalSeconds: number;
+  days: TimeTotal[];
+  weeks: TimeTotal[];
+  projects: TimeTotal[];
+  domains: TimeTotal[];
+  tasks: TimeTotal[];
+}
+
+// Date range of time entry lists, reports and exports (defaults to the current week)
+export interface TimeRange {
+  from?: string; // YYYY-MM-DD
+  to?: string; // YYYY-MM-DD (inclusive)
+  timezone?: string;
+}
+
+// ============================================
+// TRASH TYPES
+// ============================================
+
+export type TrashType = "tasks" | "routines" | "projects" | "events";
+
+// Trashed items per type, most recently deleted first (null for types not requested)
+export interface Trash {
+  tasks: Task[] | null; // Subtasks deleted with their parent are restored with it
+  routines: Routine[] | null;
+  projects: Project[] | null;
+  events: Event[] | null;
+  retentionDays: number; // Items are purged this many days after deletion
+}
+
+// ============================================
+// BOARD TYPES
+// ============================================
+
+// Manually ordered task lists: the key is the domain name, project ID or status
+export type TaskListType = "domain" | "project" | "status";
+
+// Place a task between two neighbours (none = top of the list)
+export interface MoveTaskRequest {
+  list: TaskListType;
+  key: string; // Moving into another status column changes the status
+  afterId?: string;
+  beforeId?: string;
+}
+
+export interface MoveTaskResult {
+  task: Task;
+  nextTask?: Task; // Next instance when the move completed a recurring task
+  rank: string;
+  warnings: string[]; // Exceeded WIP limits
+}
+
+export interface ProjectWIPLimit {
+  projectId: string;
+  status: TaskStatus;
+  limit: number;
+}
+
+export interface BoardColumn {
+  status: TaskStatus;
+  tasks: Task[];
+  count: number;
+  wipLimit: number; // 0 = no limit
+  overLimit: boolean;
+}
+
+export interface ProjectBoard {
+  projectId: string;
+  columns: BoardColumn[];
+  warnings: string[];
+}
+
 // ============================================
 // PROJECT MANAGER TYPES
 // ============================================
@@ -332,8 +757,10 @@ export interface Project {
   repositoryUrl?: string;
   techStack: TechStackItem[];
   tasks: ProjectTask[];
+  wipLimits: ProjectWIPLimit[];
   createdAt: string;
   updatedAt: string;
+  deletedAt?: string | null; // Set while the item is in the trash
 }
 
 export interface CreateProjectRequest {
@@ -361,6 +788,13 @@ export interface ProjectTask {
   task?: Task; // Populated from backend
 }
 
+// Project tasks with their dependencies (for drawing the graph)
+export interface ProjectTaskGraph {
+  tasks: ProjectTask[];
+  dependencies: TaskDependency[]; // Dependencies touching a project task or subtask
+  externalTasks: Task[]; // Tasks outside the project at the other end of a dependency
+}
+
 export interface AssignTaskRequest {
   taskId: string;
 }
\ No newline at end of file